@@ -11,6 +11,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/aws/aws-sdk-go/service/kms"
@@ -44,6 +46,7 @@ type Clients struct {
 	CloudFormation *cloudformation.CloudFormation
 	EC2            ec2iface.EC2API
 	ELB            elbiface.ELBAPI
+	ELBv2          elbv2iface.ELBV2API
 	IAM            iamiface.IAMAPI
 	KMS            kmsiface.KMSAPI
 	Route53        *route53.Route53
@@ -97,6 +100,7 @@ func newClients(session *session.Session, configs ...*aws.Config) Clients {
 		CloudFormation: cloudformation.New(session, configs...),
 		EC2:            ec2.New(session, configs...),
 		ELB:            elb.New(session, configs...),
+		ELBv2:          elbv2.New(session, configs...),
 		IAM:            iam.New(session, configs...),
 		KMS:            kms.New(session, configs...),
 		Route53:        route53.New(session, configs...),
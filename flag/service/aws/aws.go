@@ -2,9 +2,13 @@ package aws
 
 import (
 	"github.com/giantswarm/aws-operator/flag/service/aws/accesskey"
+	"github.com/giantswarm/aws-operator/flag/service/aws/etcdbackup"
+	"github.com/giantswarm/aws-operator/flag/service/aws/image"
 	"github.com/giantswarm/aws-operator/flag/service/aws/loggingbucket"
 	"github.com/giantswarm/aws-operator/flag/service/aws/route53"
+	"github.com/giantswarm/aws-operator/flag/service/aws/transitgateway"
 	"github.com/giantswarm/aws-operator/flag/service/aws/trustedadvisor"
+	"github.com/giantswarm/aws-operator/flag/service/aws/volumesnapshot"
 )
 
 type AWS struct {
@@ -12,15 +16,20 @@ type AWS struct {
 	AdvancedMonitoringEC2  string
 	AvailabilityZones      string
 	Encrypter              string
+	EtcdBackup             etcdbackup.EtcdBackup
 	HostAccessKey          accesskey.AccessKey
+	Image                  image.Image
 	IncludeTags            string
 	LoggingBucket          loggingbucket.LoggingBucket
 	PodInfraContainerImage string
 	PubKeyFile             string
 	Region                 string
+	RollbackSkipResources  string
 	Route53                route53.Route53
 	RouteTables            string
 	S3AccessLogsExpiration string
+	TransitGateway         transitgateway.TransitGateway
 	TrustedAdvisor         trustedadvisor.TrustedAdvisor
 	VaultAddress           string
+	VolumeSnapshot         volumesnapshot.VolumeSnapshot
 }
@@ -0,0 +1,5 @@
+package etcdbackup
+
+type EtcdBackup struct {
+	Expiration string
+}
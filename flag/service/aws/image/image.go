@@ -0,0 +1,7 @@
+package image
+
+type Image struct {
+	Name     string
+	Owner    string
+	Resolver string
+}
@@ -0,0 +1,5 @@
+package transitgateway
+
+type TransitGateway struct {
+	ID string
+}
@@ -0,0 +1,6 @@
+package volumesnapshot
+
+type VolumeSnapshot struct {
+	DockerVolume string
+	Retention    string
+}
@@ -16,4 +16,10 @@ type Network struct {
 	// PublicSubnetMaskBits is number of bits in guest cluster public subnet
 	// mask. This must be smaller than SubnetMaskBits.
 	PublicSubnetMaskBits string
+
+	// Ranges are named network segments from which IPAM allocates subnets for
+	// guest clusters requesting them, given as comma separated list of
+	// name=CIDR pairs. Guest clusters not requesting a named network range get
+	// their subnets allocated from CIDR.
+	Ranges string
 }
@@ -113,12 +113,20 @@ func mainError() error {
 	daemonCommand.PersistentFlags().String(f.Service.AWS.AccessKey.Session, "", "Session token of the AWS access key for the  account to create guest clusters in. (Can be empty)")
 	daemonCommand.PersistentFlags().StringSlice(f.Service.AWS.AvailabilityZones, []string{}, "Availability zones as a slice.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.Encrypter, "kms", "Encryption backend to use.")
+	daemonCommand.PersistentFlags().Int(f.Service.AWS.EtcdBackup.Expiration, 30, "Number of days etcd snapshots of tenant clusters are kept in their S3 buckets. If 0, etcd snapshots are kept forever.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.HostAccessKey.ID, "", "ID of the AWS access key for the host cluster account. If empty, guest cluster account is used.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.HostAccessKey.Secret, "", "Secret of the AWS access key for the host cluster account. If empty, guest cluster account is used.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.HostAccessKey.Session, "", "Session token of the AWS access key for the host cluster account. If empty, guest cluster token is used.")
+	daemonCommand.PersistentFlags().String(f.Service.AWS.Image.Name, "", "Name filter of the EC2 AMIs tenant cluster nodes are launched from. Required for the filter image resolver.")
+	daemonCommand.PersistentFlags().String(f.Service.AWS.Image.Owner, "", "AWS account ID owning the EC2 AMIs tenant cluster nodes are launched from. Required for the filter image resolver.")
+	daemonCommand.PersistentFlags().String(f.Service.AWS.Image.Resolver, "static", "Resolver of the EC2 AMIs tenant cluster nodes are launched from. Either static or filter.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.Region, "", "Region for checking for orphan AWS resources.")
+	daemonCommand.PersistentFlags().StringSlice(f.Service.AWS.RollbackSkipResources, []string{}, "Logical IDs of tenant cluster control plane stack resources which may be skipped when continuing failed update rollbacks.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.RouteTables, "", "Names of the public route tables in control plane separated by commas, required for accessing public ELBs from tenant nodes.")
+	daemonCommand.PersistentFlags().String(f.Service.AWS.TransitGateway.ID, "", "ID of the Transit Gateway tenant cluster VPCs are attached to for reaching the control plane. The Transit Gateway must be shared with the tenant cluster accounts and accept attachments automatically. If empty, tenant cluster VPCs are peered with the control plane VPC.")
 	daemonCommand.PersistentFlags().String(f.Service.AWS.VaultAddress, "", "Server address for Vault encryption.")
+	daemonCommand.PersistentFlags().Bool(f.Service.AWS.VolumeSnapshot.DockerVolume, false, "Whether to snapshot the docker volume of tenant cluster masters in addition to the etcd volume before master updates.")
+	daemonCommand.PersistentFlags().Int(f.Service.AWS.VolumeSnapshot.Retention, 3, "Number of EBS snapshots kept per tenant cluster master volume. If 0, no snapshots are created before master updates.")
 
 	daemonCommand.PersistentFlags().String(f.Service.RegistryDomain, "quay.io", "Image registry.")
 
@@ -148,6 +156,7 @@ func mainError() error {
 	daemonCommand.PersistentFlags().Int(f.Service.Installation.Guest.IPAM.Network.SubnetMaskBits, 24, "Number of bits in guest cluster subnet network mask.")
 	daemonCommand.PersistentFlags().Int(f.Service.Installation.Guest.IPAM.Network.PrivateSubnetMaskBits, 25, "Number of bits in guest cluster private subnet network mask. This must be smaller than SubnetMaskBits.")
 	daemonCommand.PersistentFlags().Int(f.Service.Installation.Guest.IPAM.Network.PublicSubnetMaskBits, 25, "Number of bits in guest cluster public subnet network mask. This must be smaller than SubnetMaskBits.")
+	daemonCommand.PersistentFlags().String(f.Service.Installation.Guest.IPAM.Network.Ranges, "", "Named guest cluster network segments from which IPAM allocates subnets, given as comma separated list of name=CIDR pairs.")
 	daemonCommand.PersistentFlags().String(f.Service.Installation.Guest.Kubernetes.API.Auth.Provider.OIDC.ClientID, "", "OIDC authorization provider ClientID.")
 	daemonCommand.PersistentFlags().String(f.Service.Installation.Guest.Kubernetes.API.Auth.Provider.OIDC.IssuerURL, "", "OIDC authorization provider IssuerURL.")
 	daemonCommand.PersistentFlags().String(f.Service.Installation.Guest.Kubernetes.API.Auth.Provider.OIDC.UsernameClaim, "", "OIDC authorization provider UsernameClaim.")
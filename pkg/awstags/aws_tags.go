@@ -3,6 +3,7 @@ package awstags
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
@@ -20,6 +21,19 @@ func NewCloudFormation(tags map[string]string) []*cloudformation.Tag {
 	return ts
 }
 
+func NewEC2(tags map[string]string) []*ec2.Tag {
+	var ts []*ec2.Tag
+	for k, v := range tags {
+		t := &ec2.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(v),
+		}
+		ts = append(ts, t)
+	}
+
+	return ts
+}
+
 func NewKMS(tags map[string]string) []*kms.Tag {
 	var ts []*kms.Tag
 	for k, v := range tags {
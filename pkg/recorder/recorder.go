@@ -0,0 +1,161 @@
+package recorder
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/scheme"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+type Config struct {
+	K8sClient kubernetes.Interface
+	Logger    micrologger.Logger
+
+	// Component is the source component of the emitted events, e.g.
+	// aws-operator.
+	Component string
+}
+
+type Recorder struct {
+	k8sClient kubernetes.Interface
+	logger    micrologger.Logger
+
+	component string
+}
+
+func New(config Config) (*Recorder, error) {
+	if config.K8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.K8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.Component == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Component must not be empty", config)
+	}
+
+	r := &Recorder{
+		k8sClient: config.K8sClient,
+		logger:    config.Logger,
+
+		component: config.Component,
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) Emit(ctx context.Context, obj runtime.Object, eventType, reason, message string) {
+	err := r.emit(obj, eventType, reason, message)
+	if err != nil {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("failed to emit event with reason %#q", reason), "stack", fmt.Sprintf("%#v", err))
+	}
+}
+
+func (r *Recorder) emit(obj runtime.Object, eventType, reason, message string) error {
+	ref, err := newObjectReference(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+
+	now := metav1.NewTime(time.Now())
+	name := newEventName(*ref, eventType, reason, message)
+
+	current, err := r.k8sClient.CoreV1().Events(namespace).Get(name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		e := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Count:          1,
+			FirstTimestamp: now,
+			InvolvedObject: *ref,
+			LastTimestamp:  now,
+			Message:        message,
+			Reason:         reason,
+			Source: corev1.EventSource{
+				Component: r.component,
+			},
+			Type: eventType,
+		}
+
+		_, err = r.k8sClient.CoreV1().Events(namespace).Create(e)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	current.Count++
+	current.LastTimestamp = now
+
+	_, err = r.k8sClient.CoreV1().Events(namespace).Update(current)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// newEventName computes a deterministic event name so that the same event
+// emitted for the same object is aggregated instead of being created over and
+// over again on every reconciliation loop.
+func newEventName(ref corev1.ObjectReference, eventType, reason, message string) string {
+	h := sha256.New()
+	h.Write([]byte(ref.UID))
+	h.Write([]byte(eventType))
+	h.Write([]byte(reason))
+	h.Write([]byte(message))
+
+	return fmt.Sprintf("%s.%x", ref.Name, h.Sum(nil)[:8])
+}
+
+// newObjectReference returns a reference to the given object. The group,
+// version and kind are looked up in the scheme in case the object's type meta
+// is empty, which is the case for objects received from informers.
+func newObjectReference(obj runtime.Object) (*corev1.ObjectReference, error) {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		gvk = gvks[0]
+	}
+
+	m, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	ref := &corev1.ObjectReference{
+		APIVersion:      gvk.GroupVersion().String(),
+		Kind:            gvk.Kind,
+		Name:            m.GetName(),
+		Namespace:       m.GetNamespace(),
+		ResourceVersion: m.GetResourceVersion(),
+		UID:             m.GetUID(),
+	}
+
+	return ref, nil
+}
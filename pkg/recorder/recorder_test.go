@@ -0,0 +1,95 @@
+package recorder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func Test_Recorder_Emit(t *testing.T) {
+	obj := &v1alpha1.AWSConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "al9qy",
+			Namespace: "default",
+			UID:       "0ee2fd2b-f3b9-4f0b-9b4c-d0c2a3b6c8ab",
+		},
+	}
+
+	testCases := []struct {
+		name           string
+		emits          []string
+		expectedCounts map[string]int32
+	}{
+		{
+			name:  "case 0: single event",
+			emits: []string{"stack created"},
+			expectedCounts: map[string]int32{
+				"stack created": 1,
+			},
+		},
+		{
+			name:  "case 1: repeated event is aggregated",
+			emits: []string{"stack created", "stack created", "stack created"},
+			expectedCounts: map[string]int32{
+				"stack created": 3,
+			},
+		},
+		{
+			name:  "case 2: different messages create different events",
+			emits: []string{"stack created", "stack updated", "stack created"},
+			expectedCounts: map[string]int32{
+				"stack created": 2,
+				"stack updated": 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			k8sClient := fake.NewSimpleClientset()
+
+			var r *Recorder
+			{
+				c := Config{
+					K8sClient: k8sClient,
+					Logger:    microloggertest.New(),
+
+					Component: "aws-operator",
+				}
+
+				var err error
+				r, err = New(c)
+				if err != nil {
+					t.Fatalf("expected %#v got %#v", nil, err)
+				}
+			}
+
+			for _, m := range tc.emits {
+				r.Emit(context.Background(), obj, corev1.EventTypeNormal, "Testing", m)
+			}
+
+			events, err := k8sClient.CoreV1().Events("default").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if len(events.Items) != len(tc.expectedCounts) {
+				t.Fatalf("expected %d events got %d", len(tc.expectedCounts), len(events.Items))
+			}
+
+			for _, e := range events.Items {
+				if e.InvolvedObject.Kind != "AWSConfig" {
+					t.Fatalf("expected %#q got %#q", "AWSConfig", e.InvolvedObject.Kind)
+				}
+				if e.Count != tc.expectedCounts[e.Message] {
+					t.Fatalf("expected %d got %d for message %#q", tc.expectedCounts[e.Message], e.Count, e.Message)
+				}
+			}
+		})
+	}
+}
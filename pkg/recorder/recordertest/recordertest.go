@@ -0,0 +1,17 @@
+// Package recordertest provides a recorder implementation which does not emit
+// any events. It is meant to be used in tests.
+package recordertest
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type Recorder struct{}
+
+func New() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Emit(ctx context.Context, obj runtime.Object, eventType, reason, message string) {}
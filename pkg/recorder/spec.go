@@ -0,0 +1,17 @@
+package recorder
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type Interface interface {
+	// Emit creates a Kubernetes event for the given object. The event type is
+	// either corev1.EventTypeNormal or corev1.EventTypeWarning. Repeatedly
+	// emitted events with the same type, reason and message are aggregated by
+	// incrementing the count of the existing event. Failures are logged and not
+	// returned, because events are informational and must never block
+	// reconciliation.
+	Emit(ctx context.Context, obj runtime.Object, eventType, reason, message string)
+}
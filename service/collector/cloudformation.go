@@ -0,0 +1,281 @@
+package collector
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+
+	clientaws "github.com/giantswarm/aws-operator/client/aws"
+)
+
+const (
+	labelDriftStatus = "drift_status"
+	labelStackType   = "stack_type"
+	labelStatus      = "status"
+)
+
+const (
+	stackTypeGuestMain = "guest-main"
+	stackTypeHostPost  = "host-post"
+	stackTypeHostPre   = "host-pre"
+)
+
+const (
+	subsystemCloudFormation = "cloudformation"
+)
+
+var (
+	cloudFormationLabels = []string{
+		labelAccountID,
+		labelCluster,
+		labelInstallation,
+		labelOrganization,
+		labelStack,
+		labelStackType,
+	}
+
+	cloudFormationStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCloudFormation, "status"),
+		"Gauge about the status of the Cloud Formation stacks managed by the operator.",
+		append(cloudFormationLabels, labelStatus),
+		nil,
+	)
+	cloudFormationLastUpdatedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCloudFormation, "last_updated_timestamp_seconds"),
+		"Gauge about the time the Cloud Formation stacks managed by the operator were last updated or created.",
+		cloudFormationLabels,
+		nil,
+	)
+	cloudFormationDriftStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCloudFormation, "drift_status"),
+		"Gauge about the drift status of the Cloud Formation stacks managed by the operator.",
+		append(cloudFormationLabels, labelDriftStatus),
+		nil,
+	)
+	cloudFormationTerminationProtectionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemCloudFormation, "termination_protection"),
+		"Gauge about the Cloud Formation stacks managed by the operator having termination protection enabled.",
+		cloudFormationLabels,
+		nil,
+	)
+)
+
+type CloudFormationConfig struct {
+	Helper *helper
+	Logger micrologger.Logger
+
+	InstallationName string
+}
+
+type CloudFormation struct {
+	helper *helper
+	logger micrologger.Logger
+
+	installationName string
+}
+
+func NewCloudFormation(config CloudFormationConfig) (*CloudFormation, error) {
+	if config.Helper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Helper must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.InstallationName == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationName must not be empty", config)
+	}
+
+	c := &CloudFormation{
+		helper: config.Helper,
+		logger: config.Logger,
+
+		installationName: config.InstallationName,
+	}
+
+	return c, nil
+}
+
+func (c *CloudFormation) Collect(ch chan<- prometheus.Metric) error {
+	awsClientsList, err := c.helper.GetAWSClients()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var g errgroup.Group
+
+	for _, item := range awsClientsList {
+		awsClients := item
+
+		g.Go(func() error {
+			err := c.collectForAccount(ch, awsClients)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (c *CloudFormation) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- cloudFormationStatusDesc
+	ch <- cloudFormationLastUpdatedDesc
+	ch <- cloudFormationDriftStatusDesc
+	ch <- cloudFormationTerminationProtectionDesc
+	return nil
+}
+
+func (c *CloudFormation) collectForAccount(ch chan<- prometheus.Metric, awsClients clientaws.Clients) error {
+	accountID, err := c.helper.AWSAccountID(awsClients)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var nextToken *string
+	for {
+		i := &cloudformation.DescribeStacksInput{
+			NextToken: nextToken,
+		}
+
+		o, err := awsClients.CloudFormation.DescribeStacks(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, stack := range o.Stacks {
+			c.collectForStack(ch, accountID, stack)
+		}
+
+		if o.NextToken == nil {
+			break
+		}
+		nextToken = o.NextToken
+	}
+
+	return nil
+}
+
+func (c *CloudFormation) collectForStack(ch chan<- prometheus.Metric, accountID string, stack *cloudformation.Stack) {
+	stackName := aws.StringValue(stack.StackName)
+
+	stackType := stackTypeFromName(stackName)
+	if stackType == "" {
+		return
+	}
+
+	var cluster, installation, organization string
+	for _, tag := range stack.Tags {
+		switch aws.StringValue(tag.Key) {
+		case tagCluster:
+			cluster = aws.StringValue(tag.Value)
+		case tagInstallation:
+			installation = aws.StringValue(tag.Value)
+		case tagOrganization:
+			organization = aws.StringValue(tag.Value)
+		}
+	}
+
+	if installation != c.installationName {
+		return
+	}
+
+	labels := []string{
+		accountID,
+		cluster,
+		installation,
+		organization,
+		stackName,
+		stackType,
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		cloudFormationStatusDesc,
+		prometheus.GaugeValue,
+		GaugeValue,
+		append(labels, aws.StringValue(stack.StackStatus))...,
+	)
+
+	{
+		lastUpdated := stack.CreationTime
+		if stack.LastUpdatedTime != nil {
+			lastUpdated = stack.LastUpdatedTime
+		}
+
+		var v float64
+		if lastUpdated != nil {
+			v = float64(lastUpdated.Unix())
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			cloudFormationLastUpdatedDesc,
+			prometheus.GaugeValue,
+			v,
+			labels...,
+		)
+	}
+
+	{
+		driftStatus := cloudformation.StackDriftStatusNotChecked
+		if stack.DriftInformation != nil && stack.DriftInformation.StackDriftStatus != nil {
+			driftStatus = *stack.DriftInformation.StackDriftStatus
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			cloudFormationDriftStatusDesc,
+			prometheus.GaugeValue,
+			GaugeValue,
+			append(labels, driftStatus)...,
+		)
+	}
+
+	{
+		var v float64
+		if aws.BoolValue(stack.EnableTerminationProtection) {
+			v = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			cloudFormationTerminationProtectionDesc,
+			prometheus.GaugeValue,
+			v,
+			labels...,
+		)
+	}
+}
+
+// stackTypeFromName returns the type of the given Cloud Formation stack in
+// case it is managed by the operator. Stack names look like the following.
+//
+//     cluster-<id>-guest-main
+//     cluster-<id>-host-main
+//     cluster-<id>-host-setup
+//
+func stackTypeFromName(stackName string) string {
+	if !strings.HasPrefix(stackName, "cluster-") {
+		return ""
+	}
+
+	switch {
+	case strings.HasSuffix(stackName, "-guest-main"):
+		return stackTypeGuestMain
+	case strings.HasSuffix(stackName, "-host-main"):
+		return stackTypeHostPost
+	case strings.HasSuffix(stackName, "-host-setup"):
+		return stackTypeHostPre
+	}
+
+	return ""
+}
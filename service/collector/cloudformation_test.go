@@ -0,0 +1,156 @@
+package collector
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// newDescribeStacksClient returns a Cloud Formation client which does not send
+// any requests but answers DescribeStacks with the given XML response body, so
+// that the response is unmarshalled like a real Cloud Formation response.
+func newDescribeStacksClient(body string) *cloudformation.CloudFormation {
+	c := &aws.Config{
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		Region:      aws.String("eu-central-1"),
+	}
+	client := cloudformation.New(session.Must(session.NewSession(c)))
+
+	client.Handlers.Send.Clear()
+	client.Handlers.Send.PushBack(func(r *request.Request) {
+		r.HTTPResponse = &http.Response{
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Header:     http.Header{},
+			StatusCode: http.StatusOK,
+		}
+	})
+
+	return client
+}
+
+func Test_CloudFormation_collectForStack_DriftStatus(t *testing.T) {
+	testCases := []struct {
+		name                string
+		driftInformation    string
+		expectedDriftStatus string
+	}{
+		{
+			name:                "case 0: stack without drift information was not checked",
+			driftInformation:    "",
+			expectedDriftStatus: cloudformation.StackDriftStatusNotChecked,
+		},
+		{
+			name:                "case 1: stack in sync",
+			driftInformation:    "<DriftInformation><StackDriftStatus>IN_SYNC</StackDriftStatus></DriftInformation>",
+			expectedDriftStatus: cloudformation.StackDriftStatusInSync,
+		},
+		{
+			name:                "case 2: drifted stack",
+			driftInformation:    "<DriftInformation><StackDriftStatus>DRIFTED</StackDriftStatus></DriftInformation>",
+			expectedDriftStatus: cloudformation.StackDriftStatusDrifted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `<DescribeStacksResponse xmlns="http://cloudformation.amazonaws.com/doc/2010-05-15/">
+  <DescribeStacksResult>
+    <Stacks>
+      <member>
+        <StackName>cluster-al9qy-guest-main</StackName>
+        <StackStatus>UPDATE_COMPLETE</StackStatus>
+        <CreationTime>2019-08-01T06:00:00Z</CreationTime>
+        ` + tc.driftInformation + `
+        <Tags>
+          <member>
+            <Key>giantswarm.io/installation</Key>
+            <Value>test</Value>
+          </member>
+        </Tags>
+      </member>
+    </Stacks>
+  </DescribeStacksResult>
+</DescribeStacksResponse>`
+
+			o, err := newDescribeStacksClient(body).DescribeStacks(&cloudformation.DescribeStacksInput{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if len(o.Stacks) != 1 {
+				t.Fatalf("expected %d stacks got %d", 1, len(o.Stacks))
+			}
+
+			c := &CloudFormation{
+				installationName: "test",
+			}
+
+			ch := make(chan prometheus.Metric, 10)
+			c.collectForStack(ch, "000000000000", o.Stacks[0])
+			close(ch)
+
+			var driftStatus string
+			for m := range ch {
+				if m.Desc() != cloudFormationDriftStatusDesc {
+					continue
+				}
+
+				var d dto.Metric
+				err := m.Write(&d)
+				if err != nil {
+					t.Fatalf("expected %#v got %#v", nil, err)
+				}
+				for _, l := range d.Label {
+					if l.GetName() == labelDriftStatus {
+						driftStatus = l.GetValue()
+					}
+				}
+			}
+
+			if driftStatus != tc.expectedDriftStatus {
+				t.Fatalf("expected %#q got %#q", tc.expectedDriftStatus, driftStatus)
+			}
+		})
+	}
+}
+
+func Test_stackTypeFromName(t *testing.T) {
+	testCases := []struct {
+		stackName    string
+		expectedType string
+	}{
+		{
+			stackName:    "cluster-al9qy-guest-main",
+			expectedType: stackTypeGuestMain,
+		},
+		{
+			stackName:    "cluster-al9qy-host-main",
+			expectedType: stackTypeHostPost,
+		},
+		{
+			stackName:    "cluster-al9qy-host-setup",
+			expectedType: stackTypeHostPre,
+		},
+		{
+			stackName:    "unmanaged-stack",
+			expectedType: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.stackName, func(t *testing.T) {
+			stackType := stackTypeFromName(tc.stackName)
+			if stackType != tc.expectedType {
+				t.Fatalf("expected %#q got %#q", tc.expectedType, stackType)
+			}
+		})
+	}
+}
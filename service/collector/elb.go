@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/sync/errgroup"
 
@@ -29,6 +30,8 @@ const (
 )
 
 var (
+	// elbsDesc is used for classic ELBs as well as NLBs. For NLBs the number of
+	// unhealthy targets of all target groups of the load balancer is reported.
 	elbsDesc *prometheus.Desc = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, subsystemELB, "instance_out_of_service_count"),
 		"Gauge about ELB instances being out of service.",
@@ -58,6 +61,8 @@ type ELB struct {
 }
 
 type loadBalancer struct {
+	// ARN is only set for load balancers managed by the ELBv2 API.
+	ARN                   string
 	InstancesOutOfService float64
 	Name                  string
 	Tags                  map[string]string
@@ -125,6 +130,20 @@ func (e *ELB) collectForAccount(ch chan<- prometheus.Metric, awsClients clientaw
 		return microerror.Mask(err)
 	}
 
+	err = e.collectClassicForAccount(ch, awsClients, account)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = e.collectV2ForAccount(ch, awsClients, account)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+func (e *ELB) collectClassicForAccount(ch chan<- prometheus.Metric, awsClients clientaws.Clients, account string) error {
 	var loadBalancerNames []*string
 	{
 		i := &elb.DescribeLoadBalancersInput{}
@@ -247,3 +266,151 @@ func (e *ELB) collectForAccount(ch chan<- prometheus.Metric, awsClients clientaw
 
 	return nil
 }
+
+func (e *ELB) collectV2ForAccount(ch chan<- prometheus.Metric, awsClients clientaws.Clients, account string) error {
+	var loadBalancerARNs []*string
+	loadBalancerNames := map[string]string{}
+	{
+		var marker *string
+		for {
+			i := &elbv2.DescribeLoadBalancersInput{
+				Marker: marker,
+			}
+
+			o, err := awsClients.ELBv2.DescribeLoadBalancers(i)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			for _, d := range o.LoadBalancers {
+				if *d.Type != elbv2.LoadBalancerTypeEnumNetwork {
+					continue
+				}
+
+				loadBalancerARNs = append(loadBalancerARNs, d.LoadBalancerArn)
+				loadBalancerNames[*d.LoadBalancerArn] = *d.LoadBalancerName
+			}
+
+			if o.NextMarker == nil {
+				break
+			}
+			marker = o.NextMarker
+		}
+
+		if len(loadBalancerARNs) == 0 {
+			// There are no NLBs in case all tenant clusters use classic ELBs.
+			// No metrics to emit either so we can short circuit here.
+			return nil
+		}
+	}
+
+	var lbs []loadBalancer
+	{
+		// The ELBv2 API has the same limit for the maximum number of resources
+		// in a single DescribeTags request as the classic ELB API.
+		lbARNChunks := loadBalancerARNs
+		for len(lbARNChunks) > 0 {
+			batchSize := maxELBsInOneDescribeTagsBatch
+			if len(lbARNChunks) < batchSize {
+				batchSize = len(lbARNChunks)
+			}
+
+			i := &elbv2.DescribeTagsInput{
+				ResourceArns: lbARNChunks[0:batchSize],
+			}
+			lbARNChunks = lbARNChunks[batchSize:]
+
+			o, err := awsClients.ELBv2.DescribeTags(i)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			for _, d := range o.TagDescriptions {
+				lb := loadBalancer{
+					ARN:  *d.ResourceArn,
+					Name: loadBalancerNames[*d.ResourceArn],
+					Tags: make(map[string]string),
+				}
+
+				for _, t := range d.Tags {
+					lb.Tags[*t.Key] = *t.Value
+				}
+
+				if lb.Tags[tagInstallation] != e.installationName {
+					continue
+				}
+
+				lbs = append(lbs, lb)
+			}
+		}
+	}
+
+	{
+		// Target health can only be described per target group, so we look up
+		// the target groups of every NLB and sum up their unhealthy targets.
+		for i := range lbs {
+			targetGroups, err := e.describeTargetGroups(awsClients, lbs[i].ARN)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			for _, g := range targetGroups {
+				o, err := awsClients.ELBv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: g.TargetGroupArn,
+				})
+				if err != nil {
+					return microerror.Mask(err)
+				}
+
+				for _, d := range o.TargetHealthDescriptions {
+					if *d.TargetHealth.State == elbv2.TargetHealthStateEnumUnhealthy {
+						lbs[i].InstancesOutOfService++
+					}
+				}
+			}
+		}
+	}
+
+	{
+		for _, lb := range lbs {
+			ch <- prometheus.MustNewConstMetric(
+				elbsDesc,
+				prometheus.GaugeValue,
+				lb.InstancesOutOfService,
+				lb.Name,
+				account,
+				lb.Tags[tagCluster],
+				lb.Tags[tagInstallation],
+				lb.Tags[tagOrganization],
+			)
+		}
+	}
+
+	return nil
+}
+
+func (e *ELB) describeTargetGroups(awsClients clientaws.Clients, loadBalancerARN string) ([]*elbv2.TargetGroup, error) {
+	var targetGroups []*elbv2.TargetGroup
+
+	var marker *string
+	for {
+		i := &elbv2.DescribeTargetGroupsInput{
+			LoadBalancerArn: &loadBalancerARN,
+			Marker:          marker,
+		}
+
+		o, err := awsClients.ELBv2.DescribeTargetGroups(i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		targetGroups = append(targetGroups, o.TargetGroups...)
+
+		if o.NextMarker == nil {
+			break
+		}
+		marker = o.NextMarker
+	}
+
+	return targetGroups, nil
+}
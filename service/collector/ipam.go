@@ -0,0 +1,455 @@
+package collector
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"sort"
+	"sync"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+
+	clientaws "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
+	"github.com/giantswarm/aws-operator/service/ipamsubnet"
+)
+
+const (
+	labelNetworkRange = "network_range"
+	labelSubnetCIDR   = "subnet_cidr"
+	labelSubnetID     = "subnet_id"
+	labelVPCID        = "vpc_id"
+)
+
+const (
+	subsystemIPAM = "ipam"
+)
+
+const (
+	// defaultNetworkRangeName is the name of the network range tenant clusters
+	// get their network segment allocated from when not requesting a named
+	// network range.
+	defaultNetworkRangeName = "default"
+)
+
+var (
+	ipamAllocatedDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemIPAM, "allocated_subnets"),
+		"Gauge about the number of tenant cluster network segments allocated within the IPAM network range.",
+		[]string{
+			labelCIDR,
+			labelInstallation,
+			labelNetworkRange,
+		},
+		nil,
+	)
+
+	ipamFreeDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemIPAM, "free_subnets"),
+		"Gauge about the number of network segments of the default tenant cluster size which can still be allocated within the IPAM network range.",
+		[]string{
+			labelCIDR,
+			labelInstallation,
+			labelNetworkRange,
+		},
+		nil,
+	)
+
+	ipamOverlappingDesc *prometheus.Desc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, subsystemIPAM, "overlapping_subnets"),
+		"VPC subnets overlapping with the network segment of a tenant cluster they do not belong to.",
+		[]string{
+			labelAccountID,
+			labelCIDR,
+			labelCluster,
+			labelInstallation,
+			labelSubnetCIDR,
+			labelSubnetID,
+			labelVPCID,
+		},
+		nil,
+	)
+)
+
+type IPAMConfig struct {
+	Helper         *helper
+	IPAMAllocation ipamallocation.Interface
+	Logger         micrologger.Logger
+
+	InstallationName string
+	NetworkRange     net.IPNet
+	NetworkRanges    map[string]net.IPNet
+	SubnetMaskBits   int
+}
+
+// IPAM collects the utilisation of the network ranges tenant cluster network
+// segments are allocated from. Network segments are considered in use the same
+// way the ipam resource considers them, which is when they are written to the
+// status of any AWSConfig, reserved by any IPAMAllocation or used by any VPC
+// subnet.
+type IPAM struct {
+	helper         *helper
+	ipamAllocation ipamallocation.Interface
+	logger         micrologger.Logger
+
+	installationName string
+	networkRanges    map[string]net.IPNet
+	subnetMask       net.IPMask
+}
+
+type ipamClusterSubnet struct {
+	ClusterID string
+	Subnet    net.IPNet
+	VPCID     string
+}
+
+type ipamVPCSubnet struct {
+	AccountID string
+	ClusterID string
+	ID        string
+	Subnet    net.IPNet
+	VPCID     string
+}
+
+func NewIPAM(config IPAMConfig) (*IPAM, error) {
+	if config.Helper == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Helper must not be empty", config)
+	}
+	if config.IPAMAllocation == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.IPAMAllocation must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.InstallationName == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationName must not be empty", config)
+	}
+	if config.NetworkRange.IP == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.NetworkRange must not be empty", config)
+	}
+	if config.SubnetMaskBits == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.SubnetMaskBits must not be empty", config)
+	}
+
+	networkRanges := map[string]net.IPNet{
+		defaultNetworkRangeName: config.NetworkRange,
+	}
+	for name, networkRange := range config.NetworkRanges {
+		networkRanges[name] = networkRange
+	}
+
+	i := &IPAM{
+		helper:         config.Helper,
+		ipamAllocation: config.IPAMAllocation,
+		logger:         config.Logger,
+
+		installationName: config.InstallationName,
+		networkRanges:    networkRanges,
+		subnetMask:       net.CIDRMask(config.SubnetMaskBits, 32),
+	}
+
+	return i, nil
+}
+
+func (i *IPAM) Collect(ch chan<- prometheus.Metric) error {
+	var clusterSubnets []ipamClusterSubnet
+	var vpcSubnets []ipamVPCSubnet
+	var allocations []net.IPNet
+	{
+		var g errgroup.Group
+
+		g.Go(func() error {
+			var err error
+
+			clusterSubnets, err = i.getAWSConfigSubnets()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		})
+
+		g.Go(func() error {
+			var err error
+
+			vpcSubnets, err = i.getVPCSubnets()
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		})
+
+		g.Go(func() error {
+			var err error
+
+			allocations, err = i.ipamAllocation.Allocations(context.Background())
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			return nil
+		})
+
+		err := g.Wait()
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	var allocated []net.IPNet
+	{
+		seen := map[string]bool{}
+
+		for _, c := range clusterSubnets {
+			allocated = appendUniqueSubnet(allocated, seen, c.Subnet)
+		}
+		for _, a := range allocations {
+			allocated = appendUniqueSubnet(allocated, seen, a)
+		}
+	}
+
+	reserved := append([]net.IPNet{}, allocated...)
+	for _, s := range vpcSubnets {
+		reserved = append(reserved, s.Subnet)
+	}
+
+	for name, networkRange := range i.networkRanges {
+		var count int
+		for _, a := range allocated {
+			if networkRange.Contains(a.IP) {
+				count++
+			}
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			ipamAllocatedDesc,
+			prometheus.GaugeValue,
+			float64(count),
+			networkRange.String(),
+			i.installationName,
+			name,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			ipamFreeDesc,
+			prometheus.GaugeValue,
+			float64(freeSubnets(networkRange, i.subnetMask, reserved)),
+			networkRange.String(),
+			i.installationName,
+			name,
+		)
+	}
+
+	for _, c := range clusterSubnets {
+		for _, s := range vpcSubnets {
+			// Subnets of the tenant cluster's own VPC are supposed to be part of
+			// its network segment. The VPC of a tenant cluster deployed into an
+			// existing VPC is identified by its ID, since its subnets do not
+			// carry the tenant cluster's tag.
+			if s.ClusterID == c.ClusterID || (c.VPCID != "" && s.VPCID == c.VPCID) {
+				continue
+			}
+			if !overlaps(c.Subnet, s.Subnet) {
+				continue
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				ipamOverlappingDesc,
+				prometheus.GaugeValue,
+				GaugeValue,
+				s.AccountID,
+				c.Subnet.String(),
+				c.ClusterID,
+				i.installationName,
+				s.Subnet.String(),
+				s.ID,
+				s.VPCID,
+			)
+		}
+	}
+
+	return nil
+}
+
+func (i *IPAM) Describe(ch chan<- *prometheus.Desc) error {
+	ch <- ipamAllocatedDesc
+	ch <- ipamFreeDesc
+	ch <- ipamOverlappingDesc
+	return nil
+}
+
+func (i *IPAM) getAWSConfigSubnets() ([]ipamClusterSubnet, error) {
+	clusterSubnets, err := ipamsubnet.AWSConfigSubnets(i.helper.g8sClient)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []ipamClusterSubnet
+	for _, c := range clusterSubnets {
+		s := ipamClusterSubnet{
+			ClusterID: c.ClusterID,
+			Subnet:    c.Subnet,
+			VPCID:     c.VPCID,
+		}
+
+		subnets = append(subnets, s)
+	}
+
+	return subnets, nil
+}
+
+func (i *IPAM) getVPCSubnets() ([]ipamVPCSubnet, error) {
+	awsClientsList, err := i.helper.GetAWSClients()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var g errgroup.Group
+	var mutex sync.Mutex
+	var subnets []ipamVPCSubnet
+
+	for _, item := range awsClientsList {
+		awsClients := item
+
+		g.Go(func() error {
+			s, err := i.getVPCSubnetsForAccount(awsClients)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			mutex.Lock()
+			subnets = append(subnets, s...)
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	err = g.Wait()
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return subnets, nil
+}
+
+func (i *IPAM) getVPCSubnetsForAccount(awsClients clientaws.Clients) ([]ipamVPCSubnet, error) {
+	vpcSubnets, err := ipamsubnet.VPCSubnets(awsClients.EC2)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	accountID, err := i.helper.AWSAccountID(awsClients)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []ipamVPCSubnet
+	for _, v := range vpcSubnets {
+		s := ipamVPCSubnet{
+			AccountID: accountID,
+			ClusterID: v.ClusterID,
+			ID:        v.ID,
+			Subnet:    v.Subnet,
+			VPCID:     v.VPCID,
+		}
+
+		subnets = append(subnets, s)
+	}
+
+	return subnets, nil
+}
+
+func appendUniqueSubnet(subnets []net.IPNet, seen map[string]bool, subnet net.IPNet) []net.IPNet {
+	if seen[subnet.String()] {
+		return subnets
+	}
+
+	seen[subnet.String()] = true
+
+	return append(subnets, subnet)
+}
+
+// freeSubnets returns the number of subnets of the given mask within the given
+// network range which do not overlap with any of the given reserved subnets.
+// Instead of allocating the free subnets one by one, the subnets touched by
+// the reserved subnets are counted as intervals of subnet indices, which keeps
+// the computation cheap for large network ranges.
+func freeSubnets(networkRange net.IPNet, mask net.IPMask, reserved []net.IPNet) int {
+	rangeOnes, rangeBits := networkRange.Mask.Size()
+	ones, bits := mask.Size()
+	if rangeBits != 32 || bits != 32 || ones < rangeOnes {
+		return 0
+	}
+
+	rangeFirst := uint64(ipToUint32(networkRange.IP.Mask(networkRange.Mask)))
+	rangeLast := rangeFirst + (uint64(1) << uint(32-rangeOnes)) - 1
+	subnetSize := uint64(1) << uint(32-ones)
+
+	type interval struct {
+		First uint64
+		Last  uint64
+	}
+
+	var used []interval
+	for _, r := range reserved {
+		rOnes, rBits := r.Mask.Size()
+		if rBits != 32 || r.IP.To4() == nil {
+			continue
+		}
+
+		first := uint64(ipToUint32(r.IP.Mask(r.Mask)))
+		last := first + (uint64(1) << uint(32-rOnes)) - 1
+		if last < rangeFirst || first > rangeLast {
+			continue
+		}
+		if first < rangeFirst {
+			first = rangeFirst
+		}
+		if last > rangeLast {
+			last = rangeLast
+		}
+
+		i := interval{
+			First: (first - rangeFirst) / subnetSize,
+			Last:  (last - rangeFirst) / subnetSize,
+		}
+
+		used = append(used, i)
+	}
+
+	sort.Slice(used, func(i, j int) bool {
+		return used[i].First < used[j].First
+	})
+
+	var usedCount uint64
+	var next uint64
+	for _, u := range used {
+		if u.Last < next {
+			continue
+		}
+		if u.First < next {
+			u.First = next
+		}
+
+		usedCount += u.Last - u.First + 1
+		next = u.Last + 1
+	}
+
+	total := (rangeLast - rangeFirst + 1) / subnetSize
+
+	return int(total - usedCount)
+}
+
+func ipToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func overlaps(a net.IPNet, b net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
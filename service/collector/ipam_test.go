@@ -0,0 +1,102 @@
+package collector
+
+import (
+	"net"
+	"strconv"
+	"testing"
+)
+
+func Test_freeSubnets(t *testing.T) {
+	testCases := []struct {
+		networkRange string
+		maskBits     int
+		reserved     []string
+		expected     int
+	}{
+		// Test 0 ensures an empty network range is entirely free.
+		{
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			reserved:     nil,
+			expected:     256,
+		},
+		// Test 1 ensures reserved subnets of the same size are subtracted once,
+		// even when reserved multiple times.
+		{
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			reserved: []string{
+				"10.1.0.0/24",
+				"10.1.1.0/24",
+				"10.1.1.0/24",
+			},
+			expected: 254,
+		},
+		// Test 2 ensures small reserved subnets block the whole subnet they are
+		// part of.
+		{
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			reserved: []string{
+				"10.1.0.0/28",
+				"10.1.0.16/28",
+				"10.1.5.128/25",
+			},
+			expected: 254,
+		},
+		// Test 3 ensures big reserved subnets block all subnets they contain.
+		{
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			reserved: []string{
+				"10.1.0.0/22",
+				"10.1.2.0/24",
+			},
+			expected: 252,
+		},
+		// Test 4 ensures reserved subnets outside of the network range are
+		// ignored and subnets exceeding the network range are clipped.
+		{
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			reserved: []string{
+				"10.2.0.0/24",
+				"10.0.0.0/8",
+			},
+			expected: 0,
+		},
+		// Test 5 ensures large network ranges are computed.
+		{
+			networkRange: "10.0.0.0/8",
+			maskBits:     28,
+			reserved: []string{
+				"10.1.0.0/16",
+			},
+			expected: 1048576 - 4096,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(strconv.Itoa(i), func(t *testing.T) {
+			_, networkRange, err := net.ParseCIDR(tc.networkRange)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var reserved []net.IPNet
+			for _, r := range tc.reserved {
+				_, n, err := net.ParseCIDR(r)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				reserved = append(reserved, *n)
+			}
+
+			free := freeSubnets(*networkRange, net.CIDRMask(tc.maskBits, 32), reserved)
+			if free != tc.expected {
+				t.Fatalf("expected %d got %d", tc.expected, free)
+			}
+		})
+	}
+}
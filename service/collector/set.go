@@ -1,6 +1,8 @@
 package collector
 
 import (
+	"net"
+
 	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/exporterkit/collector"
 	"github.com/giantswarm/microerror"
@@ -8,6 +10,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	clientaws "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 type SetConfig struct {
@@ -16,7 +19,10 @@ type SetConfig struct {
 	Logger    micrologger.Logger
 
 	AWSConfig             clientaws.Config
+	GuestSubnetMaskBits   int
 	InstallationName      string
+	IPAMNetworkRange      net.IPNet
+	IPAMNetworkRanges     map[string]net.IPNet
 	TrustedAdvisorEnabled bool
 }
 
@@ -62,6 +68,21 @@ func NewSet(config SetConfig) (*Set, error) {
 		}
 	}
 
+	var cloudFormationCollector *CloudFormation
+	{
+		c := CloudFormationConfig{
+			Helper: h,
+			Logger: config.Logger,
+
+			InstallationName: config.InstallationName,
+		}
+
+		cloudFormationCollector, err = NewCloudFormation(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var ec2InstancesCollector *EC2Instances
 	{
 		c := EC2InstancesConfig{
@@ -92,6 +113,38 @@ func NewSet(config SetConfig) (*Set, error) {
 		}
 	}
 
+	var ipamAllocationService ipamallocation.Interface
+	{
+		c := ipamallocation.Config{
+			G8sClient: config.G8sClient,
+			Logger:    config.Logger,
+		}
+
+		ipamAllocationService, err = ipamallocation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ipamCollector *IPAM
+	{
+		c := IPAMConfig{
+			Helper:         h,
+			IPAMAllocation: ipamAllocationService,
+			Logger:         config.Logger,
+
+			InstallationName: config.InstallationName,
+			NetworkRange:     config.IPAMNetworkRange,
+			NetworkRanges:    config.IPAMNetworkRanges,
+			SubnetMaskBits:   config.GuestSubnetMaskBits,
+		}
+
+		ipamCollector, err = NewIPAM(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var trustedAdvisorCollector *TrustedAdvisor
 	{
 		c := TrustedAdvisorConfig{
@@ -125,8 +178,10 @@ func NewSet(config SetConfig) (*Set, error) {
 		c := collector.SetConfig{
 			Collectors: []collector.Interface{
 				asgCollector,
+				cloudFormationCollector,
 				ec2InstancesCollector,
 				elbCollector,
+				ipamCollector,
 				vpcCollector,
 			},
 			Logger: config.Logger,
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/v22"
+	"github.com/giantswarm/aws-operator/service/controller/v22patch1"
+	"github.com/giantswarm/aws-operator/service/controller/v23"
+	"github.com/giantswarm/aws-operator/service/controller/v24"
+	"github.com/giantswarm/aws-operator/service/controller/v25"
+	"github.com/giantswarm/aws-operator/service/controller/v26"
+)
+
+// Bundles returns the version bundles the operator reconciles, in the order
+// they got released. Releasing a new version bundle only requires adding it
+// here, since the controllers and the version service are derived from it.
+func Bundles() []core.Bundle {
+	return []core.Bundle{
+		v22.Bundle(),
+		v22patch1.Bundle(),
+		v23.Bundle(),
+		v24.Bundle(),
+		v25.Bundle(),
+		v26.Bundle(),
+	}
+}
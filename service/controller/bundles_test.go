@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"testing"
+)
+
+func Test_Bundles(t *testing.T) {
+	versions := map[string]bool{}
+
+	for _, b := range Bundles() {
+		v := b.VersionBundle.Version
+
+		if v == "" {
+			t.Fatalf("expected version bundle version to be set")
+		}
+		if versions[v] {
+			t.Fatalf("expected version bundle version %#q to be declared once", v)
+		}
+		if b.NewClusterResourceSet == nil {
+			t.Fatalf("expected version bundle %#q to declare its cluster resource set", v)
+		}
+		if b.NewDrainerResourceSet == nil {
+			t.Fatalf("expected version bundle %#q to declare its drainer resource set", v)
+		}
+
+		versions[v] = true
+	}
+}
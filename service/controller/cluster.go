@@ -1,10 +1,13 @@
 package controller
 
 import (
+	"context"
+	"fmt"
 	"net"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
+	"github.com/giantswarm/backoff"
 	"github.com/giantswarm/certs"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
@@ -16,24 +19,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	awsclient "github.com/giantswarm/aws-operator/client/aws"
-	"github.com/giantswarm/aws-operator/service/controller/v22"
-	v22adapter "github.com/giantswarm/aws-operator/service/controller/v22/adapter"
-	v22cloudconfig "github.com/giantswarm/aws-operator/service/controller/v22/cloudconfig"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1"
-	v22patch1adapter "github.com/giantswarm/aws-operator/service/controller/v22patch1/adapter"
-	v22patch1cloudconfig "github.com/giantswarm/aws-operator/service/controller/v22patch1/cloudconfig"
-	"github.com/giantswarm/aws-operator/service/controller/v23"
-	v23adapter "github.com/giantswarm/aws-operator/service/controller/v23/adapter"
-	v23cloudconfig "github.com/giantswarm/aws-operator/service/controller/v23/cloudconfig"
-	"github.com/giantswarm/aws-operator/service/controller/v24"
-	v24adapter "github.com/giantswarm/aws-operator/service/controller/v24/adapter"
-	v24cloudconfig "github.com/giantswarm/aws-operator/service/controller/v24/cloudconfig"
-	"github.com/giantswarm/aws-operator/service/controller/v25"
-	v25adapter "github.com/giantswarm/aws-operator/service/controller/v25/adapter"
-	v25cloudconfig "github.com/giantswarm/aws-operator/service/controller/v25/cloudconfig"
-	"github.com/giantswarm/aws-operator/service/controller/v26"
-	v26adapter "github.com/giantswarm/aws-operator/service/controller/v26/adapter"
-	v26cloudconfig "github.com/giantswarm/aws-operator/service/controller/v26/cloudconfig"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+	"github.com/giantswarm/aws-operator/service/controller/core"
 )
 
 type ClusterConfig struct {
@@ -47,6 +34,7 @@ type ClusterConfig struct {
 	APIWhitelist               FrameworkConfigAPIWhitelistConfig
 	DeleteLoggingBucket        bool
 	EncrypterBackend           string
+	EtcdBackupExpiration       int
 	GuestAWSConfig             ClusterConfigAWSConfig
 	GuestPrivateSubnetMaskBits int
 	GuestPublicSubnetMaskBits  int
@@ -54,18 +42,28 @@ type ClusterConfig struct {
 	GuestUpdateEnabled         bool
 	HostAWSConfig              ClusterConfigAWSConfig
 	IgnitionPath               string
+	Image                      ClusterConfigImage
 	IncludeTags                bool
 	InstallationName           string
 	IPAMNetworkRange           net.IPNet
+	IPAMNetworkRanges          map[string]net.IPNet
 	OIDC                       ClusterConfigOIDC
 	PodInfraContainerImage     string
 	ProjectName                string
 	PubKeyFile                 string
 	RegistryDomain             string
+	RollbackSkipResources      []string
 	Route53Enabled             bool
 	RouteTables                string
 	SSOPublicKey               string
+	TransitGatewayID           string
 	VaultAddress               string
+	VolumeSnapshot             ClusterConfigVolumeSnapshot
+}
+
+type ClusterConfigVolumeSnapshot struct {
+	DockerVolume bool
+	Retention    int
 }
 
 type ClusterConfigAWSConfig struct {
@@ -76,6 +74,13 @@ type ClusterConfigAWSConfig struct {
 	SessionToken      string
 }
 
+// ClusterConfigImage represents the configuration of the EC2 AMI resolution.
+type ClusterConfigImage struct {
+	Name     string
+	Owner    string
+	Resolver string
+}
+
 // ClusterConfigOIDC represents the configuration of the OIDC authorization
 // provider.
 type ClusterConfigOIDC struct {
@@ -93,6 +98,9 @@ type FrameworkConfigAPIWhitelistConfig struct {
 
 type Cluster struct {
 	*controller.Controller
+
+	crdClient *k8scrdclient.CRDClient
+	logger    micrologger.Logger
 }
 
 func NewCluster(config ClusterConfig) (*Cluster, error) {
@@ -157,11 +165,30 @@ func NewCluster(config ClusterConfig) (*Cluster, error) {
 
 	c := &Cluster{
 		Controller: operatorkitController,
+
+		crdClient: crdClient,
+		logger:    config.Logger,
 	}
 
 	return c, nil
 }
 
+// Boot ensures the IPAMAllocation CRD exists before booting the controller.
+// The ipam resources of all controller versions reserve tenant cluster subnets
+// using IPAMAllocation custom resources.
+func (c *Cluster) Boot(ctx context.Context) {
+	c.logger.LogCtx(ctx, "level", "debug", "message", "ensuring IPAMAllocation custom resource definition exists")
+
+	err := c.crdClient.EnsureCreated(ctx, v1alpha1.NewIPAMAllocationCRD(), backoff.NewExponential(backoff.ShortMaxWait, backoff.ShortMaxInterval))
+	if err != nil {
+		panic(fmt.Sprintf("%#v", microerror.Mask(err)))
+	}
+
+	c.logger.LogCtx(ctx, "level", "debug", "message", "ensured IPAMAllocation custom resource definition exists")
+
+	c.Controller.Boot(ctx)
+}
+
 func newClusterResourceSets(config ClusterConfig) ([]*controller.ResourceSet, error) {
 	var err error
 
@@ -193,186 +220,53 @@ func newClusterResourceSets(config ClusterConfig) ([]*controller.ResourceSet, er
 		}
 	}
 
-	var randomKeysSearcher randomkeys.Interface
+	var eventRecorder recorder.Interface
 	{
-		c := randomkeys.Config{
+		c := recorder.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
-		}
 
-		randomKeysSearcher, err = randomkeys.NewSearcher(c)
-		if err != nil {
-			return nil, microerror.Mask(err)
+			Component: config.ProjectName,
 		}
-	}
-
-	var resourceSetV22 *controller.ResourceSet
-	{
-		c := v22.ClusterResourceSetConfig{
-			CertsSearcher: certsSearcher,
-			G8sClient:     config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
-			HostAWSClients:     controlPlaneAWSClients,
-			K8sClient:          config.K8sClient,
-			Logger:             config.Logger,
-			RandomKeysSearcher: randomKeysSearcher,
 
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
-			DeleteLoggingBucket:        config.DeleteLoggingBucket,
-			EncrypterBackend:           config.EncrypterBackend,
-			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
-			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
-			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
-			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			GuestUpdateEnabled:         config.GuestUpdateEnabled,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
-			IgnitionPath:               config.IgnitionPath,
-			IncludeTags:                config.IncludeTags,
-			InstallationName:           config.InstallationName,
-			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v22cloudconfig.OIDCConfig{
-				ClientID:      config.OIDC.ClientID,
-				IssuerURL:     config.OIDC.IssuerURL,
-				UsernameClaim: config.OIDC.UsernameClaim,
-				GroupsClaim:   config.OIDC.GroupsClaim,
-			},
-			APIWhitelist: v22adapter.APIWhitelist{
-				Enabled:    config.APIWhitelist.Enabled,
-				SubnetList: config.APIWhitelist.SubnetList,
-			},
-			ProjectName:       config.ProjectName,
-			PublicRouteTables: config.RouteTables,
-			RegistryDomain:    config.RegistryDomain,
-			SSOPublicKey:      config.SSOPublicKey,
-			VaultAddress:      config.VaultAddress,
-		}
-
-		resourceSetV22, err = v22.NewClusterResourceSet(c)
+		eventRecorder, err = recorder.New(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var resourceSetV22patch1 *controller.ResourceSet
+	var randomKeysSearcher randomkeys.Interface
 	{
-		c := v22patch1.ClusterResourceSetConfig{
-			CertsSearcher: certsSearcher,
-			G8sClient:     config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
-			HostAWSClients:     controlPlaneAWSClients,
-			K8sClient:          config.K8sClient,
-			Logger:             config.Logger,
-			RandomKeysSearcher: randomKeysSearcher,
-
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
-			DeleteLoggingBucket:        config.DeleteLoggingBucket,
-			EncrypterBackend:           config.EncrypterBackend,
-			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
-			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
-			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
-			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			GuestUpdateEnabled:         config.GuestUpdateEnabled,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
-			IgnitionPath:               config.IgnitionPath,
-			IncludeTags:                config.IncludeTags,
-			InstallationName:           config.InstallationName,
-			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v22patch1cloudconfig.OIDCConfig{
-				ClientID:      config.OIDC.ClientID,
-				IssuerURL:     config.OIDC.IssuerURL,
-				UsernameClaim: config.OIDC.UsernameClaim,
-				GroupsClaim:   config.OIDC.GroupsClaim,
-			},
-			APIWhitelist: v22patch1adapter.APIWhitelist{
-				Enabled:    config.APIWhitelist.Enabled,
-				SubnetList: config.APIWhitelist.SubnetList,
-			},
-			ProjectName:       config.ProjectName,
-			PublicRouteTables: config.RouteTables,
-			RegistryDomain:    config.RegistryDomain,
-			SSOPublicKey:      config.SSOPublicKey,
-			VaultAddress:      config.VaultAddress,
+		c := randomkeys.Config{
+			K8sClient: config.K8sClient,
+			Logger:    config.Logger,
 		}
 
-		resourceSetV22patch1, err = v22patch1.NewClusterResourceSet(c)
+		randomKeysSearcher, err = randomkeys.NewSearcher(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var resourceSetV23 *controller.ResourceSet
+	var registry *core.Registry
 	{
-		c := v23.ClusterResourceSetConfig{
-			CertsSearcher: certsSearcher,
-			G8sClient:     config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
-			HostAWSClients:     controlPlaneAWSClients,
-			K8sClient:          config.K8sClient,
-			Logger:             config.Logger,
-			RandomKeysSearcher: randomKeysSearcher,
+		c := core.RegistryConfig{
+			Logger: config.Logger,
 
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
-			DeleteLoggingBucket:        config.DeleteLoggingBucket,
-			EncrypterBackend:           config.EncrypterBackend,
-			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
-			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
-			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
-			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			GuestUpdateEnabled:         config.GuestUpdateEnabled,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
-			IgnitionPath:               config.IgnitionPath,
-			IncludeTags:                config.IncludeTags,
-			InstallationName:           config.InstallationName,
-			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v23cloudconfig.OIDCConfig{
-				ClientID:      config.OIDC.ClientID,
-				IssuerURL:     config.OIDC.IssuerURL,
-				UsernameClaim: config.OIDC.UsernameClaim,
-				GroupsClaim:   config.OIDC.GroupsClaim,
-			},
-			APIWhitelist: v23adapter.APIWhitelist{
-				Enabled:    config.APIWhitelist.Enabled,
-				SubnetList: config.APIWhitelist.SubnetList,
-			},
-			ProjectName:       config.ProjectName,
-			PublicRouteTables: config.RouteTables,
-			RegistryDomain:    config.RegistryDomain,
-			SSOPublicKey:      config.SSOPublicKey,
-			VaultAddress:      config.VaultAddress,
+			VersionFunc: core.AWSConfigVersion,
 		}
 
-		resourceSetV23, err = v23.NewClusterResourceSet(c)
+		registry, err = core.NewRegistry(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var resourceSetV24 *controller.ResourceSet
-	{
-		c := v24.ClusterResourceSetConfig{
+	for _, b := range Bundles() {
+		c := core.ClusterResourceSetConfig{
 			CertsSearcher:          certsSearcher,
 			ControlPlaneAWSClients: controlPlaneAWSClients,
+			EventRecorder:          eventRecorder,
 			G8sClient:              config.G8sClient,
 			HostAWSConfig: awsclient.Config{
 				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
@@ -384,156 +278,61 @@ func newClusterResourceSets(config ClusterConfig) ([]*controller.ResourceSet, er
 			Logger:             config.Logger,
 			RandomKeysSearcher: randomKeysSearcher,
 
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
-			DeleteLoggingBucket:        config.DeleteLoggingBucket,
-			EncrypterBackend:           config.EncrypterBackend,
-			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
-			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
-			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
-			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
-			IgnitionPath:               config.IgnitionPath,
-			IncludeTags:                config.IncludeTags,
-			InstallationName:           config.InstallationName,
-			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v24cloudconfig.OIDCConfig{
-				ClientID:      config.OIDC.ClientID,
-				IssuerURL:     config.OIDC.IssuerURL,
-				UsernameClaim: config.OIDC.UsernameClaim,
-				GroupsClaim:   config.OIDC.GroupsClaim,
-			},
-			APIWhitelist: v24adapter.APIWhitelist{
+			AccessLogsExpiration:  config.AccessLogsExpiration,
+			AdvancedMonitoringEC2: config.AdvancedMonitoringEC2,
+			APIWhitelist: core.APIWhitelist{
 				Enabled:    config.APIWhitelist.Enabled,
 				SubnetList: config.APIWhitelist.SubnetList,
 			},
-			ProjectName:    config.ProjectName,
-			RouteTables:    config.RouteTables,
-			RegistryDomain: config.RegistryDomain,
-			SSOPublicKey:   config.SSOPublicKey,
-			VaultAddress:   config.VaultAddress,
-		}
-
-		resourceSetV24, err = v24.NewClusterResourceSet(c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	var resourceSetV25 *controller.ResourceSet
-	{
-		c := v25.ClusterResourceSetConfig{
-			CertsSearcher:          certsSearcher,
-			ControlPlaneAWSClients: controlPlaneAWSClients,
-			G8sClient:              config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				Region:          config.HostAWSConfig.Region,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-			},
-			K8sClient:          config.K8sClient,
-			Logger:             config.Logger,
-			RandomKeysSearcher: randomKeysSearcher,
-
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
 			DeleteLoggingBucket:        config.DeleteLoggingBucket,
 			EncrypterBackend:           config.EncrypterBackend,
+			EtcdBackupExpiration:       config.EtcdBackupExpiration,
 			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
 			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
 			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
 			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
+			GuestUpdateEnabled:         config.GuestUpdateEnabled,
 			IgnitionPath:               config.IgnitionPath,
+			ImageName:                  config.Image.Name,
+			ImageOwner:                 config.Image.Owner,
+			ImageResolver:              config.Image.Resolver,
 			IncludeTags:                config.IncludeTags,
 			InstallationName:           config.InstallationName,
 			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v25cloudconfig.OIDCConfig{
+			IPAMNetworkRanges:          config.IPAMNetworkRanges,
+			OIDC: core.OIDC{
 				ClientID:      config.OIDC.ClientID,
 				IssuerURL:     config.OIDC.IssuerURL,
 				UsernameClaim: config.OIDC.UsernameClaim,
 				GroupsClaim:   config.OIDC.GroupsClaim,
 			},
-			APIWhitelist: v25adapter.APIWhitelist{
-				Enabled:    config.APIWhitelist.Enabled,
-				SubnetList: config.APIWhitelist.SubnetList,
-			},
-			ProjectName:    config.ProjectName,
-			RouteTables:    config.RouteTables,
-			RegistryDomain: config.RegistryDomain,
-			SSOPublicKey:   config.SSOPublicKey,
-			VaultAddress:   config.VaultAddress,
+			PodInfraContainerImage:     config.PodInfraContainerImage,
+			ProjectName:                config.ProjectName,
+			RegistryDomain:             config.RegistryDomain,
+			RollbackSkipResources:      config.RollbackSkipResources,
+			Route53Enabled:             config.Route53Enabled,
+			RouteTables:                config.RouteTables,
+			SSOPublicKey:               config.SSOPublicKey,
+			TransitGatewayID:           config.TransitGatewayID,
+			VaultAddress:               config.VaultAddress,
+			VolumeSnapshotDockerVolume: config.VolumeSnapshot.DockerVolume,
+			VolumeSnapshotRetention:    config.VolumeSnapshot.Retention,
 		}
 
-		resourceSetV25, err = v25.NewClusterResourceSet(c)
+		resourceSet, err := b.NewClusterResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
-	}
 
-	var resourceSetV26 *controller.ResourceSet
-	{
-		c := v26.ClusterResourceSetConfig{
-			CertsSearcher:          certsSearcher,
-			ControlPlaneAWSClients: controlPlaneAWSClients,
-			G8sClient:              config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				Region:          config.HostAWSConfig.Region,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-			},
-			K8sClient:          config.K8sClient,
-			Logger:             config.Logger,
-			RandomKeysSearcher: randomKeysSearcher,
-
-			AccessLogsExpiration:       config.AccessLogsExpiration,
-			AdvancedMonitoringEC2:      config.AdvancedMonitoringEC2,
-			DeleteLoggingBucket:        config.DeleteLoggingBucket,
-			EncrypterBackend:           config.EncrypterBackend,
-			GuestAvailabilityZones:     config.GuestAWSConfig.AvailabilityZones,
-			GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
-			GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
-			GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
-			PodInfraContainerImage:     config.PodInfraContainerImage,
-			Route53Enabled:             config.Route53Enabled,
-			IgnitionPath:               config.IgnitionPath,
-			IncludeTags:                config.IncludeTags,
-			InstallationName:           config.InstallationName,
-			IPAMNetworkRange:           config.IPAMNetworkRange,
-			OIDC: v26cloudconfig.OIDCConfig{
-				ClientID:      config.OIDC.ClientID,
-				IssuerURL:     config.OIDC.IssuerURL,
-				UsernameClaim: config.OIDC.UsernameClaim,
-				GroupsClaim:   config.OIDC.GroupsClaim,
-			},
-			APIWhitelist: v26adapter.APIWhitelist{
-				Enabled:    config.APIWhitelist.Enabled,
-				SubnetList: config.APIWhitelist.SubnetList,
-			},
-			ProjectName:    config.ProjectName,
-			RouteTables:    config.RouteTables,
-			RegistryDomain: config.RegistryDomain,
-			SSOPublicKey:   config.SSOPublicKey,
-			VaultAddress:   config.VaultAddress,
-		}
-
-		resourceSetV26, err = v26.NewClusterResourceSet(c)
+		err = registry.Register(b.VersionBundle, resourceSet)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	resourceSets := []*controller.ResourceSet{
-		resourceSetV22,
-		resourceSetV22patch1,
-		resourceSetV23,
-		resourceSetV24,
-		resourceSetV25,
-		resourceSetV26,
+	resourceSets, err := registry.ResourceSets()
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
 	return resourceSets, nil
@@ -49,6 +49,9 @@ func newTestClusterConfig() ClusterConfig {
 		RegistryDomain:      "quay.io",
 		SSOPublicKey:        "test",
 		EncrypterBackend:    "kms",
+		Image: ClusterConfigImage{
+			Resolver: "static",
+		},
 	}
 }
 
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"net"
+
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
+	"github.com/giantswarm/certs"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/controller"
+	"github.com/giantswarm/randomkeys"
+	"github.com/giantswarm/versionbundle"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+)
+
+// Bundle declares what a version bundle adds on top of the core package. The
+// version bundle carries the component versions and changelogs. The resource
+// set constructors carry the version specific templates, resources and
+// feature toggles. Everything else, i.e. the dependencies, the operator
+// settings, the dispatching and the version independent resources, is shared
+// by all version bundles via the core package.
+type Bundle struct {
+	VersionBundle versionbundle.Bundle
+
+	// NewClusterResourceSet creates the resource set of the cluster controller.
+	// Version bundles pick the settings they support out of the given config.
+	NewClusterResourceSet func(config ClusterResourceSetConfig) (*controller.ResourceSet, error)
+	// NewDrainerResourceSet creates the resource set of the drainer controller.
+	// Version bundles pick the settings they support out of the given config.
+	NewDrainerResourceSet func(config DrainerResourceSetConfig) (*controller.ResourceSet, error)
+}
+
+// ClusterResourceSetConfig holds the dependencies and settings of the cluster
+// controller every version bundle creates its cluster resource set with.
+type ClusterResourceSetConfig struct {
+	CertsSearcher          certs.Interface
+	ControlPlaneAWSClients aws.Clients
+	EventRecorder          recorder.Interface
+	G8sClient              versioned.Interface
+	HostAWSConfig          aws.Config
+	K8sClient              kubernetes.Interface
+	Logger                 micrologger.Logger
+	RandomKeysSearcher     randomkeys.Interface
+
+	AccessLogsExpiration       int
+	AdvancedMonitoringEC2      bool
+	APIWhitelist               APIWhitelist
+	DeleteLoggingBucket        bool
+	EncrypterBackend           string
+	EtcdBackupExpiration       int
+	GuestAvailabilityZones     []string
+	GuestPrivateSubnetMaskBits int
+	GuestPublicSubnetMaskBits  int
+	GuestSubnetMaskBits        int
+	GuestUpdateEnabled         bool
+	IgnitionPath               string
+	ImageName                  string
+	ImageOwner                 string
+	ImageResolver              string
+	IncludeTags                bool
+	InstallationName           string
+	IPAMNetworkRange           net.IPNet
+	IPAMNetworkRanges          map[string]net.IPNet
+	OIDC                       OIDC
+	PodInfraContainerImage     string
+	ProjectName                string
+	RegistryDomain             string
+	RollbackSkipResources      []string
+	Route53Enabled             bool
+	RouteTables                string
+	SSOPublicKey               string
+	TransitGatewayID           string
+	VaultAddress               string
+	VolumeSnapshotDockerVolume bool
+	VolumeSnapshotRetention    int
+}
+
+// DrainerResourceSetConfig holds the dependencies and settings of the drainer
+// controller every version bundle creates its drainer resource set with.
+type DrainerResourceSetConfig struct {
+	ControlPlaneAWSClients aws.Clients
+	EventRecorder          recorder.Interface
+	G8sClient              versioned.Interface
+	HostAWSConfig          aws.Config
+	K8sClient              kubernetes.Interface
+	Logger                 micrologger.Logger
+
+	GuestUpdateEnabled bool
+	ProjectName        string
+	Route53Enabled     bool
+}
+
+// APIWhitelist defines tenant cluster Kubernetes API whitelisting.
+type APIWhitelist struct {
+	Enabled    bool
+	SubnetList string
+}
+
+// OIDC represents the configuration of the OIDC authorization provider.
+type OIDC struct {
+	ClientID      string
+	IssuerURL     string
+	UsernameClaim string
+	GroupsClaim   string
+}
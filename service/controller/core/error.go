@@ -1,7 +1,16 @@
-package namespace
+package core
 
 import "github.com/giantswarm/microerror"
 
+var alreadyRegisteredError = &microerror.Error{
+	Kind: "alreadyRegisteredError",
+}
+
+// IsAlreadyRegistered asserts alreadyRegisteredError.
+func IsAlreadyRegistered(err error) bool {
+	return microerror.Cause(err) == alreadyRegisteredError
+}
+
 var invalidConfigError = &microerror.Error{
 	Kind: "invalidConfigError",
 }
@@ -11,15 +20,6 @@ func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
 
-var notFoundError = &microerror.Error{
-	Kind: "notFoundError",
-}
-
-// IsNotFound asserts notFoundError.
-func IsNotFound(err error) bool {
-	return microerror.Cause(err) == notFoundError
-}
-
 var wrongTypeError = &microerror.Error{
 	Kind: "wrongTypeError",
 }
@@ -0,0 +1,68 @@
+// Package key provides the version independent key functions of the resources
+// shared by all version bundles in the core resource packages.
+package key
+
+import (
+	"fmt"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	AnnotationEtcdDomain        = "giantswarm.io/etcd-domain"
+	AnnotationPrometheusCluster = "giantswarm.io/prometheus-cluster"
+
+	LabelApp           = "app"
+	LabelCluster       = "giantswarm.io/cluster"
+	LabelCustomer      = "customer"
+	LabelOrganization  = "giantswarm.io/organization"
+	LabelVersionBundle = "giantswarm.io/version-bundle"
+
+	LegacyLabelCluster = "cluster"
+)
+
+func ClusterCustomer(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.Cluster.Customer.ID
+}
+
+func ClusterEtcdDomain(customObject v1alpha1.AWSConfig) string {
+	return fmt.Sprintf("%s:%d", customObject.Spec.Cluster.Etcd.Domain, customObject.Spec.Cluster.Etcd.Port)
+}
+
+func ClusterID(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.Cluster.ID
+}
+
+func ClusterNamespace(customObject v1alpha1.AWSConfig) string {
+	return ClusterID(customObject)
+}
+
+func CustomerID(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.Cluster.Customer.ID
+}
+
+func IsDeleted(customObject v1alpha1.AWSConfig) bool {
+	return customObject.GetDeletionTimestamp() != nil
+}
+
+func ToCustomObject(v interface{}) (v1alpha1.AWSConfig, error) {
+	if v == nil {
+		return v1alpha1.AWSConfig{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &v1alpha1.AWSConfig{}, v)
+	}
+
+	customObjectPointer, ok := v.(*v1alpha1.AWSConfig)
+	if !ok {
+		return v1alpha1.AWSConfig{}, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &v1alpha1.AWSConfig{}, v)
+	}
+	customObject := *customObjectPointer
+
+	customObject = *customObject.DeepCopy()
+
+	return customObject, nil
+}
+
+// VersionBundleVersion returns the version contained in the Version Bundle.
+func VersionBundleVersion(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.VersionBundle.Version
+}
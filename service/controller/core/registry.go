@@ -0,0 +1,123 @@
+package core
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/controller"
+	"github.com/giantswarm/versionbundle"
+)
+
+type RegistryConfig struct {
+	Logger micrologger.Logger
+
+	// VersionFunc returns the version bundle version of the reconciled runtime
+	// object. It is used to dispatch runtime objects to the resource set
+	// registered for their version bundle version.
+	VersionFunc func(obj interface{}) (string, error)
+}
+
+// Registry holds the resource sets of all version bundles a controller
+// reconciles. Resource sets are keyed by the version of the version bundle
+// they are released with. The registry takes care of dispatching reconciled
+// runtime objects to the resource set matching their version bundle version,
+// so that version bundles do not have to implement their own dispatching.
+type Registry struct {
+	logger micrologger.Logger
+
+	resourceSets map[string]*controller.ResourceSet
+	versions     []string
+	versionFunc  func(obj interface{}) (string, error)
+}
+
+func NewRegistry(config RegistryConfig) (*Registry, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if config.VersionFunc == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.VersionFunc must not be empty", config)
+	}
+
+	r := &Registry{
+		logger: config.Logger,
+
+		resourceSets: map[string]*controller.ResourceSet{},
+		versions:     nil,
+		versionFunc:  config.VersionFunc,
+	}
+
+	return r, nil
+}
+
+// Register adds the resource set released with the given version bundle to
+// the registry. Each version bundle version can only be registered once.
+func (r *Registry) Register(versionBundle versionbundle.Bundle, resourceSet *controller.ResourceSet) error {
+	if versionBundle.Version == "" {
+		return microerror.Maskf(invalidConfigError, "version bundle version must not be empty")
+	}
+	if resourceSet == nil {
+		return microerror.Maskf(invalidConfigError, "resource set for version bundle version %#q must not be empty", versionBundle.Version)
+	}
+
+	_, ok := r.resourceSets[versionBundle.Version]
+	if ok {
+		return microerror.Maskf(alreadyRegisteredError, "version bundle version %#q", versionBundle.Version)
+	}
+
+	r.resourceSets[versionBundle.Version] = resourceSet
+	r.versions = append(r.versions, versionBundle.Version)
+
+	return nil
+}
+
+// Handles returns the handles function of the resource set registered for the
+// given version bundle version. It returns true for runtime objects having
+// the given version bundle version, if the version is registered.
+func (r *Registry) Handles(version string) func(obj interface{}) bool {
+	return func(obj interface{}) bool {
+		_, ok := r.resourceSets[version]
+		if !ok {
+			return false
+		}
+
+		v, err := r.versionFunc(obj)
+		if err != nil {
+			return false
+		}
+
+		return v == version
+	}
+}
+
+// ResourceSets returns all registered resource sets in the order they got
+// registered. The returned resource sets are dispatched by the registry,
+// regardless of the handles function the registered resource sets were
+// created with.
+func (r *Registry) ResourceSets() ([]*controller.ResourceSet, error) {
+	var resourceSets []*controller.ResourceSet
+
+	for _, v := range r.versions {
+		rs := r.resourceSets[v]
+
+		c := controller.ResourceSetConfig{
+			Handles:   r.Handles(v),
+			InitCtx:   rs.InitCtx,
+			Logger:    r.logger,
+			Resources: rs.Resources(),
+		}
+
+		resourceSet, err := controller.NewResourceSet(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		resourceSets = append(resourceSets, resourceSet)
+	}
+
+	return resourceSets, nil
+}
+
+// Versions returns all registered version bundle versions in the order they
+// got registered.
+func (r *Registry) Versions() []string {
+	return append([]string(nil), r.versions...)
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/controller"
+	"github.com/giantswarm/versionbundle"
+)
+
+type resourceMock struct{}
+
+func (r *resourceMock) EnsureCreated(ctx context.Context, obj interface{}) error {
+	return nil
+}
+
+func (r *resourceMock) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
+
+func (r *resourceMock) Name() string {
+	return "mock"
+}
+
+func newResourceSet(t *testing.T) *controller.ResourceSet {
+	c := ResourceSetConfig{
+		Logger: microloggertest.New(),
+
+		Resources: []controller.Resource{
+			&resourceMock{},
+		},
+	}
+
+	rs, err := NewResourceSet(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return rs
+}
+
+func newCR(version string) *v1alpha1.AWSConfig {
+	return &v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			VersionBundle: v1alpha1.AWSConfigSpecVersionBundle{
+				Version: version,
+			},
+		},
+	}
+}
+
+func Test_Registry_ResourceSets(t *testing.T) {
+	c := RegistryConfig{
+		Logger: microloggertest.New(),
+
+		VersionFunc: AWSConfigVersion,
+	}
+
+	r, err := NewRegistry(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	versions := []string{"4.9.0", "5.0.0"}
+	for _, v := range versions {
+		err = r.Register(versionbundle.Bundle{Version: v}, newResourceSet(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = r.Register(versionbundle.Bundle{Version: "5.0.0"}, newResourceSet(t))
+	if !IsAlreadyRegistered(err) {
+		t.Fatalf("expected %#v got %#v", alreadyRegisteredError, err)
+	}
+
+	resourceSets, err := r.ResourceSets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resourceSets) != len(versions) {
+		t.Fatalf("expected %d resource sets got %d", len(versions), len(resourceSets))
+	}
+
+	testCases := []struct {
+		name            string
+		obj             interface{}
+		expectedHandles []bool
+	}{
+		{
+			name:            "case 0: object of first version is handled by first resource set",
+			obj:             newCR("4.9.0"),
+			expectedHandles: []bool{true, false},
+		},
+		{
+			name:            "case 1: object of second version is handled by second resource set",
+			obj:             newCR("5.0.0"),
+			expectedHandles: []bool{false, true},
+		},
+		{
+			name:            "case 2: object of unregistered version is not handled",
+			obj:             newCR("3.0.0"),
+			expectedHandles: []bool{false, false},
+		},
+		{
+			name:            "case 3: object of wrong type is not handled",
+			obj:             &v1alpha1.KVMConfig{},
+			expectedHandles: []bool{false, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, rs := range resourceSets {
+				if rs.Handles(tc.obj) != tc.expectedHandles[i] {
+					t.Fatalf("expected resource set %d to handle object %t", i, tc.expectedHandles[i])
+				}
+			}
+		})
+	}
+}
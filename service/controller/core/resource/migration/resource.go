@@ -23,12 +23,10 @@ import (
 	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+	"github.com/giantswarm/aws-operator/service/controller/core/key"
 )
 
 const (
-	name = "migrationv26"
-
 	awsConfigNamespace               = "default"
 	credentialSecretDefaultNamespace = "giantswarm"
 	credentialSecretDefaultName      = "credential-default"
@@ -37,11 +35,16 @@ const (
 type Config struct {
 	G8sClient versioned.Interface
 	Logger    micrologger.Logger
+
+	// Name is the versioned identifier of the resource, e.g. migrationv25.
+	Name string
 }
 
 type Resource struct {
 	g8sClient versioned.Interface
 	logger    micrologger.Logger
+
+	name string
 }
 
 func New(config Config) (*Resource, error) {
@@ -52,16 +55,22 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Name must not be empty", config)
+	}
+
 	r := &Resource{
 		g8sClient: config.G8sClient,
 		logger:    config.Logger,
+
+		name: config.Name,
 	}
 
 	return r, nil
 }
 
 func (r *Resource) Name() string {
-	return name
+	return r.name
 }
 
 func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
@@ -163,7 +163,7 @@ func Test_migrateSpec(t *testing.T) {
 			}
 
 			if !reflect.DeepEqual(tc.spec, tc.expectedSpec) {
-				t.Errorf("spec == %q, want %q", tc.spec, tc.expectedSpec)
+				t.Errorf("spec == %#v, want %#v", tc.spec, tc.expectedSpec)
 			}
 		})
 	}
@@ -100,6 +100,8 @@ func Test_Resource_Namespace_newCreateChange(t *testing.T) {
 		c := Config{
 			K8sClient: fake.NewSimpleClientset(),
 			Logger:    microloggertest.New(),
+
+			Name: "namespace",
 		}
 		newResource, err = New(c)
 		if err != nil {
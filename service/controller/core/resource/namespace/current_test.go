@@ -35,6 +35,8 @@ func Test_Resource_Namespace_GetCurrentState(t *testing.T) {
 		c := Config{
 			K8sClient: fake.NewSimpleClientset(),
 			Logger:    microloggertest.New(),
+
+			Name: "namespace",
 		}
 		newResource, err = New(c)
 		if err != nil {
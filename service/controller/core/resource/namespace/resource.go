@@ -7,16 +7,19 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
-const (
-	// Name is the identifier of the resource.
-	Name = "namespacev22patch1"
-)
-
 // Config represents the configuration used to create a new namespace resource.
 type Config struct {
 	// Dependencies.
 	K8sClient kubernetes.Interface
 	Logger    micrologger.Logger
+
+	// Settings.
+	//
+	// Name is the identifier of the resource. Version bundles set their own
+	// versioned name, e.g. namespacev25, so that the identity of the resource,
+	// e.g. in metrics and logs, is kept from when each version bundle had its
+	// own copy of the resource.
+	Name string
 }
 
 // Resource implements the namespace resource.
@@ -24,6 +27,9 @@ type Resource struct {
 	// Dependencies.
 	k8sClient kubernetes.Interface
 	logger    micrologger.Logger
+
+	// Settings.
+	name string
 }
 
 // New creates a new configured namespace resource.
@@ -36,17 +42,25 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
 	}
 
+	// Settings.
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Name must not be empty")
+	}
+
 	newResource := &Resource{
 		// Dependencies.
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
+
+		// Settings.
+		name: config.Name,
 	}
 
 	return newResource, nil
 }
 
 func (r *Resource) Name() string {
-	return Name
+	return r.name
 }
 
 func toNamespace(v interface{}) (*apiv1.Namespace, error) {
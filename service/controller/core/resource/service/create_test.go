@@ -87,6 +87,8 @@ func Test_Resource_Service_newCreateChange(t *testing.T) {
 		c := Config{
 			K8sClient: fake.NewSimpleClientset(),
 			Logger:    microloggertest.New(),
+
+			Name: "service",
 		}
 		newResource, err = New(c)
 		if err != nil {
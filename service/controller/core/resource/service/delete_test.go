@@ -107,6 +107,8 @@ func Test_Resource_Service_newDeleteChange(t *testing.T) {
 		c := Config{
 			K8sClient: fake.NewSimpleClientset(),
 			Logger:    microloggertest.New(),
+
+			Name: "service",
 		}
 		newResource, err = New(c)
 		if err != nil {
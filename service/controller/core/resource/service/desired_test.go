@@ -43,6 +43,8 @@ func Test_Resource_Service_GetDesiredState(t *testing.T) {
 		c := Config{
 			K8sClient: fake.NewSimpleClientset(),
 			Logger:    microloggertest.New(),
+
+			Name: "service",
 		}
 		newResource, err = New(c)
 		if err != nil {
@@ -70,11 +72,11 @@ func Test_Resource_Service_GetDesiredState(t *testing.T) {
 			}
 
 			if int32(tc.expectedPort) != desiredService.Spec.Ports[0].Port {
-				t.Errorf("expected port %q got %q", int32(tc.expectedPort), desiredService.Spec.Ports[0].Port)
+				t.Errorf("expected port %d got %d", int32(tc.expectedPort), desiredService.Spec.Ports[0].Port)
 			}
 
 			if intstr.FromInt(tc.expectedPort) != desiredService.Spec.Ports[0].TargetPort {
-				t.Errorf("expected target port %q got %q", intstr.FromInt(tc.expectedPort), desiredService.Spec.Ports[0].TargetPort)
+				t.Errorf("expected target port %#v got %#v", intstr.FromInt(tc.expectedPort), desiredService.Spec.Ports[0].TargetPort)
 			}
 		})
 	}
@@ -10,9 +10,6 @@ import (
 )
 
 const (
-	// Name is the identifier of the resource.
-	Name = "servicev22patch1"
-
 	httpsPort         = 443
 	masterServiceName = "master"
 )
@@ -22,6 +19,11 @@ type Config struct {
 	// Dependencies.
 	K8sClient kubernetes.Interface
 	Logger    micrologger.Logger
+
+	// Settings.
+	//
+	// Name is the versioned identifier of the resource, e.g. servicev25.
+	Name string
 }
 
 // Resource implements the service resource.
@@ -29,6 +31,9 @@ type Resource struct {
 	// Dependencies.
 	k8sClient kubernetes.Interface
 	logger    micrologger.Logger
+
+	// Settings.
+	name string
 }
 
 // New creates a new configured service resource.
@@ -41,17 +46,25 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
 	}
 
+	// Settings.
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "config.Name must not be empty")
+	}
+
 	newResource := &Resource{
 		// Dependencies.
 		k8sClient: config.K8sClient,
 		logger:    config.Logger,
+
+		// Settings.
+		name: config.Name,
 	}
 
 	return newResource, nil
 }
 
 func (r *Resource) Name() string {
-	return Name
+	return r.name
 }
 
 func isServiceModified(a, b *apiv1.Service) bool {
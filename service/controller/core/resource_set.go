@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	"github.com/giantswarm/operatorkit/controller"
+	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
+	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
+)
+
+type ResourceSetConfig struct {
+	Logger micrologger.Logger
+
+	// InitCtx prepares the context of a single reconciliation loop, e.g. with
+	// the version bundle specific controller context.
+	InitCtx func(ctx context.Context, obj interface{}) (context.Context, error)
+	// Resources are the version bundle specific resources executed in the given
+	// order.
+	Resources []controller.Resource
+}
+
+// NewResourceSet creates a resource set out of the resources of a version
+// bundle. All resources are wrapped with the retry and metrics resources.
+// Dispatching runtime objects to the created resource set is done by the
+// Registry the resource set is registered with.
+func NewResourceSet(config ResourceSetConfig) (*controller.ResourceSet, error) {
+	var err error
+
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+	if len(config.Resources) == 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Resources must not be empty", config)
+	}
+
+	resources := config.Resources
+
+	{
+		c := retryresource.WrapConfig{
+			Logger: config.Logger,
+		}
+
+		resources, err = retryresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	{
+		c := metricsresource.WrapConfig{}
+
+		resources, err = metricsresource.Wrap(resources, c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var resourceSet *controller.ResourceSet
+	{
+		c := controller.ResourceSetConfig{
+			InitCtx:   config.InitCtx,
+			Logger:    config.Logger,
+			Resources: resources,
+		}
+
+		resourceSet, err = controller.NewResourceSet(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	return resourceSet, nil
+}
@@ -0,0 +1,18 @@
+package core
+
+import (
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+)
+
+// AWSConfigVersion returns the version bundle version of the given AWSConfig
+// CR. It is meant to be used as RegistryConfig.VersionFunc for controllers
+// reconciling AWSConfig CRs.
+func AWSConfigVersion(obj interface{}) (string, error) {
+	cr, ok := obj.(*v1alpha1.AWSConfig)
+	if !ok {
+		return "", microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &v1alpha1.AWSConfig{}, obj)
+	}
+
+	return cr.Spec.VersionBundle.Version, nil
+}
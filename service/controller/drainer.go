@@ -14,12 +14,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 
 	awsclient "github.com/giantswarm/aws-operator/client/aws"
-	"github.com/giantswarm/aws-operator/service/controller/v22"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1"
-	"github.com/giantswarm/aws-operator/service/controller/v23"
-	"github.com/giantswarm/aws-operator/service/controller/v24"
-	"github.com/giantswarm/aws-operator/service/controller/v25"
-	"github.com/giantswarm/aws-operator/service/controller/v26"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+	"github.com/giantswarm/aws-operator/service/controller/core"
 )
 
 type DrainerConfig struct {
@@ -133,100 +129,39 @@ func newDrainerResourceSets(config DrainerConfig) ([]*controller.ResourceSet, er
 		}
 	}
 
-	var v22ResourceSet *controller.ResourceSet
+	var eventRecorder recorder.Interface
 	{
-		c := v22.DrainerResourceSetConfig{
-			G8sClient: config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
+		c := recorder.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			GuestUpdateEnabled: config.GuestUpdateEnabled,
-			ProjectName:        config.ProjectName,
+			Component: config.ProjectName,
 		}
-		v22ResourceSet, err = v22.NewDrainerResourceSet(c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
 
-	var v22patch1ResourceSet *controller.ResourceSet
-	{
-		c := v22patch1.DrainerResourceSetConfig{
-			G8sClient: config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
-
-			GuestUpdateEnabled: config.GuestUpdateEnabled,
-			ProjectName:        config.ProjectName,
-		}
-		v22patch1ResourceSet, err = v22patch1.NewDrainerResourceSet(c)
+		eventRecorder, err = recorder.New(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var v23ResourceSet *controller.ResourceSet
+	var registry *core.Registry
 	{
-		c := v23.DrainerResourceSetConfig{
-			G8sClient: config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-				Region:          config.HostAWSConfig.Region,
-			},
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
+		c := core.RegistryConfig{
+			Logger: config.Logger,
 
-			GuestUpdateEnabled: config.GuestUpdateEnabled,
-			ProjectName:        config.ProjectName,
+			VersionFunc: core.AWSConfigVersion,
 		}
-		v23ResourceSet, err = v23.NewDrainerResourceSet(c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	var v24ResourceSet *controller.ResourceSet
-	{
-		c := v24.DrainerResourceSetConfig{
-			ControlPlaneAWSClients: controlPlaneAWSClients,
-			G8sClient:              config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				Region:          config.HostAWSConfig.Region,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-			},
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
 
-			ProjectName:    config.ProjectName,
-			Route53Enabled: config.Route53Enabled,
-		}
-
-		v24ResourceSet, err = v24.NewDrainerResourceSet(c)
+		registry, err = core.NewRegistry(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	var v25ResourceSet *controller.ResourceSet
-	{
-		c := v25.DrainerResourceSetConfig{
+	for _, b := range Bundles() {
+		c := core.DrainerResourceSetConfig{
 			ControlPlaneAWSClients: controlPlaneAWSClients,
+			EventRecorder:          eventRecorder,
 			G8sClient:              config.G8sClient,
 			HostAWSConfig: awsclient.Config{
 				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
@@ -237,47 +172,25 @@ func newDrainerResourceSets(config DrainerConfig) ([]*controller.ResourceSet, er
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
 
-			ProjectName:    config.ProjectName,
-			Route53Enabled: config.Route53Enabled,
+			GuestUpdateEnabled: config.GuestUpdateEnabled,
+			ProjectName:        config.ProjectName,
+			Route53Enabled:     config.Route53Enabled,
 		}
 
-		v25ResourceSet, err = v25.NewDrainerResourceSet(c)
+		resourceSet, err := b.NewDrainerResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
-	}
-
-	var v26ResourceSet *controller.ResourceSet
-	{
-		c := v26.DrainerResourceSetConfig{
-			ControlPlaneAWSClients: controlPlaneAWSClients,
-			G8sClient:              config.G8sClient,
-			HostAWSConfig: awsclient.Config{
-				AccessKeyID:     config.HostAWSConfig.AccessKeyID,
-				AccessKeySecret: config.HostAWSConfig.AccessKeySecret,
-				Region:          config.HostAWSConfig.Region,
-				SessionToken:    config.HostAWSConfig.SessionToken,
-			},
-			K8sClient: config.K8sClient,
-			Logger:    config.Logger,
-
-			ProjectName:    config.ProjectName,
-			Route53Enabled: config.Route53Enabled,
-		}
 
-		v26ResourceSet, err = v26.NewDrainerResourceSet(c)
+		err = registry.Register(b.VersionBundle, resourceSet)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
 	}
 
-	resourceSets := []*controller.ResourceSet{
-		v22ResourceSet,
-		v22patch1ResourceSet,
-		v23ResourceSet,
-		v24ResourceSet,
-		v25ResourceSet,
-		v26ResourceSet,
+	resourceSets, err := registry.ResourceSets()
+	if err != nil {
+		return nil, microerror.Mask(err)
 	}
 
 	return resourceSets, nil
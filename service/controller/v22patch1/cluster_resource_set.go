@@ -11,8 +11,6 @@ import (
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/controller"
 	"github.com/giantswarm/operatorkit/controller/context/updateallowedcontext"
-	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
-	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
 	"github.com/giantswarm/randomkeys"
 	"github.com/giantswarm/statusresource"
 	"github.com/giantswarm/tenantcluster"
@@ -20,6 +18,10 @@ import (
 
 	"github.com/giantswarm/aws-operator/client/aws"
 	awsservice "github.com/giantswarm/aws-operator/service/aws"
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/migration"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/namespace"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/adapter"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/cloudconfig"
 	cloudformationservice "github.com/giantswarm/aws-operator/service/controller/v22patch1/cloudformation"
@@ -39,13 +41,11 @@ import (
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/endpoints"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/ipam"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/loadbalancer"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/migration"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/namespace"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/s3bucket"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/s3object"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/stackoutput"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/workerasgname"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 const (
@@ -246,6 +246,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := migration.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+
+			Name: "migrationv22patch1",
 		}
 
 		migrationResource, err = migration.New(c)
@@ -254,11 +256,25 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		}
 	}
 
-	var ipamResource controller.Resource
+	var ipamAllocationService ipamallocation.Interface
 	{
-		c := ipam.Config{
+		c := ipamallocation.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+		}
+
+		ipamAllocationService, err = ipamallocation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ipamResource controller.Resource
+	{
+		c := ipam.Config{
+			G8sClient:      config.G8sClient,
+			IPAMAllocation: ipamAllocationService,
+			Logger:         config.Logger,
 
 			AllocatedSubnetMaskBits: config.GuestSubnetMaskBits,
 			AvailabilityZones:       config.GuestAvailabilityZones,
@@ -396,6 +412,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := namespace.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "namespacev22patch1",
 		}
 
 		ops, err := namespace.New(c)
@@ -414,6 +432,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := service.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "servicev22patch1",
 		}
 
 		ops, err := service.New(c)
@@ -541,39 +561,6 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		endpointsResource,
 	}
 
-	{
-		c := retryresource.WrapConfig{
-			Logger: config.Logger,
-		}
-
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	{
-		c := metricsresource.WrapConfig{}
-
-		resources, err = metricsresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	handlesFunc := func(obj interface{}) bool {
-		customObject, err := key.ToCustomObject(obj)
-		if err != nil {
-			return false
-		}
-
-		if key.VersionBundleVersion(customObject) == VersionBundle().Version {
-			return true
-		}
-
-		return false
-	}
-
 	initCtxFunc := func(ctx context.Context, obj interface{}) (context.Context, error) {
 		if config.GuestUpdateEnabled {
 			updateallowedcontext.SetUpdateAllowed(ctx)
@@ -647,14 +634,14 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 
 	var resourceSet *controller.ResourceSet
 	{
-		c := controller.ResourceSetConfig{
-			Handles:   handlesFunc,
+		c := core.ResourceSetConfig{
+			Logger: config.Logger,
+
 			InitCtx:   initCtxFunc,
-			Logger:    config.Logger,
 			Resources: resources,
 		}
 
-		resourceSet, err = controller.NewResourceSet(c)
+		resourceSet, err = core.NewResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -8,15 +8,13 @@ import (
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/controller"
 	"github.com/giantswarm/operatorkit/controller/context/updateallowedcontext"
-	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
-	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/service/controller/core"
 	cloudformationservice "github.com/giantswarm/aws-operator/service/controller/v22patch1/cloudformation"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/credential"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1/key"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/drainer"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/drainfinisher"
 	"github.com/giantswarm/aws-operator/service/controller/v22patch1/resource/stackoutput"
@@ -97,39 +95,6 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 		drainFinisherResource,
 	}
 
-	{
-		c := retryresource.WrapConfig{
-			Logger: config.Logger,
-		}
-
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	{
-		c := metricsresource.WrapConfig{}
-
-		resources, err = metricsresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	handlesFunc := func(obj interface{}) bool {
-		customObject, err := key.ToCustomObject(obj)
-		if err != nil {
-			return false
-		}
-
-		if key.VersionBundleVersion(customObject) == VersionBundle().Version {
-			return true
-		}
-
-		return false
-	}
-
 	initCtxFunc := func(ctx context.Context, obj interface{}) (context.Context, error) {
 		if config.GuestUpdateEnabled {
 			updateallowedcontext.SetUpdateAllowed(ctx)
@@ -173,14 +138,14 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 
 	var resourceSet *controller.ResourceSet
 	{
-		c := controller.ResourceSetConfig{
-			Handles:   handlesFunc,
+		c := core.ResourceSetConfig{
+			Logger: config.Logger,
+
 			InitCtx:   initCtxFunc,
-			Logger:    config.Logger,
 			Resources: resources,
 		}
 
-		resourceSet, err = controller.NewResourceSet(c)
+		resourceSet, err = core.NewResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
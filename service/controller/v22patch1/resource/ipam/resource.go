@@ -7,6 +7,8 @@ import (
 	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 const (
@@ -14,8 +16,9 @@ const (
 )
 
 type Config struct {
-	G8sClient versioned.Interface
-	Logger    micrologger.Logger
+	G8sClient      versioned.Interface
+	IPAMAllocation ipamallocation.Interface
+	Logger         micrologger.Logger
 
 	AllocatedSubnetMaskBits int
 	AvailabilityZones       []string
@@ -23,8 +26,9 @@ type Config struct {
 }
 
 type Resource struct {
-	g8sClient versioned.Interface
-	logger    micrologger.Logger
+	g8sClient      versioned.Interface
+	ipamAllocation ipamallocation.Interface
+	logger         micrologger.Logger
 
 	allocatedSubnetMask net.IPMask
 	availabilityZones   []string
@@ -35,6 +39,9 @@ func New(config Config) (*Resource, error) {
 	if config.G8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
 	}
+	if config.IPAMAllocation == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.IPAMAllocation must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
@@ -47,8 +54,9 @@ func New(config Config) (*Resource, error) {
 	}
 
 	newResource := &Resource{
-		g8sClient: config.G8sClient,
-		logger:    config.Logger,
+		g8sClient:      config.G8sClient,
+		ipamAllocation: config.IPAMAllocation,
+		logger:         config.Logger,
 
 		allocatedSubnetMask: net.CIDRMask(config.AllocatedSubnetMaskBits, 32),
 		availabilityZones:   config.AvailabilityZones,
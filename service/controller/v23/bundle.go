@@ -0,0 +1,90 @@
+package v23
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller"
+
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/v23/adapter"
+	"github.com/giantswarm/aws-operator/service/controller/v23/cloudconfig"
+)
+
+// Bundle returns the declaration of the v23 version bundle, which creates its
+// resource sets out of the settings it supports.
+func Bundle() core.Bundle {
+	return core.Bundle{
+		VersionBundle: VersionBundle(),
+
+		NewClusterResourceSet: newClusterResourceSetFromCore,
+		NewDrainerResourceSet: newDrainerResourceSetFromCore,
+	}
+}
+
+func newClusterResourceSetFromCore(config core.ClusterResourceSetConfig) (*controller.ResourceSet, error) {
+	c := ClusterResourceSetConfig{
+		CertsSearcher:      config.CertsSearcher,
+		G8sClient:          config.G8sClient,
+		HostAWSConfig:      config.HostAWSConfig,
+		HostAWSClients:     config.ControlPlaneAWSClients,
+		K8sClient:          config.K8sClient,
+		Logger:             config.Logger,
+		RandomKeysSearcher: config.RandomKeysSearcher,
+
+		AccessLogsExpiration:  config.AccessLogsExpiration,
+		AdvancedMonitoringEC2: config.AdvancedMonitoringEC2,
+		APIWhitelist: adapter.APIWhitelist{
+			Enabled:    config.APIWhitelist.Enabled,
+			SubnetList: config.APIWhitelist.SubnetList,
+		},
+		DeleteLoggingBucket:        config.DeleteLoggingBucket,
+		EncrypterBackend:           config.EncrypterBackend,
+		GuestAvailabilityZones:     config.GuestAvailabilityZones,
+		GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
+		GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
+		GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
+		GuestUpdateEnabled:         config.GuestUpdateEnabled,
+		IgnitionPath:               config.IgnitionPath,
+		IncludeTags:                config.IncludeTags,
+		InstallationName:           config.InstallationName,
+		IPAMNetworkRange:           config.IPAMNetworkRange,
+		OIDC: cloudconfig.OIDCConfig{
+			ClientID:      config.OIDC.ClientID,
+			IssuerURL:     config.OIDC.IssuerURL,
+			UsernameClaim: config.OIDC.UsernameClaim,
+			GroupsClaim:   config.OIDC.GroupsClaim,
+		},
+		PodInfraContainerImage: config.PodInfraContainerImage,
+		ProjectName:            config.ProjectName,
+		PublicRouteTables:      config.RouteTables,
+		RegistryDomain:         config.RegistryDomain,
+		Route53Enabled:         config.Route53Enabled,
+		SSOPublicKey:           config.SSOPublicKey,
+		VaultAddress:           config.VaultAddress,
+	}
+
+	resourceSet, err := NewClusterResourceSet(c)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resourceSet, nil
+}
+
+func newDrainerResourceSetFromCore(config core.DrainerResourceSetConfig) (*controller.ResourceSet, error) {
+	c := DrainerResourceSetConfig{
+		G8sClient:     config.G8sClient,
+		HostAWSConfig: config.HostAWSConfig,
+		K8sClient:     config.K8sClient,
+		Logger:        config.Logger,
+
+		GuestUpdateEnabled: config.GuestUpdateEnabled,
+		ProjectName:        config.ProjectName,
+	}
+
+	resourceSet, err := NewDrainerResourceSet(c)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resourceSet, nil
+}
@@ -1,63 +0,0 @@
-package namespace
-
-import (
-	"github.com/giantswarm/microerror"
-	"github.com/giantswarm/micrologger"
-	apiv1 "k8s.io/api/core/v1"
-	"k8s.io/client-go/kubernetes"
-)
-
-const (
-	// Name is the identifier of the resource.
-	Name = "namespacev23"
-)
-
-// Config represents the configuration used to create a new namespace resource.
-type Config struct {
-	// Dependencies.
-	K8sClient kubernetes.Interface
-	Logger    micrologger.Logger
-}
-
-// Resource implements the namespace resource.
-type Resource struct {
-	// Dependencies.
-	k8sClient kubernetes.Interface
-	logger    micrologger.Logger
-}
-
-// New creates a new configured namespace resource.
-func New(config Config) (*Resource, error) {
-	// Dependencies.
-	if config.K8sClient == nil {
-		return nil, microerror.Maskf(invalidConfigError, "config.K8sClient must not be empty")
-	}
-	if config.Logger == nil {
-		return nil, microerror.Maskf(invalidConfigError, "config.Logger must not be empty")
-	}
-
-	newResource := &Resource{
-		// Dependencies.
-		k8sClient: config.K8sClient,
-		logger:    config.Logger,
-	}
-
-	return newResource, nil
-}
-
-func (r *Resource) Name() string {
-	return Name
-}
-
-func toNamespace(v interface{}) (*apiv1.Namespace, error) {
-	if v == nil {
-		return nil, nil
-	}
-
-	namespace, ok := v.(*apiv1.Namespace)
-	if !ok {
-		return nil, microerror.Maskf(wrongTypeError, "expected '%T', got '%T'", &apiv1.Namespace{}, v)
-	}
-
-	return namespace, nil
-}
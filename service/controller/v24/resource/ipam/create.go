@@ -20,6 +20,7 @@ import (
 
 	"github.com/giantswarm/aws-operator/service/controller/v24/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v24/key"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 func init() {
@@ -89,7 +90,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		} else {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "allocating cluster subnet CIDR")
 
-			subnetCIDR, err = r.allocateSubnet(ctx)
+			subnetCIDR, err = r.allocateSubnet(ctx, key.ClusterID(customResource))
 			if err != nil {
 				return microerror.Mask(err)
 			}
@@ -134,11 +135,30 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
-func (r *Resource) allocateSubnet(ctx context.Context) (net.IPNet, error) {
+// allocateSubnet finds a free subnet for the given tenant cluster and reserves
+// it by creating an IPAMAllocation CR. In case another tenant cluster
+// reserved the same subnet concurrently, the next free subnet is tried. A
+// subnet reserved for the tenant cluster in a previous reconciliation is used
+// right away.
+func (r *Resource) allocateSubnet(ctx context.Context, clusterID string) (net.IPNet, error) {
 	var err error
 	var mutex sync.Mutex
 	var reservedSubnets []net.IPNet
 
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding reserved subnet of tenant cluster")
+
+		subnet, err := r.ipamAllocation.Lookup(ctx, clusterID)
+		if ipamallocation.IsNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find reserved subnet of tenant cluster")
+		} else if err != nil {
+			return net.IPNet{}, microerror.Mask(err)
+		} else {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found reserved subnet %#q of tenant cluster", subnet.String()))
+			return subnet, nil
+		}
+	}
+
 	g := &errgroup.Group{}
 
 	g.Go(func() error {
@@ -173,6 +193,22 @@ func (r *Resource) allocateSubnet(ctx context.Context) (net.IPNet, error) {
 		return nil
 	})
 
+	g.Go(func() error {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding allocated subnets from IPAMAllocations")
+
+		subnets, err := r.ipamAllocation.Allocations(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		mutex.Lock()
+		reservedSubnets = append(reservedSubnets, subnets...)
+		mutex.Unlock()
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found allocated subnets from IPAMAllocations")
+
+		return nil
+	})
+
 	err = g.Wait()
 	if err != nil {
 		return net.IPNet{}, microerror.Mask(err)
@@ -180,19 +216,36 @@ func (r *Resource) allocateSubnet(ctx context.Context) (net.IPNet, error) {
 
 	reservedSubnets = ipam.CanonicalizeSubnets(r.networkRange, reservedSubnets)
 
-	var subnet net.IPNet
-	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", "finding free subnet")
+	for {
+		var subnet net.IPNet
+		{
+			r.logger.LogCtx(ctx, "level", "debug", "message", "finding free subnet")
 
-		subnet, err = ipam.Free(r.networkRange, r.allocatedSubnetMask, reservedSubnets)
-		if err != nil {
-			return net.IPNet{}, microerror.Maskf(err, "networkRange: %s, allocatedSubnetMask: %s, reservedSubnets: %#v", r.networkRange.String(), r.allocatedSubnetMask.String(), reservedSubnets)
+			subnet, err = ipam.Free(r.networkRange, r.allocatedSubnetMask, reservedSubnets)
+			if err != nil {
+				return net.IPNet{}, microerror.Maskf(err, "networkRange: %s, allocatedSubnetMask: %s, reservedSubnets: %#v", r.networkRange.String(), r.allocatedSubnetMask.String(), reservedSubnets)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found free subnet %#q", subnet.String()))
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found free subnet %#q", subnet.String()))
-	}
+		{
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("reserving subnet %#q", subnet.String()))
+
+			err = r.ipamAllocation.Reserve(ctx, clusterID, subnet)
+			if ipamallocation.IsAlreadyAllocated(err) {
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not reserve subnet %#q because it got allocated for another tenant cluster", subnet.String()))
+				reservedSubnets = append(reservedSubnets, subnet)
+				continue
+			} else if err != nil {
+				return net.IPNet{}, microerror.Mask(err)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("reserved subnet %#q", subnet.String()))
+		}
 
-	return subnet, nil
+		return subnet, nil
+	}
 }
 
 func (r *Resource) selectRandomAZs(n int) ([]string, error) {
@@ -1,138 +0,0 @@
-package service
-
-import (
-	"context"
-	"testing"
-
-	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
-	"github.com/giantswarm/micrologger/microloggertest"
-	apiv1 "k8s.io/api/core/v1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/fake"
-)
-
-func Test_Resource_Service_newDeleteChange(t *testing.T) {
-	t.Parallel()
-	testCases := []struct {
-		description     string
-		obj             interface{}
-		cur             interface{}
-		des             interface{}
-		expectedService *apiv1.Service
-	}{
-		{
-			description: "current state matches desired state, return desired state",
-			obj: &v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					Cluster: v1alpha1.Cluster{
-						ID: "foobar",
-					},
-				},
-			},
-			cur: &apiv1.Service{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Service",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "master",
-				},
-			},
-			des: &apiv1.Service{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Service",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "master",
-				},
-			},
-			expectedService: &apiv1.Service{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Service",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "master",
-				},
-			},
-		},
-		{
-			description: "current state is empty, no change",
-			obj: &v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					Cluster: v1alpha1.Cluster{
-						ID: "foobar",
-					},
-				},
-			},
-			cur: nil,
-			des: &apiv1.Service{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Service",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "master",
-				},
-			},
-			expectedService: nil,
-		},
-		{
-			description: "current and desired states are different, no change",
-			obj: &v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					Cluster: v1alpha1.Cluster{
-						ID: "foobar",
-					},
-				},
-			},
-			cur: nil,
-			des: &apiv1.Service{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Service",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "master",
-				},
-			},
-			expectedService: nil,
-		},
-	}
-
-	var err error
-	var newResource *Resource
-	{
-		c := Config{
-			K8sClient: fake.NewSimpleClientset(),
-			Logger:    microloggertest.New(),
-		}
-		newResource, err = New(c)
-		if err != nil {
-			t.Fatal("expected", nil, "got", err)
-		}
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.description, func(t *testing.T) {
-			result, err := newResource.newDeleteChange(context.TODO(), tc.obj, tc.cur, tc.des)
-			if err != nil {
-				t.Errorf("expected '%v' got '%#v'", nil, err)
-			}
-			if tc.expectedService == nil {
-				if tc.expectedService != result {
-					t.Errorf("expected '%v' got '%v'", tc.expectedService, result)
-				}
-			} else {
-				serviceToDelete, ok := result.(*apiv1.Service)
-				if !ok {
-					t.Fatalf("case expected '%T', got '%T'", serviceToDelete, result)
-				}
-				if tc.expectedService.Name != serviceToDelete.Name {
-					t.Errorf("expected %s, got %s", tc.expectedService.Name, serviceToDelete.Name)
-				}
-			}
-		})
-	}
-}
@@ -11,14 +11,16 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/controller"
-	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
-	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
 	"github.com/giantswarm/randomkeys"
 	"github.com/giantswarm/statusresource"
 	"github.com/giantswarm/tenantcluster"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/migration"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/namespace"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v25/adapter"
 	"github.com/giantswarm/aws-operator/service/controller/v25/cloudconfig"
 	"github.com/giantswarm/aws-operator/service/controller/v25/controllercontext"
@@ -38,19 +40,17 @@ import (
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/endpoints"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/ipam"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/loadbalancer"
-	"github.com/giantswarm/aws-operator/service/controller/v25/resource/migration"
-	"github.com/giantswarm/aws-operator/service/controller/v25/resource/namespace"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/natgatewayaddresses"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/peerrolearn"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/routetable"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/s3bucket"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/s3object"
-	"github.com/giantswarm/aws-operator/service/controller/v25/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/tccp"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/tccpoutputs"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/tccpsubnet"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/vpccidr"
 	"github.com/giantswarm/aws-operator/service/controller/v25/resource/workerasgname"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 const (
@@ -228,6 +228,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := migration.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+
+			Name: "migrationv25",
 		}
 
 		migrationResource, err = migration.New(c)
@@ -236,11 +238,25 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		}
 	}
 
-	var ipamResource controller.Resource
+	var ipamAllocationService ipamallocation.Interface
 	{
-		c := ipam.Config{
+		c := ipamallocation.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+		}
+
+		ipamAllocationService, err = ipamallocation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ipamResource controller.Resource
+	{
+		c := ipam.Config{
+			G8sClient:      config.G8sClient,
+			IPAMAllocation: ipamAllocationService,
+			Logger:         config.Logger,
 
 			AllocatedSubnetMaskBits: config.GuestSubnetMaskBits,
 			AvailabilityZones:       config.GuestAvailabilityZones,
@@ -417,6 +433,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := namespace.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "namespacev25",
 		}
 
 		ops, err := namespace.New(c)
@@ -475,6 +493,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := service.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "servicev25",
 		}
 
 		ops, err := service.New(c)
@@ -607,39 +627,6 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		endpointsResource,
 	}
 
-	{
-		c := retryresource.WrapConfig{
-			Logger: config.Logger,
-		}
-
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	{
-		c := metricsresource.WrapConfig{}
-
-		resources, err = metricsresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	handlesFunc := func(obj interface{}) bool {
-		customObject, err := key.ToCustomObject(obj)
-		if err != nil {
-			return false
-		}
-
-		if key.VersionBundleVersion(customObject) == VersionBundle().Version {
-			return true
-		}
-
-		return false
-	}
-
 	initCtxFunc := func(ctx context.Context, obj interface{}) (context.Context, error) {
 		var tenantClusterAWSClients aws.Clients
 		{
@@ -673,14 +660,14 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 
 	var resourceSet *controller.ResourceSet
 	{
-		c := controller.ResourceSetConfig{
-			Handles:   handlesFunc,
+		c := core.ResourceSetConfig{
+			Logger: config.Logger,
+
 			InitCtx:   initCtxFunc,
-			Logger:    config.Logger,
 			Resources: resources,
 		}
 
-		resourceSet, err = controller.NewResourceSet(c)
+		resourceSet, err = core.NewResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -1,9 +1,30 @@
 package ipam
 
-import "context"
+import (
+	"context"
 
-// EnsureDeleted is a NOP for IPAM resource as allocated subnet will get
-// released when the guest cluster VPC and AWSConfig CR is deleted.
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v25/key"
+)
+
+// EnsureDeleted releases the IPAMAllocation of the tenant cluster's subnet.
+// The subnet itself is freed once the tenant cluster's VPC and AWSConfig CR
+// are deleted.
 func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCustomObject(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", "releasing reserved subnet of tenant cluster")
+
+	err = r.ipamAllocation.Release(ctx, key.ClusterID(cr))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", "released reserved subnet of tenant cluster")
+
 	return nil
 }
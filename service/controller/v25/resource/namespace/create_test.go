@@ -1,126 +0,0 @@
-package namespace
-
-import (
-	"context"
-	"testing"
-
-	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
-	"github.com/giantswarm/micrologger/microloggertest"
-	apiv1 "k8s.io/api/core/v1"
-	apismetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/kubernetes/fake"
-)
-
-func Test_Resource_Namespace_newCreateChange(t *testing.T) {
-	t.Parallel()
-	testCases := []struct {
-		Obj               interface{}
-		Cur               interface{}
-		Des               interface{}
-		ExpectedNamespace *apiv1.Namespace
-	}{
-		{
-			Obj: &v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					Cluster: v1alpha1.Cluster{
-						ID: "foobar",
-					},
-				},
-			},
-			Cur: &apiv1.Namespace{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Namespace",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "al9qy",
-					Labels: map[string]string{
-						"cluster":  "al9qy",
-						"customer": "test-customer",
-					},
-				},
-			},
-			Des: &apiv1.Namespace{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Namespace",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "al9qy",
-					Labels: map[string]string{
-						"cluster":  "al9qy",
-						"customer": "test-customer",
-					},
-				},
-			},
-			ExpectedNamespace: nil,
-		},
-
-		{
-			Obj: &v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					Cluster: v1alpha1.Cluster{
-						ID: "foobar",
-					},
-				},
-			},
-			Cur: nil,
-			Des: &apiv1.Namespace{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Namespace",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "al9qy",
-					Labels: map[string]string{
-						"cluster":  "al9qy",
-						"customer": "test-customer",
-					},
-				},
-			},
-			ExpectedNamespace: &apiv1.Namespace{
-				TypeMeta: apismetav1.TypeMeta{
-					Kind:       "Namespace",
-					APIVersion: "v1",
-				},
-				ObjectMeta: apismetav1.ObjectMeta{
-					Name: "al9qy",
-					Labels: map[string]string{
-						"cluster":  "al9qy",
-						"customer": "test-customer",
-					},
-				},
-			},
-		},
-	}
-
-	var err error
-	var newResource *Resource
-	{
-		c := Config{
-			K8sClient: fake.NewSimpleClientset(),
-			Logger:    microloggertest.New(),
-		}
-		newResource, err = New(c)
-		if err != nil {
-			t.Fatal("expected", nil, "got", err)
-		}
-	}
-
-	for i, tc := range testCases {
-		result, err := newResource.newCreateChange(context.TODO(), tc.Obj, tc.Cur, tc.Des)
-		if err != nil {
-			t.Fatal("case", i+1, "expected", nil, "got", err)
-		}
-		if tc.ExpectedNamespace == nil {
-			if tc.ExpectedNamespace != result {
-				t.Fatal("case", i+1, "expected", tc.ExpectedNamespace, "got", result)
-			}
-		} else {
-			name := result.(*apiv1.Namespace).Name
-			if tc.ExpectedNamespace.Name != name {
-				t.Fatal("case", i+1, "expected", tc.ExpectedNamespace.Name, "got", name)
-			}
-		}
-	}
-}
@@ -20,7 +20,7 @@
 // `service/template/cloudformation/main.yaml` to include the new template.
 // * Add the adapter logic file in `service/resource/cloudformation/adapter` with the type
 // definition and the Hydrater function to fill the fields (like asg.go or
-// launch_configuration.go).
+// launch_template.go).
 // * Add the new type to the Adapter type in `service/resource/cloudformation/adapter/adapter.go`
 // and include the Hydrater function in the `hydraters` slice.
 package adapter
@@ -36,9 +36,13 @@ type Config struct {
 	ControlPlaneAccountID           string
 	ControlPlaneNATGatewayAddresses []*ec2.Address
 	ControlPlanePeerRoleARN         string
+	ControlPlaneTransitGatewayID    string
 	ControlPlaneVPCCidr             string
 	CustomObject                    v1alpha1.AWSConfig
 	EncrypterBackend                string
+	// ExistingVPCPrivateRouteTableIDs are the IDs of the route tables of the
+	// private subnets of the existing VPC the tenant cluster is deployed into.
+	ExistingVPCPrivateRouteTableIDs []string
 	GuestAccountID                  string
 	InstallationName                string
 	PublicRouteTables               string
@@ -46,6 +50,9 @@ type Config struct {
 	StackState                      StackState
 	TenantClusterAccountID          string
 	TenantClusterKMSKeyARN          string
+	// TenantClusterVolumeKMSKeyARN is the ARN of the KMS key the worker volumes
+	// are encrypted with. Empty means the AWS managed EBS key is used.
+	TenantClusterVolumeKMSKeyARN string
 }
 
 type Adapter struct {
@@ -57,10 +64,11 @@ func NewGuest(cfg Config) (Adapter, error) {
 
 	hydraters := []Hydrater{
 		a.Guest.AutoScalingGroup.Adapt,
+		a.Guest.ExistingVPC.Adapt,
 		a.Guest.IAMPolicies.Adapt,
 		a.Guest.InternetGateway.Adapt,
 		a.Guest.Instance.Adapt,
-		a.Guest.LaunchConfiguration.Adapt,
+		a.Guest.LaunchTemplate.Adapt,
 		a.Guest.LifecycleHooks.Adapt,
 		a.Guest.LoadBalancers.Adapt,
 		a.Guest.NATGateway.Adapt,
@@ -82,18 +90,19 @@ func NewGuest(cfg Config) (Adapter, error) {
 }
 
 type GuestAdapter struct {
-	AutoScalingGroup    GuestAutoScalingGroupAdapter
-	IAMPolicies         GuestIAMPoliciesAdapter
-	InternetGateway     GuestInternetGatewayAdapter
-	Instance            GuestInstanceAdapter
-	LaunchConfiguration GuestLaunchConfigAdapter
-	LifecycleHooks      GuestLifecycleHooksAdapter
-	LoadBalancers       GuestLoadBalancersAdapter
-	NATGateway          GuestNATGatewayAdapter
-	Outputs             GuestOutputsAdapter
-	RecordSets          GuestRecordSetsAdapter
-	RouteTables         GuestRouteTablesAdapter
-	SecurityGroups      GuestSecurityGroupsAdapter
-	Subnets             GuestSubnetsAdapter
-	VPC                 GuestVPCAdapter
+	AutoScalingGroup GuestAutoScalingGroupAdapter
+	ExistingVPC      GuestExistingVPCAdapter
+	IAMPolicies      GuestIAMPoliciesAdapter
+	InternetGateway  GuestInternetGatewayAdapter
+	Instance         GuestInstanceAdapter
+	LaunchTemplate   GuestLaunchTemplateAdapter
+	LifecycleHooks   GuestLifecycleHooksAdapter
+	LoadBalancers    GuestLoadBalancersAdapter
+	NATGateway       GuestNATGatewayAdapter
+	Outputs          GuestOutputsAdapter
+	RecordSets       GuestRecordSetsAdapter
+	RouteTables      GuestRouteTablesAdapter
+	SecurityGroups   GuestSecurityGroupsAdapter
+	Subnets          GuestSubnetsAdapter
+	VPC              GuestVPCAdapter
 }
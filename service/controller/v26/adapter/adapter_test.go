@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
 var (
@@ -144,8 +146,18 @@ func TestAdapterGuestMain(t *testing.T) {
 				CustomObject:          tc.customObject,
 				InstallationName:      "myinstallation",
 				StackState: StackState{
-					MasterImageID: "master-image-id",
-					WorkerImageID: "worker-image-id",
+					MasterDockerVolume: key.MasterDockerVolume(tc.customObject),
+					MasterEtcdVolume:   key.MasterEtcdVolume(tc.customObject),
+					MasterImageID:      "master-image-id",
+					MasterLogVolume:    key.MasterLogVolume(tc.customObject),
+					WorkerImageID:      "worker-image-id",
+					WorkerNodePools: []StackStateNodePool{
+						{
+							Max: key.ScalingMax(tc.customObject),
+							Min: key.ScalingMin(tc.customObject),
+						},
+					},
+					WorkerVolumeType: key.WorkerVolumeType(tc.customObject),
 				},
 			}
 			a, err := NewGuest(config)
@@ -160,19 +172,19 @@ func TestAdapterGuestMain(t *testing.T) {
 				t.Fatalf("error == %#v, want matching", err)
 			}
 
-			if tc.expectedASGType != a.Guest.AutoScalingGroup.ASGType {
-				t.Fatalf("unexpected ASG type, expected %q, got %q", tc.expectedASGType, a.Guest.AutoScalingGroup.ASGType)
+			if tc.expectedASGType != a.Guest.AutoScalingGroup.NodePools[0].ASGType {
+				t.Fatalf("unexpected ASG type, expected %q, got %q", tc.expectedASGType, a.Guest.AutoScalingGroup.NodePools[0].ASGType)
 			}
-			if tc.expectedASGType != a.Guest.LaunchConfiguration.ASGType {
-				t.Fatalf("unexpected ASG type, expected %q, got %q", tc.expectedASGType, a.Guest.LaunchConfiguration.ASGType)
+			if tc.expectedASGType != a.Guest.LaunchTemplate.NodePools[0].ASGType {
+				t.Fatalf("unexpected ASG type, expected %q, got %q", tc.expectedASGType, a.Guest.LaunchTemplate.NodePools[0].ASGType)
 			}
 
 			if tc.expectedEC2ServiceDomain != a.Guest.IAMPolicies.EC2ServiceDomain {
 				t.Fatalf("unexpected EC2 service domain, expected %q, got %q", tc.expectedEC2ServiceDomain, a.Guest.IAMPolicies.EC2ServiceDomain)
 			}
 
-			if tc.expectedWorkerImageID != a.Guest.LaunchConfiguration.WorkerImageID {
-				t.Fatalf("unexpected WorkerImageID, expected %q, got %q", tc.expectedWorkerImageID, a.Guest.LaunchConfiguration.WorkerImageID)
+			if tc.expectedWorkerImageID != a.Guest.LaunchTemplate.NodePools[0].WorkerImageID {
+				t.Fatalf("unexpected WorkerImageID, expected %q, got %q", tc.expectedWorkerImageID, a.Guest.LaunchTemplate.NodePools[0].WorkerImageID)
 			}
 		})
 	}
@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"regexp"
 	"strconv"
 
 	"github.com/giantswarm/microerror"
@@ -8,30 +9,47 @@ import (
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
+var nodePoolNameRegexp = regexp.MustCompile("^[a-z0-9]{1,20}$")
+
 type GuestAutoScalingGroupAdapter struct {
-	ASGDesiredCapacity     int
-	ASGMaxSize             int
-	ASGMinSize             int
-	ASGType                string
-	ClusterID              string
-	HealthCheckGracePeriod int
-	MaxBatchSize           string
-	MinInstancesInService  string
-	PrivateSubnets         []string
-	RollingUpdatePauseTime string
-	WorkerAZs              []string
+	NodePools []GuestAutoScalingGroupAdapterNodePool
 }
 
-func (a *GuestAutoScalingGroupAdapter) Adapt(cfg Config) error {
-	maxWorkers := key.ScalingMax(cfg.CustomObject)
-	minWorkers := key.ScalingMin(cfg.CustomObject)
-
-	if minWorkers <= 0 {
-		return microerror.Maskf(invalidConfigError, "at least 1 worker required, found %d", minWorkers)
-	}
+type GuestAutoScalingGroupAdapterNodePool struct {
+	ASGDesiredCapacity         int
+	ASGMaxSize                 int
+	ASGMinSize                 int
+	ASGType                    string
+	ClusterID                  string
+	HealthCheckGracePeriod     int
+	LaunchTemplateResourceName string
+	MaxBatchSize               string
+	MinInstancesInService      string
+	PrivateSubnets             []string
+	ResourceName               string
+	RollingUpdatePauseTime     string
+	WorkerAZs                  []string
+
+	// IngressTargetGroups are the CloudFormation resource names of the ingress
+	// NLB target groups the instances are registered with. It is only set when
+	// the tenant cluster uses NLBs.
+	IngressTargetGroups []string
+	// InstanceTypes are the instance types used as launch template overrides
+	// of the mixed instances policy.
+	InstanceTypes []string
+	// OnDemandBaseCapacity is the number of instances always launched as
+	// on-demand instances.
+	OnDemandBaseCapacity int
+	// OnDemandPercentageAboveBaseCapacity is the percentage of instances above
+	// OnDemandBaseCapacity launched as on-demand instances. The rest is
+	// launched as spot instances.
+	OnDemandPercentageAboveBaseCapacity int
+}
 
-	if maxWorkers < minWorkers {
-		return microerror.Maskf(invalidConfigError, "maximum number of workers (%d) is smaller than minimum number of workers (%d)", maxWorkers, minWorkers)
+func (a *GuestAutoScalingGroupAdapter) Adapt(cfg Config) error {
+	err := validateNodePools(cfg.StackState.WorkerNodePools)
+	if err != nil {
+		return microerror.Mask(err)
 	}
 
 	{
@@ -41,15 +59,50 @@ func (a *GuestAutoScalingGroupAdapter) Adapt(cfg Config) error {
 		}
 	}
 
+	for _, p := range cfg.StackState.WorkerNodePools {
+		n, err := newGuestAutoScalingGroupAdapterNodePool(cfg, p)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		a.NodePools = append(a.NodePools, n)
+	}
+
+	return nil
+}
+
+func newGuestAutoScalingGroupAdapterNodePool(cfg Config, p StackStateNodePool) (GuestAutoScalingGroupAdapterNodePool, error) {
+	maxWorkers := p.Max
+	minWorkers := p.Min
+
+	if minWorkers <= 0 {
+		return GuestAutoScalingGroupAdapterNodePool{}, microerror.Maskf(invalidConfigError, "at least 1 worker required, found %d", minWorkers)
+	}
+
+	if maxWorkers < minWorkers {
+		return GuestAutoScalingGroupAdapterNodePool{}, microerror.Maskf(invalidConfigError, "maximum number of workers (%d) is smaller than minimum number of workers (%d)", maxWorkers, minWorkers)
+	}
+
+	if p.OnDemandBaseCapacity < 0 {
+		return GuestAutoScalingGroupAdapterNodePool{}, microerror.Maskf(invalidConfigError, "on-demand base capacity (%d) must not be negative", p.OnDemandBaseCapacity)
+	}
+	if p.SpotPercentage < 0 || p.SpotPercentage > 100 {
+		return GuestAutoScalingGroupAdapterNodePool{}, microerror.Maskf(invalidConfigError, "spot percentage (%d) must be between 0 and 100", p.SpotPercentage)
+	}
+
+	var a GuestAutoScalingGroupAdapterNodePool
+
 	// Find out the minimum desired number of workers.
-	currentDesiredMinWorkers := minDesiredWorkers(minWorkers, maxWorkers, cfg.StackState.WorkerDesired)
+	currentDesiredMinWorkers := minDesiredWorkers(minWorkers, maxWorkers, p.Desired)
 
 	a.ASGDesiredCapacity = currentDesiredMinWorkers
 	a.ASGMaxSize = maxWorkers
 	a.ASGMinSize = minWorkers
-	a.ASGType = key.KindWorker
+	a.ASGType = key.NodePoolRole(p.Name)
 	a.ClusterID = key.ClusterID(cfg.CustomObject)
+	a.LaunchTemplateResourceName = key.NodePoolResourceName(key.WorkerLaunchTemplateRef, p.Name)
 	a.MaxBatchSize = strconv.Itoa(workerCountRatio(currentDesiredMinWorkers, asgMaxBatchSizeRatio))
+	a.ResourceName = key.NodePoolResourceName(key.WorkerASGRef, p.Name)
 
 	minInstancesInService := workerCountRatio(currentDesiredMinWorkers, asgMinInstancesRatio)
 	if minWorkers == 1 && maxWorkers == 1 {
@@ -64,11 +117,48 @@ func (a *GuestAutoScalingGroupAdapter) Adapt(cfg Config) error {
 	a.HealthCheckGracePeriod = gracePeriodSeconds
 	a.RollingUpdatePauseTime = rollingUpdatePauseTime
 
+	a.InstanceTypes = p.InstanceTypes
+	if len(a.InstanceTypes) == 0 {
+		a.InstanceTypes = []string{p.InstanceType}
+	}
+	a.OnDemandBaseCapacity = p.OnDemandBaseCapacity
+	a.OnDemandPercentageAboveBaseCapacity = 100 - p.SpotPercentage
+
+	if key.IsNetworkLoadBalancer(cfg.CustomObject) {
+		a.IngressTargetGroups = ingressTargetGroupResourceNames()
+	}
+
 	for i, az := range key.StatusAvailabilityZones(cfg.CustomObject) {
 		a.PrivateSubnets = append(a.PrivateSubnets, key.PrivateSubnetName(i))
 		a.WorkerAZs = append(a.WorkerAZs, az.Name)
 	}
 
+	return a, nil
+}
+
+// validateNodePools ensures the node pools can be rendered into the tenant
+// cluster's main stack. Either there is a single unnamed node pool, or all
+// node pools have unique names made of lower case alphanumeric characters,
+// since the names are part of CloudFormation resource names.
+func validateNodePools(nodePools []StackStateNodePool) error {
+	if len(nodePools) == 0 {
+		return microerror.Maskf(invalidConfigError, "at least one node pool required")
+	}
+	if len(nodePools) == 1 && nodePools[0].Name == "" {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, p := range nodePools {
+		if !nodePoolNameRegexp.MatchString(p.Name) {
+			return microerror.Maskf(invalidConfigError, "node pool name %#q must match %#q", p.Name, nodePoolNameRegexp.String())
+		}
+		if seen[p.Name] {
+			return microerror.Maskf(invalidConfigError, "node pool name %#q must be unique", p.Name)
+		}
+		seen[p.Name] = true
+	}
+
 	return nil
 }
 
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
 func TestAdapterAutoScalingGroupRegularFields(t *testing.T) {
@@ -141,6 +143,14 @@ func TestAdapterAutoScalingGroupRegularFields(t *testing.T) {
 		t.Run(tc.description, func(t *testing.T) {
 			cfg := Config{
 				CustomObject: tc.customObject,
+				StackState: StackState{
+					WorkerNodePools: []StackStateNodePool{
+						{
+							Max: key.ScalingMax(tc.customObject),
+							Min: key.ScalingMin(tc.customObject),
+						},
+					},
+				},
 			}
 			err := a.Guest.AutoScalingGroup.Adapt(cfg)
 			if tc.expectedError && err == nil {
@@ -152,32 +162,32 @@ func TestAdapterAutoScalingGroupRegularFields(t *testing.T) {
 			}
 
 			if !tc.expectedError {
-				if a.Guest.AutoScalingGroup.ASGMaxSize != tc.expectedASGMaxSize {
-					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.ASGMaxSize, tc.expectedASGMaxSize)
+				if a.Guest.AutoScalingGroup.NodePools[0].ASGMaxSize != tc.expectedASGMaxSize {
+					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.NodePools[0].ASGMaxSize, tc.expectedASGMaxSize)
 				}
 
-				if a.Guest.AutoScalingGroup.ASGMinSize != tc.expectedASGMinSize {
-					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.ASGMinSize, tc.expectedASGMinSize)
+				if a.Guest.AutoScalingGroup.NodePools[0].ASGMinSize != tc.expectedASGMinSize {
+					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.NodePools[0].ASGMinSize, tc.expectedASGMinSize)
 				}
 
-				if a.Guest.AutoScalingGroup.HealthCheckGracePeriod != tc.expectedHealthCheckGracePeriod {
-					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.HealthCheckGracePeriod, tc.expectedHealthCheckGracePeriod)
+				if a.Guest.AutoScalingGroup.NodePools[0].HealthCheckGracePeriod != tc.expectedHealthCheckGracePeriod {
+					t.Errorf("unexpected output, got %d, want %d", a.Guest.AutoScalingGroup.NodePools[0].HealthCheckGracePeriod, tc.expectedHealthCheckGracePeriod)
 				}
 
-				if a.Guest.AutoScalingGroup.MaxBatchSize != tc.expectedMaxBatchSize {
-					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.MaxBatchSize, tc.expectedMaxBatchSize)
+				if a.Guest.AutoScalingGroup.NodePools[0].MaxBatchSize != tc.expectedMaxBatchSize {
+					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.NodePools[0].MaxBatchSize, tc.expectedMaxBatchSize)
 				}
 
-				if a.Guest.AutoScalingGroup.MinInstancesInService != tc.expectedMinInstancesInService {
-					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.MinInstancesInService, tc.expectedMinInstancesInService)
+				if a.Guest.AutoScalingGroup.NodePools[0].MinInstancesInService != tc.expectedMinInstancesInService {
+					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.NodePools[0].MinInstancesInService, tc.expectedMinInstancesInService)
 				}
 
-				if a.Guest.AutoScalingGroup.RollingUpdatePauseTime != tc.expectedRollingUpdatePauseTime {
-					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.RollingUpdatePauseTime, tc.expectedRollingUpdatePauseTime)
+				if a.Guest.AutoScalingGroup.NodePools[0].RollingUpdatePauseTime != tc.expectedRollingUpdatePauseTime {
+					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.NodePools[0].RollingUpdatePauseTime, tc.expectedRollingUpdatePauseTime)
 				}
 
-				if !reflect.DeepEqual(a.Guest.AutoScalingGroup.WorkerAZs, tc.expectedAZs) {
-					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.WorkerAZs, tc.expectedAZs)
+				if !reflect.DeepEqual(a.Guest.AutoScalingGroup.NodePools[0].WorkerAZs, tc.expectedAZs) {
+					t.Errorf("unexpected output, got %q, want %q", a.Guest.AutoScalingGroup.NodePools[0].WorkerAZs, tc.expectedAZs)
 				}
 
 			}
@@ -185,6 +195,205 @@ func TestAdapterAutoScalingGroupRegularFields(t *testing.T) {
 	}
 }
 
+func TestAdapterAutoScalingGroupMixedInstancesPolicy(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description                                 string
+		nodePool                                    StackStateNodePool
+		expectedError                               bool
+		expectedInstanceTypes                       []string
+		expectedOnDemandBaseCapacity                int
+		expectedOnDemandPercentageAboveBaseCapacity int
+	}{
+		{
+			description: "case 0: no instance distribution results in on-demand instances of the worker instance type",
+			nodePool: StackStateNodePool{
+				InstanceType: "m5.xlarge",
+			},
+			expectedInstanceTypes:                       []string{"m5.xlarge"},
+			expectedOnDemandBaseCapacity:                0,
+			expectedOnDemandPercentageAboveBaseCapacity: 100,
+		},
+		{
+			description: "case 1: spot instances above the on-demand base capacity",
+			nodePool: StackStateNodePool{
+				InstanceType:         "m5.xlarge",
+				InstanceTypes:        []string{"m5.xlarge", "m4.xlarge", "m5a.xlarge"},
+				OnDemandBaseCapacity: 2,
+				SpotPercentage:       75,
+			},
+			expectedInstanceTypes:                       []string{"m5.xlarge", "m4.xlarge", "m5a.xlarge"},
+			expectedOnDemandBaseCapacity:                2,
+			expectedOnDemandPercentageAboveBaseCapacity: 25,
+		},
+		{
+			description: "case 2: invalid spot percentage returns an error",
+			nodePool: StackStateNodePool{
+				InstanceType:   "m5.xlarge",
+				SpotPercentage: 101,
+			},
+			expectedError: true,
+		},
+		{
+			description: "case 3: negative on-demand base capacity returns an error",
+			nodePool: StackStateNodePool{
+				InstanceType:         "m5.xlarge",
+				OnDemandBaseCapacity: -1,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.nodePool.Max = 3
+			tc.nodePool.Min = 3
+
+			cfg := Config{
+				CustomObject: v1alpha1.AWSConfig{
+					Spec: v1alpha1.AWSConfigSpec{
+						Cluster: defaultCluster,
+					},
+					Status: v1alpha1.AWSConfigStatus{
+						AWS: v1alpha1.AWSConfigStatusAWS{
+							AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+								{
+									Name: "myaz",
+								},
+							},
+						},
+					},
+				},
+				StackState: StackState{
+					WorkerNodePools: []StackStateNodePool{
+						tc.nodePool,
+					},
+				},
+			}
+
+			a := Adapter{}
+			err := a.Guest.AutoScalingGroup.Adapt(cfg)
+			if tc.expectedError && err == nil {
+				t.Fatal("expected error didn't happen")
+			}
+			if !tc.expectedError && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tc.expectedError {
+				return
+			}
+
+			if !reflect.DeepEqual(a.Guest.AutoScalingGroup.NodePools[0].InstanceTypes, tc.expectedInstanceTypes) {
+				t.Fatalf("unexpected InstanceTypes, got %v, want %v", a.Guest.AutoScalingGroup.NodePools[0].InstanceTypes, tc.expectedInstanceTypes)
+			}
+			if a.Guest.AutoScalingGroup.NodePools[0].OnDemandBaseCapacity != tc.expectedOnDemandBaseCapacity {
+				t.Fatalf("unexpected OnDemandBaseCapacity, got %d, want %d", a.Guest.AutoScalingGroup.NodePools[0].OnDemandBaseCapacity, tc.expectedOnDemandBaseCapacity)
+			}
+			if a.Guest.AutoScalingGroup.NodePools[0].OnDemandPercentageAboveBaseCapacity != tc.expectedOnDemandPercentageAboveBaseCapacity {
+				t.Fatalf("unexpected OnDemandPercentageAboveBaseCapacity, got %d, want %d", a.Guest.AutoScalingGroup.NodePools[0].OnDemandPercentageAboveBaseCapacity, tc.expectedOnDemandPercentageAboveBaseCapacity)
+			}
+		})
+	}
+}
+
+func TestAdapterAutoScalingGroupNodePools(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description           string
+		nodePools             []StackStateNodePool
+		expectedError         bool
+		expectedResourceNames []string
+	}{
+		{
+			description: "case 0: the unnamed node pool keeps the plain resource names",
+			nodePools: []StackStateNodePool{
+				{Max: 3, Min: 3},
+			},
+			expectedResourceNames: []string{"workerAutoScalingGroup"},
+		},
+		{
+			description: "case 1: named node pools get suffixed resource names",
+			nodePools: []StackStateNodePool{
+				{Name: "general", Max: 3, Min: 3},
+				{Name: "highmem", Max: 2, Min: 1},
+			},
+			expectedResourceNames: []string{"workerAutoScalingGroupGeneral", "workerAutoScalingGroupHighmem"},
+		},
+		{
+			description: "case 2: an unnamed node pool next to named node pools returns an error",
+			nodePools: []StackStateNodePool{
+				{Max: 3, Min: 3},
+				{Name: "highmem", Max: 2, Min: 1},
+			},
+			expectedError: true,
+		},
+		{
+			description: "case 3: duplicated node pool names return an error",
+			nodePools: []StackStateNodePool{
+				{Name: "general", Max: 3, Min: 3},
+				{Name: "general", Max: 2, Min: 1},
+			},
+			expectedError: true,
+		},
+		{
+			description: "case 4: invalid node pool names return an error",
+			nodePools: []StackStateNodePool{
+				{Name: "high-mem", Max: 3, Min: 3},
+			},
+			expectedError: true,
+		},
+		{
+			description:   "case 5: no node pools return an error",
+			nodePools:     nil,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := Config{
+				CustomObject: v1alpha1.AWSConfig{
+					Spec: v1alpha1.AWSConfigSpec{
+						Cluster: defaultCluster,
+					},
+					Status: v1alpha1.AWSConfigStatus{
+						AWS: v1alpha1.AWSConfigStatusAWS{
+							AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+								{
+									Name: "myaz",
+								},
+							},
+						},
+					},
+				},
+				StackState: StackState{
+					WorkerNodePools: tc.nodePools,
+				},
+			}
+
+			a := Adapter{}
+			err := a.Guest.AutoScalingGroup.Adapt(cfg)
+			if tc.expectedError && err == nil {
+				t.Fatal("expected error didn't happen")
+			}
+			if !tc.expectedError && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tc.expectedError {
+				return
+			}
+
+			var resourceNames []string
+			for _, p := range a.Guest.AutoScalingGroup.NodePools {
+				resourceNames = append(resourceNames, p.ResourceName)
+			}
+			if !reflect.DeepEqual(resourceNames, tc.expectedResourceNames) {
+				t.Fatalf("unexpected resource names, got %v, want %v", resourceNames, tc.expectedResourceNames)
+			}
+		})
+	}
+}
+
 func TestWorkerCountRatioMaxBatchSize(t *testing.T) {
 	t.Parallel()
 	tcs := []struct {
@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+type ExistingSubnet struct {
+	ID   string
+	Name string
+}
+
+// GuestExistingVPCAdapter renders the existing VPC and subnets a tenant
+// cluster is deployed into as template parameters. The parameters use the
+// logical IDs of the VPC and subnet resources created otherwise, so that
+// references to them keep working. ID is empty when the tenant cluster's VPC
+// is managed by the operator.
+type GuestExistingVPCAdapter struct {
+	ID      string
+	Subnets []ExistingSubnet
+}
+
+func (a *GuestExistingVPCAdapter) Adapt(cfg Config) error {
+	if !key.IsExistingVPC(cfg.CustomObject) {
+		return nil
+	}
+
+	a.ID = key.ExistingVPCID(cfg.CustomObject)
+
+	for i, s := range key.ExistingVPCSubnets(cfg.CustomObject) {
+		a.Subnets = append(a.Subnets, ExistingSubnet{
+			ID:   s.PublicSubnetID,
+			Name: key.PublicSubnetName(i),
+		})
+		a.Subnets = append(a.Subnets, ExistingSubnet{
+			ID:   s.PrivateSubnetID,
+			Name: key.PrivateSubnetName(i),
+		})
+	}
+
+	return nil
+}
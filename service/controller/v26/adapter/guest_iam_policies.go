@@ -7,6 +7,7 @@ import (
 type GuestIAMPoliciesAdapter struct {
 	ClusterID         string
 	EC2ServiceDomain  string
+	EtcdBackupPrefix  string
 	KMSKeyARN         string
 	MasterRoleName    string
 	MasterPolicyName  string
@@ -23,6 +24,7 @@ func (i *GuestIAMPoliciesAdapter) Adapt(cfg Config) error {
 
 	i.ClusterID = clusterID
 	i.EC2ServiceDomain = key.EC2ServiceDomain(cfg.CustomObject)
+	i.EtcdBackupPrefix = key.EtcdBackupPrefix(cfg.CustomObject)
 	i.MasterPolicyName = key.PolicyName(cfg.CustomObject, key.KindMaster)
 	i.MasterProfileName = key.InstanceProfileName(cfg.CustomObject, key.KindMaster)
 	i.MasterRoleName = key.RoleName(cfg.CustomObject, key.KindMaster)
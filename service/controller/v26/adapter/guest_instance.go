@@ -2,8 +2,9 @@ package adapter
 
 import (
 	"encoding/base64"
-	"sort"
+	"fmt"
 
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
@@ -13,7 +14,11 @@ import (
 type GuestInstanceAdapter struct {
 	Cluster GuestInstanceAdapterCluster
 	Image   GuestInstanceAdapterImage
-	Master  GuestInstanceAdapterMaster
+	// Masters holds the master instances of the tenant cluster. Single master
+	// clusters have exactly one item here. Multi master clusters have one item
+	// per configured master, distributed across the tenant cluster's
+	// availability zones.
+	Masters []GuestInstanceAdapterMaster
 }
 
 type GuestInstanceAdapterCluster struct {
@@ -36,19 +41,34 @@ type GuestInstanceAdapterMaster struct {
 }
 
 type GuestInstanceAdapterMasterDockerVolume struct {
+	IOPS         int
 	Name         string
 	ResourceName string
+	SizeGB       int
+	Throughput   int
+	Type         string
 }
 
 type GuestInstanceAdapterMasterEtcdVolume struct {
-	Name string
+	IOPS         int
+	Name         string
+	ResourceName string
+	SizeGB       int
+	Throughput   int
+	Type         string
 }
 
 type GuestInstanceAdapterMasterLogVolume struct {
-	Name string
+	IOPS         int
+	Name         string
+	ResourceName string
+	SizeGB       int
+	Throughput   int
+	Type         string
 }
 
 type GuestInstanceAdapterMasterInstance struct {
+	Name         string
 	ResourceName string
 	Type         string
 	Monitoring   bool
@@ -63,45 +83,154 @@ func (i *GuestInstanceAdapter) Adapt(config Config) error {
 		i.Image.ID = config.StackState.MasterImageID
 	}
 
+	volumes := []v1alpha1.AWSConfigSpecAWSVolume{
+		config.StackState.MasterDockerVolume,
+		config.StackState.MasterEtcdVolume,
+		config.StackState.MasterLogVolume,
+	}
+	for _, v := range volumes {
+		err := validateMasterVolume(v)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	{
+		// The master is placed into the first availability zone of the CR
+		// status, which does not change when availability zones are added.
 		zones := key.StatusAvailabilityZones(config.CustomObject)
-		sort.Slice(zones, func(i, j int) bool {
-			return zones[i].Name < zones[j].Name
-		})
 
 		if len(zones) < 1 {
 			return microerror.Maskf(notFoundError, "CustomObject has no availability zones")
 		}
 
-		i.Master.AZ = zones[0].Name
-		i.Master.PrivateSubnet = key.PrivateSubnetName(0)
+		// Masters are distributed across the tenant cluster's availability zones
+		// in a round robin fashion, unless their availability zones are pinned
+		// in the stack state. The subnet index is the index of the availability
+		// zone, since there is one private subnet per availability zone.
+		for idx := 0; idx < key.MasterReplicas(config.CustomObject); idx++ {
+			azIdx := idx % len(zones)
+			if idx < len(config.StackState.MasterAvailabilityZones) {
+				azIdx = availabilityZoneIndex(zones, config.StackState.MasterAvailabilityZones[idx])
+				if azIdx < 0 {
+					return microerror.Maskf(notFoundError, "availability zone %#q of master %d", config.StackState.MasterAvailabilityZones[idx], idx)
+				}
+			}
 
-		c := SmallCloudconfigConfig{
-			InstanceRole: key.KindMaster,
-			S3URL:        key.SmallCloudConfigS3URL(config.CustomObject, config.TenantClusterAccountID, key.KindMaster),
-		}
-		rendered, err := templates.Render(key.CloudConfigSmallTemplates(), c)
-		if err != nil {
-			return microerror.Mask(err)
-		}
-		i.Master.CloudConfig = base64.StdEncoding.EncodeToString([]byte(rendered))
+			m := GuestInstanceAdapterMaster{}
+
+			m.AZ = zones[azIdx].Name
+			m.PrivateSubnet = key.PrivateSubnetName(azIdx)
+
+			cloudConfig, err := masterSmallCloudConfig(config, idx)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+			m.CloudConfig = cloudConfig
+
+			m.EncrypterBackend = config.EncrypterBackend
+
+			m.DockerVolume.Name = key.DockerVolumeName(config.CustomObject, idx)
+
+			m.DockerVolume.ResourceName = key.MasterResourceName(config.StackState.DockerVolumeResourceName, idx)
+
+			m.DockerVolume.IOPS = config.StackState.MasterDockerVolume.IOPS
+			m.DockerVolume.SizeGB = config.StackState.MasterDockerVolume.SizeGB
+			m.DockerVolume.Throughput = config.StackState.MasterDockerVolume.Throughput
+			m.DockerVolume.Type = config.StackState.MasterDockerVolume.Type
+
+			m.EtcdVolume.Name = key.EtcdVolumeName(config.CustomObject, idx)
 
-		i.Master.EncrypterBackend = config.EncrypterBackend
+			m.EtcdVolume.ResourceName = key.EtcdVolumeResourceName(idx)
 
-		i.Master.DockerVolume.Name = key.DockerVolumeName(config.CustomObject)
+			m.EtcdVolume.IOPS = config.StackState.MasterEtcdVolume.IOPS
+			m.EtcdVolume.SizeGB = config.StackState.MasterEtcdVolume.SizeGB
+			m.EtcdVolume.Throughput = config.StackState.MasterEtcdVolume.Throughput
+			m.EtcdVolume.Type = config.StackState.MasterEtcdVolume.Type
 
-		i.Master.DockerVolume.ResourceName = config.StackState.DockerVolumeResourceName
+			m.LogVolume.Name = key.LogVolumeName(config.CustomObject, idx)
 
-		i.Master.EtcdVolume.Name = key.EtcdVolumeName(config.CustomObject)
+			m.LogVolume.ResourceName = key.LogVolumeResourceName(idx)
 
-		i.Master.LogVolume.Name = key.LogVolumeName(config.CustomObject)
+			m.LogVolume.IOPS = config.StackState.MasterLogVolume.IOPS
+			m.LogVolume.SizeGB = config.StackState.MasterLogVolume.SizeGB
+			m.LogVolume.Throughput = config.StackState.MasterLogVolume.Throughput
+			m.LogVolume.Type = config.StackState.MasterLogVolume.Type
 
-		i.Master.Instance.ResourceName = config.StackState.MasterInstanceResourceName
+			m.Instance.Name = key.MasterInstanceName(config.CustomObject, idx)
 
-		i.Master.Instance.Type = config.StackState.MasterInstanceType
+			m.Instance.ResourceName = key.MasterResourceName(config.StackState.MasterInstanceResourceName, idx)
 
-		i.Master.Instance.Monitoring = config.StackState.MasterInstanceMonitoring
+			m.Instance.Type = config.StackState.MasterInstanceType
+
+			m.Instance.Monitoring = config.StackState.MasterInstanceMonitoring
+
+			i.Masters = append(i.Masters, m)
+		}
+	}
+
+	return nil
+}
+
+// masterSmallCloudConfig renders the small cloud config of the master with the
+// given index. Masters of multi master tenant clusters additionally get the
+// environment file defining the name and peer URL of their etcd member, since
+// all masters share the same master cloud config.
+func masterSmallCloudConfig(config Config, idx int) (string, error) {
+	c := SmallCloudconfigConfig{
+		InstanceRole: key.KindMaster,
+		S3URL:        key.SmallCloudConfigS3URL(config.CustomObject, config.TenantClusterAccountID, key.KindMaster),
+	}
+	if key.MasterReplicas(config.CustomObject) > 1 {
+		env := fmt.Sprintf("ETCD_MEMBER_NAME=%s\nETCD_MEMBER_PEER_URL=%s\n", key.EtcdMemberName(idx), key.EtcdMemberPeerURL(config.CustomObject, idx))
+
+		c.EtcdMemberEnvironment = base64.StdEncoding.EncodeToString([]byte(env))
+		c.EtcdMemberEnvironmentFile = key.EtcdMemberEnvironmentFile
+	}
+
+	rendered, err := templates.Render(key.CloudConfigSmallTemplates(), c)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(rendered)), nil
+}
+
+// validateMasterVolume checks that the provisioned IOPS and throughput of the
+// given master volume are supported by its volume type. io1 volumes require
+// provisioned IOPS, gp3 volumes support provisioned IOPS and throughput and gp2
+// volumes support neither.
+func validateMasterVolume(volume v1alpha1.AWSConfigSpecAWSVolume) error {
+	switch volume.Type {
+	case key.VolumeTypeGP2:
+		if volume.IOPS != 0 || volume.Throughput != 0 {
+			return microerror.Maskf(invalidConfigError, "EBS volume type %#q does not support provisioned IOPS or throughput", volume.Type)
+		}
+	case key.VolumeTypeGP3:
+		// gp3 volumes fall back to their baseline performance in case no IOPS
+		// and throughput are provisioned.
+	case key.VolumeTypeIO1:
+		if volume.IOPS <= 0 {
+			return microerror.Maskf(invalidConfigError, "EBS volume type %#q requires provisioned IOPS", volume.Type)
+		}
+		if volume.Throughput != 0 {
+			return microerror.Maskf(invalidConfigError, "EBS volume type %#q does not support provisioned throughput", volume.Type)
+		}
+	default:
+		return microerror.Maskf(invalidConfigError, "EBS volume type %#q is not supported", volume.Type)
 	}
 
 	return nil
 }
+
+// availabilityZoneIndex returns the index of the given availability zone in
+// the given availability zones of the CR status, or -1 if it is not found.
+func availabilityZoneIndex(zones []v1alpha1.AWSConfigStatusAWSAvailabilityZone, name string) int {
+	for i, az := range zones {
+		if az.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -46,7 +46,10 @@ func Test_Adapter_Instance_RegularFields(t *testing.T) {
 					},
 				},
 				StackState: StackState{
+					MasterDockerVolume: key.MasterDockerVolume(v1alpha1.AWSConfig{}),
+					MasterEtcdVolume:   key.MasterEtcdVolume(v1alpha1.AWSConfig{}),
 					MasterInstanceType: "m3.large",
+					MasterLogVolume:    key.MasterLogVolume(v1alpha1.AWSConfig{}),
 				},
 				EncrypterBackend: "my-encrypter-backend",
 			},
@@ -65,25 +68,242 @@ func Test_Adapter_Instance_RegularFields(t *testing.T) {
 				t.Fatal("expected", nil, "got", err)
 			}
 
-			if a.Master.AZ != tc.ExpectedAZ {
-				t.Fatalf("unexpected a.Master.AZ, got %q, want %q", a.Master.AZ, tc.ExpectedAZ)
+			if len(a.Masters) != 1 {
+				t.Fatalf("expected %d masters, got %d", 1, len(a.Masters))
 			}
 
-			if a.Master.EtcdVolume.Name != tc.ExpectedEtcdVolumeName {
-				t.Fatalf("unexpected a.Master.EtcdVolume.Name, got %q, want %q", a.Master.EtcdVolume.Name, tc.ExpectedEtcdVolumeName)
+			if a.Masters[0].AZ != tc.ExpectedAZ {
+				t.Fatalf("unexpected a.Masters[0].AZ, got %q, want %q", a.Masters[0].AZ, tc.ExpectedAZ)
 			}
 
-			if a.Master.Instance.Type != tc.ExpectedInstanceType {
-				t.Fatalf("unexpected a.Master.Instance.Type, got %q, want %q", a.Master.Instance.Type, tc.ExpectedInstanceType)
+			if a.Masters[0].EtcdVolume.Name != tc.ExpectedEtcdVolumeName {
+				t.Fatalf("unexpected a.Masters[0].EtcdVolume.Name, got %q, want %q", a.Masters[0].EtcdVolume.Name, tc.ExpectedEtcdVolumeName)
 			}
 
-			if a.Master.EncrypterBackend != tc.ExpectedEncrypterBackend {
-				t.Fatalf("unexpected a.Master.Instance.Type, got %q, want %q", a.Master.EncrypterBackend, tc.ExpectedEncrypterBackend)
+			if a.Masters[0].Instance.Type != tc.ExpectedInstanceType {
+				t.Fatalf("unexpected a.Masters[0].Instance.Type, got %q, want %q", a.Masters[0].Instance.Type, tc.ExpectedInstanceType)
+			}
+
+			if a.Masters[0].EncrypterBackend != tc.ExpectedEncrypterBackend {
+				t.Fatalf("unexpected a.Masters[0].Instance.Type, got %q, want %q", a.Masters[0].EncrypterBackend, tc.ExpectedEncrypterBackend)
 			}
 		})
 	}
 }
 
+func Test_Adapter_Instance_MultiMaster(t *testing.T) {
+	t.Parallel()
+
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				HostedZones: v1alpha1.AWSConfigSpecAWSHostedZones{
+					API: v1alpha1.AWSConfigSpecAWSHostedZonesZone{
+						Name: "installation.eu-west-1.aws.gigantic.io",
+					},
+				},
+				Masters: []v1alpha1.AWSConfigSpecAWSNode{
+					{InstanceType: "m3.large"},
+					{InstanceType: "m3.large"},
+					{InstanceType: "m3.large"},
+				},
+				Region: "eu-west-1",
+			},
+		},
+		Status: v1alpha1.AWSConfigStatus{
+			AWS: v1alpha1.AWSConfigStatusAWS{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					{Name: "eu-west-1a"},
+					{Name: "eu-west-1b"},
+				},
+			},
+		},
+	}
+	cfg := Config{
+		CustomObject: customObject,
+		StackState: StackState{
+			DockerVolumeResourceName:   "DockerVolumeTESTCLUSTERABCDE",
+			MasterDockerVolume:         key.MasterDockerVolume(customObject),
+			MasterEtcdVolume:           key.MasterEtcdVolume(customObject),
+			MasterInstanceResourceName: "MasterInstanceTESTCLUSTERABCDE",
+			MasterInstanceType:         "m3.large",
+			MasterLogVolume:            key.MasterLogVolume(customObject),
+		},
+	}
+
+	a := &GuestInstanceAdapter{}
+	err := a.Adapt(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []struct {
+		AZ                       string
+		PrivateSubnet            string
+		InstanceName             string
+		InstanceResourceName     string
+		DockerVolumeResourceName string
+		EtcdVolumeName           string
+		EtcdVolumeResourceName   string
+		EtcdMemberEnvironment    string
+	}{
+		{
+			AZ:                       "eu-west-1a",
+			PrivateSubnet:            "PrivateSubnet",
+			InstanceName:             "test-cluster-master",
+			InstanceResourceName:     "MasterInstanceTESTCLUSTERABCDE",
+			DockerVolumeResourceName: "DockerVolumeTESTCLUSTERABCDE",
+			EtcdVolumeName:           "test-cluster-etcd",
+			EtcdVolumeResourceName:   "EtcdVolume",
+			EtcdMemberEnvironment:    "ETCD_MEMBER_NAME=etcd0\nETCD_MEMBER_PEER_URL=https://etcd0.test-cluster.k8s.installation.eu-west-1.aws.gigantic.io:2380\n",
+		},
+		{
+			AZ:                       "eu-west-1b",
+			PrivateSubnet:            "PrivateSubnet01",
+			InstanceName:             "test-cluster-master-01",
+			InstanceResourceName:     "MasterInstanceTESTCLUSTERABCDE01",
+			DockerVolumeResourceName: "DockerVolumeTESTCLUSTERABCDE01",
+			EtcdVolumeName:           "test-cluster-etcd-01",
+			EtcdVolumeResourceName:   "EtcdVolume01",
+			EtcdMemberEnvironment:    "ETCD_MEMBER_NAME=etcd1\nETCD_MEMBER_PEER_URL=https://etcd1.test-cluster.k8s.installation.eu-west-1.aws.gigantic.io:2380\n",
+		},
+		{
+			AZ:                       "eu-west-1a",
+			PrivateSubnet:            "PrivateSubnet",
+			InstanceName:             "test-cluster-master-02",
+			InstanceResourceName:     "MasterInstanceTESTCLUSTERABCDE02",
+			DockerVolumeResourceName: "DockerVolumeTESTCLUSTERABCDE02",
+			EtcdVolumeName:           "test-cluster-etcd-02",
+			EtcdVolumeResourceName:   "EtcdVolume02",
+			EtcdMemberEnvironment:    "ETCD_MEMBER_NAME=etcd2\nETCD_MEMBER_PEER_URL=https://etcd2.test-cluster.k8s.installation.eu-west-1.aws.gigantic.io:2380\n",
+		},
+	}
+
+	if len(a.Masters) != len(expected) {
+		t.Fatalf("expected %d masters, got %d", len(expected), len(a.Masters))
+	}
+
+	for i, e := range expected {
+		m := a.Masters[i]
+
+		if m.AZ != e.AZ {
+			t.Fatalf("master %d: expected AZ %q, got %q", i, e.AZ, m.AZ)
+		}
+		if m.PrivateSubnet != e.PrivateSubnet {
+			t.Fatalf("master %d: expected private subnet %q, got %q", i, e.PrivateSubnet, m.PrivateSubnet)
+		}
+		if m.Instance.Name != e.InstanceName {
+			t.Fatalf("master %d: expected instance name %q, got %q", i, e.InstanceName, m.Instance.Name)
+		}
+		if m.Instance.ResourceName != e.InstanceResourceName {
+			t.Fatalf("master %d: expected instance resource name %q, got %q", i, e.InstanceResourceName, m.Instance.ResourceName)
+		}
+		if m.DockerVolume.ResourceName != e.DockerVolumeResourceName {
+			t.Fatalf("master %d: expected docker volume resource name %q, got %q", i, e.DockerVolumeResourceName, m.DockerVolume.ResourceName)
+		}
+		if m.EtcdVolume.Name != e.EtcdVolumeName {
+			t.Fatalf("master %d: expected etcd volume name %q, got %q", i, e.EtcdVolumeName, m.EtcdVolume.Name)
+		}
+		if m.EtcdVolume.ResourceName != e.EtcdVolumeResourceName {
+			t.Fatalf("master %d: expected etcd volume resource name %q, got %q", i, e.EtcdVolumeResourceName, m.EtcdVolume.ResourceName)
+		}
+
+		data, err := base64.StdEncoding.DecodeString(m.CloudConfig)
+		if err != nil {
+			t.Fatalf("master %d: unexpected error decoding cloud config %v", i, err)
+		}
+		env := base64.StdEncoding.EncodeToString([]byte(e.EtcdMemberEnvironment))
+		if !strings.Contains(string(data), env) {
+			t.Fatalf("master %d: expected cloud config to contain etcd member environment %q, complete: %q", i, e.EtcdMemberEnvironment, string(data))
+		}
+	}
+}
+
+// Test_Adapter_Instance_MultiMaster_PinnedAZs ensures masters stay in their
+// availability zones when availability zones are added to the tenant cluster.
+func Test_Adapter_Instance_MultiMaster_PinnedAZs(t *testing.T) {
+	t.Parallel()
+
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Masters: []v1alpha1.AWSConfigSpecAWSNode{
+					{InstanceType: "m3.large"},
+					{InstanceType: "m3.large"},
+					{InstanceType: "m3.large"},
+				},
+				Region: "eu-west-1",
+			},
+		},
+		Status: v1alpha1.AWSConfigStatus{
+			AWS: v1alpha1.AWSConfigStatusAWS{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					{Name: "eu-west-1b"},
+					{Name: "eu-west-1c"},
+					{Name: "eu-west-1a"},
+				},
+			},
+		},
+	}
+	cfg := Config{
+		CustomObject: customObject,
+		StackState: StackState{
+			DockerVolumeResourceName:   "DockerVolumeTESTCLUSTERABCDE",
+			MasterAvailabilityZones:    []string{"eu-west-1b", "eu-west-1c", "eu-west-1b"},
+			MasterDockerVolume:         key.MasterDockerVolume(customObject),
+			MasterEtcdVolume:           key.MasterEtcdVolume(customObject),
+			MasterInstanceResourceName: "MasterInstanceTESTCLUSTERABCDE",
+			MasterInstanceType:         "m3.large",
+			MasterLogVolume:            key.MasterLogVolume(customObject),
+		},
+	}
+
+	a := &GuestInstanceAdapter{}
+	err := a.Adapt(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []struct {
+		AZ            string
+		PrivateSubnet string
+	}{
+		{
+			AZ:            "eu-west-1b",
+			PrivateSubnet: "PrivateSubnet",
+		},
+		{
+			AZ:            "eu-west-1c",
+			PrivateSubnet: "PrivateSubnet01",
+		},
+		{
+			AZ:            "eu-west-1b",
+			PrivateSubnet: "PrivateSubnet",
+		},
+	}
+
+	if len(a.Masters) != len(expected) {
+		t.Fatalf("expected %d masters, got %d", len(expected), len(a.Masters))
+	}
+
+	for i, e := range expected {
+		m := a.Masters[i]
+
+		if m.AZ != e.AZ {
+			t.Fatalf("master %d: expected AZ %q, got %q", i, e.AZ, m.AZ)
+		}
+		if m.PrivateSubnet != e.PrivateSubnet {
+			t.Fatalf("master %d: expected private subnet %q, got %q", i, e.PrivateSubnet, m.PrivateSubnet)
+		}
+	}
+}
+
 func Test_Adapter_Instance_SmallCloudConfig(t *testing.T) {
 	t.Parallel()
 
@@ -133,6 +353,9 @@ func Test_Adapter_Instance_SmallCloudConfig(t *testing.T) {
 				CustomObject: customObject,
 				StackState: StackState{
 					MasterCloudConfigVersion: "foo",
+					MasterDockerVolume:       key.MasterDockerVolume(customObject),
+					MasterEtcdVolume:         key.MasterEtcdVolume(customObject),
+					MasterLogVolume:          key.MasterLogVolume(customObject),
 				},
 				TenantClusterAccountID: "000000000000",
 			}
@@ -143,9 +366,9 @@ func Test_Adapter_Instance_SmallCloudConfig(t *testing.T) {
 				t.Fatalf("unexpected error %v", err)
 			}
 
-			data, err := base64.StdEncoding.DecodeString(a.Master.CloudConfig)
+			data, err := base64.StdEncoding.DecodeString(a.Masters[0].CloudConfig)
 			if err != nil {
-				t.Fatalf("unexpected error decoding a.Master.CloudConfig %v", err)
+				t.Fatalf("unexpected error decoding a.Masters[0].CloudConfig %v", err)
 			}
 
 			if !strings.Contains(string(data), tc.ExpectedLine) {
@@ -0,0 +1,151 @@
+package adapter
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+	"github.com/giantswarm/aws-operator/service/controller/v26/templates"
+)
+
+type GuestLaunchTemplateAdapter struct {
+	NodePools []GuestLaunchTemplateAdapterNodePool
+}
+
+type GuestLaunchTemplateAdapterNodePool struct {
+	ASGType                        string
+	ClusterID                      string
+	ResourceName                   string
+	WorkerAssociatePublicIPAddress bool
+	WorkerBlockDeviceMappings      []BlockDeviceMapping
+	WorkerInstanceMonitoring       bool
+	WorkerInstanceType             string
+	WorkerImageID                  string
+	WorkerSecurityGroupID          string
+	WorkerSmallCloudConfig         string
+}
+
+type BlockDeviceMapping struct {
+	DeleteOnTermination bool
+	DeviceName          string
+	Encrypted           bool
+	// KMSKeyARN is the ARN of the KMS key the volume is encrypted with. The AWS
+	// managed EBS key is used when it is empty.
+	KMSKeyARN string
+	// VolumeSize is the size of the volume in GB. The size of the AMI's
+	// snapshot is used when it is empty.
+	VolumeSize string
+	VolumeType string
+}
+
+func (a *GuestLaunchTemplateAdapter) Adapt(config Config) error {
+	for _, p := range config.StackState.WorkerNodePools {
+		n, err := newGuestLaunchTemplateAdapterNodePool(config, p)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		a.NodePools = append(a.NodePools, n)
+	}
+
+	return nil
+}
+
+func newGuestLaunchTemplateAdapterNodePool(config Config, p StackStateNodePool) (GuestLaunchTemplateAdapterNodePool, error) {
+	var l GuestLaunchTemplateAdapterNodePool
+
+	l.ASGType = key.NodePoolRole(p.Name)
+	l.ClusterID = key.ClusterID(config.CustomObject)
+	l.ResourceName = key.NodePoolResourceName(key.WorkerLaunchTemplateRef, p.Name)
+	l.WorkerInstanceType = p.InstanceType
+	l.WorkerImageID = config.StackState.WorkerImageID
+	l.WorkerAssociatePublicIPAddress = false
+
+	if config.StackState.WorkerVolumeType != key.VolumeTypeGP2 && config.StackState.WorkerVolumeType != key.VolumeTypeGP3 {
+		return GuestLaunchTemplateAdapterNodePool{}, microerror.Maskf(invalidConfigError, "EBS volume type %#q is not supported for worker volumes", config.StackState.WorkerVolumeType)
+	}
+
+	dockerVolumeSizeGB, err := volumeSizeOrDefault(p.DockerVolumeSizeGB)
+	if err != nil {
+		return GuestLaunchTemplateAdapterNodePool{}, microerror.Mask(err)
+	}
+	logVolumeSizeGB, err := volumeSizeOrDefault(config.StackState.WorkerLogVolumeSizeGB)
+	if err != nil {
+		return GuestLaunchTemplateAdapterNodePool{}, microerror.Mask(err)
+	}
+	kubeletVolumeSizeGB, err := volumeSizeOrDefault(p.KubeletVolumeSizeGB)
+	if err != nil {
+		return GuestLaunchTemplateAdapterNodePool{}, microerror.Mask(err)
+	}
+
+	// All worker volumes are encrypted, including the root volume the AMI
+	// provides.
+	l.WorkerBlockDeviceMappings = []BlockDeviceMapping{
+		{
+			DeleteOnTermination: true,
+			DeviceName:          rootEBSVolumeMountPoint,
+			Encrypted:           true,
+			KMSKeyARN:           config.TenantClusterVolumeKMSKeyARN,
+			VolumeType:          config.StackState.WorkerVolumeType,
+		},
+		{
+			DeleteOnTermination: true,
+			DeviceName:          defaultEBSVolumeMountPoint,
+			Encrypted:           true,
+			KMSKeyARN:           config.TenantClusterVolumeKMSKeyARN,
+			VolumeSize:          dockerVolumeSizeGB,
+			VolumeType:          config.StackState.WorkerVolumeType,
+		},
+		{
+			DeleteOnTermination: true,
+			DeviceName:          logEBSVolumeMountPoint,
+			Encrypted:           true,
+			KMSKeyARN:           config.TenantClusterVolumeKMSKeyARN,
+			VolumeSize:          logVolumeSizeGB,
+			VolumeType:          config.StackState.WorkerVolumeType,
+		},
+		{
+			DeleteOnTermination: true,
+			DeviceName:          kubeletEBSVolumeMountPoint,
+			Encrypted:           true,
+			KMSKeyARN:           config.TenantClusterVolumeKMSKeyARN,
+			VolumeSize:          kubeletVolumeSizeGB,
+			VolumeType:          config.StackState.WorkerVolumeType,
+		},
+	}
+	l.WorkerInstanceMonitoring = config.StackState.WorkerInstanceMonitoring
+
+	// small cloud config field.
+	c := SmallCloudconfigConfig{
+		InstanceRole: key.KindWorker,
+		S3URL:        key.SmallCloudConfigS3URL(config.CustomObject, config.TenantClusterAccountID, key.NodePoolRole(p.Name)),
+	}
+	rendered, err := templates.Render(key.CloudConfigSmallTemplates(), c)
+	if err != nil {
+		return GuestLaunchTemplateAdapterNodePool{}, microerror.Mask(err)
+	}
+	l.WorkerSmallCloudConfig = base64.StdEncoding.EncodeToString([]byte(rendered))
+
+	return l, nil
+}
+
+// volumeSizeOrDefault returns the given EBS volume size in GB, or the default
+// EBS volume size in case the given size is not set.
+func volumeSizeOrDefault(size string) (string, error) {
+	if size == "" {
+		return defaultEBSVolumeSize, nil
+	}
+
+	i, err := strconv.Atoi(size)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if i <= 0 {
+		return defaultEBSVolumeSize, nil
+	}
+
+	return size, nil
+}
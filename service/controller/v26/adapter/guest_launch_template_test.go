@@ -0,0 +1,339 @@
+package adapter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+func Test_AdapterLaunchTemplate_RegularFields(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description                      string
+		customObject                     v1alpha1.AWSConfig
+		volumeKMSKeyARN                  string
+		expectedError                    bool
+		expectedInstanceType             string
+		expectedAssociatePublicIPAddress bool
+		expectedBlockDeviceMappings      []BlockDeviceMapping
+	}{
+		{
+			description: "basic matching, all fields present",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								DockerVolumeSizeGB: 250,
+								InstanceType:       "myinstancetype",
+							},
+						},
+					},
+				},
+			},
+			expectedInstanceType:             "myinstancetype",
+			expectedAssociatePublicIPAddress: false,
+			expectedBlockDeviceMappings: []BlockDeviceMapping{
+				{
+					DeleteOnTermination: true,
+					DeviceName:          rootEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          defaultEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          "250",
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          logEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          kubeletEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          "250",
+					VolumeType:          "gp2",
+				},
+			},
+		},
+		{
+			description: "basic matching, verify that default value is used for docker volume when field is missing in CR",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "myinstancetype",
+							},
+						},
+					},
+				},
+			},
+			expectedInstanceType:             "myinstancetype",
+			expectedAssociatePublicIPAddress: false,
+			expectedBlockDeviceMappings: []BlockDeviceMapping{
+				{
+					DeleteOnTermination: true,
+					DeviceName:          rootEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          defaultEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          logEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          kubeletEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+			},
+		},
+		{
+			description: "basic matching, gp3 worker volumes",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "myinstancetype",
+							},
+						},
+						WorkerVolumeType: "gp3",
+					},
+				},
+			},
+			expectedInstanceType:             "myinstancetype",
+			expectedAssociatePublicIPAddress: false,
+			expectedBlockDeviceMappings: []BlockDeviceMapping{
+				{
+					DeleteOnTermination: true,
+					DeviceName:          rootEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeType:          "gp3",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          defaultEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp3",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          logEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp3",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          kubeletEBSVolumeMountPoint,
+					Encrypted:           true,
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp3",
+				},
+			},
+		},
+		{
+			description: "basic matching, worker volumes encrypted with KMS key",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "myinstancetype",
+							},
+						},
+					},
+				},
+			},
+			volumeKMSKeyARN:                  "arn:aws:kms:eu-central-1:000000000000:key/abc",
+			expectedInstanceType:             "myinstancetype",
+			expectedAssociatePublicIPAddress: false,
+			expectedBlockDeviceMappings: []BlockDeviceMapping{
+				{
+					DeleteOnTermination: true,
+					DeviceName:          rootEBSVolumeMountPoint,
+					Encrypted:           true,
+					KMSKeyARN:           "arn:aws:kms:eu-central-1:000000000000:key/abc",
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          defaultEBSVolumeMountPoint,
+					Encrypted:           true,
+					KMSKeyARN:           "arn:aws:kms:eu-central-1:000000000000:key/abc",
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          logEBSVolumeMountPoint,
+					Encrypted:           true,
+					KMSKeyARN:           "arn:aws:kms:eu-central-1:000000000000:key/abc",
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+				{
+					DeleteOnTermination: true,
+					DeviceName:          kubeletEBSVolumeMountPoint,
+					Encrypted:           true,
+					KMSKeyARN:           "arn:aws:kms:eu-central-1:000000000000:key/abc",
+					VolumeSize:          defaultEBSVolumeSize,
+					VolumeType:          "gp2",
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		a := Adapter{}
+
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := Config{
+				CustomObject: tc.customObject,
+				StackState: StackState{
+					WorkerNodePools: []StackStateNodePool{
+						{
+							DockerVolumeSizeGB:  key.WorkerDockerVolumeSizeGB(tc.customObject),
+							InstanceType:        key.WorkerInstanceType(tc.customObject),
+							KubeletVolumeSizeGB: key.WorkerDockerVolumeSizeGB(tc.customObject),
+						},
+					},
+					WorkerVolumeType: key.WorkerVolumeType(tc.customObject),
+				},
+				TenantClusterVolumeKMSKeyARN: tc.volumeKMSKeyARN,
+			}
+			err := a.Guest.LaunchTemplate.Adapt(cfg)
+			if tc.expectedError && err == nil {
+				t.Error("expected error didn't happen")
+			}
+
+			if !tc.expectedError && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			if a.Guest.LaunchTemplate.NodePools[0].ASGType != key.KindWorker {
+				t.Errorf("unexpected ASGType, got %q, want %q", a.Guest.LaunchTemplate.NodePools[0].ASGType, key.KindWorker)
+			}
+			if a.Guest.LaunchTemplate.NodePools[0].WorkerInstanceType != tc.expectedInstanceType {
+				t.Errorf("unexpected InstanceType, got %q, want %q", a.Guest.LaunchTemplate.NodePools[0].WorkerInstanceType, tc.expectedInstanceType)
+			}
+			if a.Guest.LaunchTemplate.NodePools[0].WorkerAssociatePublicIPAddress != tc.expectedAssociatePublicIPAddress {
+				t.Errorf("unexpected WorkerAssociatePublicIPAddress, got %t, want %t", a.Guest.LaunchTemplate.NodePools[0].WorkerAssociatePublicIPAddress, tc.expectedAssociatePublicIPAddress)
+			}
+			if !reflect.DeepEqual(a.Guest.LaunchTemplate.NodePools[0].WorkerBlockDeviceMappings, tc.expectedBlockDeviceMappings) {
+				t.Errorf("unexpected BlockDeviceMappings, got %v, want %v", a.Guest.LaunchTemplate.NodePools[0].WorkerBlockDeviceMappings, tc.expectedBlockDeviceMappings)
+			}
+		})
+	}
+}
+
+func Test_AdapterLaunchTemplate_SmallCloudConfig(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description   string
+		nodePoolIndex int
+		expectedLine  string
+	}{
+		{
+			description:   "contains S3 URL",
+			nodePoolIndex: 0,
+			expectedLine:  fmt.Sprintf("s3://000000000000-g8s-test-cluster/version/0.1.0/cloudconfig/%s/worker\"", key.CloudConfigVersion),
+		},
+		{
+			description:   "contains S3 URL of named node pool",
+			nodePoolIndex: 1,
+			expectedLine:  fmt.Sprintf("s3://000000000000-g8s-test-cluster/version/0.1.0/cloudconfig/%s/worker-highmem\"", key.CloudConfigVersion),
+		},
+	}
+
+	a := Adapter{}
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Region: "myregion",
+				Workers: []v1alpha1.AWSConfigSpecAWSNode{
+					{
+						ImageID:      "myimageid",
+						InstanceType: "myinstancetype",
+					},
+				},
+			},
+			VersionBundle: v1alpha1.AWSConfigSpecVersionBundle{
+				Version: "0.1.0",
+			},
+		},
+	}
+	cfg := Config{
+		CustomObject: customObject,
+		StackState: StackState{
+			WorkerNodePools: []StackStateNodePool{
+				{},
+				{
+					Name: "highmem",
+				},
+			},
+			WorkerVolumeType: key.WorkerVolumeType(customObject),
+		},
+		TenantClusterAccountID: "000000000000",
+	}
+	err := a.Guest.LaunchTemplate.Adapt(cfg)
+
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			data, err := base64.StdEncoding.DecodeString(a.Guest.LaunchTemplate.NodePools[tc.nodePoolIndex].WorkerSmallCloudConfig)
+			if err != nil {
+				t.Errorf("unexpected error decoding SmallCloudConfig %v", err)
+			}
+
+			if !strings.Contains(string(data), tc.expectedLine) {
+				t.Errorf("SmallCloudConfig didn't contain expected %q, complete: %q", tc.expectedLine, string(data))
+			}
+		})
+	}
+}
@@ -3,7 +3,7 @@ package adapter
 import "github.com/giantswarm/aws-operator/service/controller/v26/key"
 
 type GuestLifecycleHooksAdapter struct {
-	Worker GuestLifecycleHooksAdapterWorker
+	Workers []GuestLifecycleHooksAdapterWorker
 }
 
 type GuestLifecycleHooksAdapterWorker struct {
@@ -16,12 +16,19 @@ type GuestLifecycleHooksAdapterASG struct {
 }
 
 type GuestLifecycleHooksAdapterLifecycleHook struct {
-	Name string
+	Name         string
+	ResourceName string
 }
 
 func (a *GuestLifecycleHooksAdapter) Adapt(config Config) error {
-	a.Worker.ASG.Ref = key.WorkerASGRef
-	a.Worker.LifecycleHook.Name = key.NodeDrainerLifecycleHookName
+	for _, p := range config.StackState.WorkerNodePools {
+		w := GuestLifecycleHooksAdapterWorker{}
+		w.ASG.Ref = key.NodePoolResourceName(key.WorkerASGRef, p.Name)
+		w.LifecycleHook.Name = key.NodeDrainerLifecycleHookName
+		w.LifecycleHook.ResourceName = key.NodePoolResourceName(key.NodeDrainerLifecycleHookName+"LifecycleHook", p.Name)
+
+		a.Workers = append(a.Workers, w)
+	}
 
 	return nil
 }
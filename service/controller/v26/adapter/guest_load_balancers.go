@@ -14,6 +14,12 @@ const (
 	healthCheckInterval           = 5
 	healthCheckTimeout            = 3
 	healthCheckUnhealthyThreshold = 2
+
+	// Default values for NLB target group health checks. NLBs only support
+	// intervals of 10 or 30 seconds and require the healthy and unhealthy
+	// thresholds to be equal.
+	nlbHealthCheckInterval  = 10
+	nlbHealthCheckThreshold = 3
 )
 
 type GuestLoadBalancersAdapter struct {
@@ -35,7 +41,10 @@ type GuestLoadBalancersAdapter struct {
 	IngressElbName                   string
 	IngressElbPortsToOpen            []GuestLoadBalancersAdapterPortPair
 	IngressElbScheme                 string
-	MasterInstanceResourceName       string
+	IsNetworkLoadBalancer            bool
+	MasterInstanceResourceNames      []string
+	NLBHealthCheckInterval           int
+	NLBHealthCheckThreshold          int
 	PublicSubnets                    []string
 	PrivateSubnets                   []string
 }
@@ -63,6 +72,7 @@ func (a *GuestLoadBalancersAdapter) Adapt(cfg Config) error {
 		},
 	}
 	a.APIElbScheme = externalELBScheme
+	a.IsNetworkLoadBalancer = key.IsNetworkLoadBalancer(cfg.CustomObject)
 
 	// etcd load balancer settings.
 	etcdElbName, err := key.LoadBalancerName(key.EtcdDomain(cfg.CustomObject), cfg.CustomObject)
@@ -106,7 +116,18 @@ func (a *GuestLoadBalancersAdapter) Adapt(cfg Config) error {
 	a.ELBHealthCheckInterval = healthCheckInterval
 	a.ELBHealthCheckTimeout = healthCheckTimeout
 	a.ELBHealthCheckUnhealthyThreshold = healthCheckUnhealthyThreshold
-	a.MasterInstanceResourceName = cfg.StackState.MasterInstanceResourceName
+	a.NLBHealthCheckInterval = nlbHealthCheckInterval
+	a.NLBHealthCheckThreshold = nlbHealthCheckThreshold
+
+	if a.IsNetworkLoadBalancer {
+		setNetworkLoadBalancerResourceNames(apiLoadBalancerResourcePrefix, a.APIElbPortsToOpen)
+		setNetworkLoadBalancerResourceNames(etcdLoadBalancerResourcePrefix, a.EtcdElbPortsToOpen)
+		setNetworkLoadBalancerResourceNames(ingressLoadBalancerResourcePrefix, a.IngressElbPortsToOpen)
+	}
+
+	for i := 0; i < key.MasterReplicas(cfg.CustomObject); i++ {
+		a.MasterInstanceResourceNames = append(a.MasterInstanceResourceNames, key.MasterResourceName(cfg.StackState.MasterInstanceResourceName, i))
+	}
 
 	for i := 0; i < len(key.StatusAvailabilityZones(cfg.CustomObject)); i++ {
 		a.PublicSubnets = append(a.PublicSubnets, key.PublicSubnetName(i))
@@ -117,10 +138,37 @@ func (a *GuestLoadBalancersAdapter) Adapt(cfg Config) error {
 }
 
 type GuestLoadBalancersAdapterPortPair struct {
+	// ListenerResourceName is the CloudFormation resource name of the NLB
+	// listener for this port pair. It is only set for NLBs.
+	ListenerResourceName string
 	// PortELB is the port the ELB should listen on.
 	PortELB int
 	// PortInstance is the port on the instance the ELB forwards traffic to.
 	PortInstance int
+	// TargetGroupResourceName is the CloudFormation resource name of the NLB
+	// target group for this port pair. It is only set for NLBs.
+	TargetGroupResourceName string
+}
+
+// ingressTargetGroupResourceNames returns the CloudFormation resource names of
+// the ingress NLB target groups the worker auto scaling groups register their
+// instances with.
+func ingressTargetGroupResourceNames() []string {
+	return []string{
+		targetGroupResourceName(ingressLoadBalancerResourcePrefix, httpsPort),
+		targetGroupResourceName(ingressLoadBalancerResourcePrefix, httpPort),
+	}
+}
+
+func setNetworkLoadBalancerResourceNames(prefix string, portPairs []GuestLoadBalancersAdapterPortPair) {
+	for i, p := range portPairs {
+		portPairs[i].ListenerResourceName = fmt.Sprintf("%sListener%d", prefix, p.PortELB)
+		portPairs[i].TargetGroupResourceName = targetGroupResourceName(prefix, p.PortELB)
+	}
+}
+
+func targetGroupResourceName(prefix string, port int) string {
+	return fmt.Sprintf("%sTargetGroup%d", prefix, port)
 }
 
 func heathCheckTarget(port int) string {
@@ -198,3 +198,100 @@ func TestAdapterLoadBalancersRegularFields(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapterLoadBalancersNetworkLoadBalancer(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description                   string
+		loadBalancerType              string
+		expectedIsNetworkLoadBalancer bool
+		expectedIngressElbPortsToOpen []GuestLoadBalancersAdapterPortPair
+	}{
+		{
+			description:                   "case 0: classic load balancers by default",
+			loadBalancerType:              "",
+			expectedIsNetworkLoadBalancer: false,
+			expectedIngressElbPortsToOpen: []GuestLoadBalancersAdapterPortPair{
+				{
+					PortELB:      443,
+					PortInstance: 30011,
+				},
+				{
+					PortELB:      80,
+					PortInstance: 30010,
+				},
+			},
+		},
+		{
+			description:                   "case 1: network load balancers with listeners and target groups",
+			loadBalancerType:              "network",
+			expectedIsNetworkLoadBalancer: true,
+			expectedIngressElbPortsToOpen: []GuestLoadBalancersAdapterPortPair{
+				{
+					ListenerResourceName:    "IngressListener443",
+					PortELB:                 443,
+					PortInstance:            30011,
+					TargetGroupResourceName: "IngressTargetGroup443",
+				},
+				{
+					ListenerResourceName:    "IngressListener80",
+					PortELB:                 80,
+					PortInstance:            30010,
+					TargetGroupResourceName: "IngressTargetGroup80",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			customObject := v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+						Etcd: v1alpha1.ClusterEtcd{
+							Domain: "etcd.test-cluster.aws.giantswarm.io",
+							Port:   2379,
+						},
+						Kubernetes: v1alpha1.ClusterKubernetes{
+							API: v1alpha1.ClusterKubernetesAPI{
+								Domain:     "api.test-cluster.aws.giantswarm.io",
+								SecurePort: 443,
+							},
+							IngressController: v1alpha1.ClusterKubernetesIngressController{
+								Domain:       "ingress.test-cluster.aws.giantswarm.io",
+								InsecurePort: 30010,
+								SecurePort:   30011,
+							},
+						},
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						LoadBalancerType: tc.loadBalancerType,
+					},
+				},
+				Status: v1alpha1.AWSConfigStatus{
+					AWS: v1alpha1.AWSConfigStatusAWS{
+						AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+							{
+								Name: "eu-central-1a",
+							},
+						},
+					},
+				},
+			}
+
+			a := Adapter{}
+			err := a.Guest.LoadBalancers.Adapt(Config{CustomObject: customObject})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if a.Guest.LoadBalancers.IsNetworkLoadBalancer != tc.expectedIsNetworkLoadBalancer {
+				t.Fatalf("expected %t got %t", tc.expectedIsNetworkLoadBalancer, a.Guest.LoadBalancers.IsNetworkLoadBalancer)
+			}
+			if !reflect.DeepEqual(a.Guest.LoadBalancers.IngressElbPortsToOpen, tc.expectedIngressElbPortsToOpen) {
+				t.Fatalf("expected %#v got %#v", tc.expectedIngressElbPortsToOpen, a.Guest.LoadBalancers.IngressElbPortsToOpen)
+			}
+		})
+	}
+}
@@ -18,6 +18,11 @@ type GuestNATGatewayAdapter struct {
 }
 
 func (a *GuestNATGatewayAdapter) Adapt(cfg Config) error {
+	// Existing VPCs bring their own egress routing for the private subnets.
+	if key.IsExistingVPC(cfg.CustomObject) {
+		return nil
+	}
+
 	for i := 0; i < len(key.StatusAvailabilityZones(cfg.CustomObject)); i++ {
 		gw := Gateway{
 			ClusterID:             key.ClusterID(cfg.CustomObject),
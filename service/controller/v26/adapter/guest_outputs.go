@@ -1,29 +1,51 @@
 package adapter
 
 import (
+	"strings"
+
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
 type GuestOutputsAdapter struct {
-	Master         GuestOutputsAdapterMaster
-	Worker         GuestOutputsAdapterWorker
-	Route53Enabled bool
-	VersionBundle  GuestOutputsAdapterVersionBundle
+	Master                 GuestOutputsAdapterMaster
+	Worker                 GuestOutputsAdapterWorker
+	Route53Enabled         bool
+	TransitGatewayAttached bool
+	VersionBundle          GuestOutputsAdapterVersionBundle
 }
 
 func (a *GuestOutputsAdapter) Adapt(config Config) error {
 	a.Route53Enabled = config.Route53Enabled
+	a.TransitGatewayAttached = config.ControlPlaneTransitGatewayID != ""
+	a.Master.AvailabilityZones = strings.Join(config.StackState.MasterAvailabilityZones, ",")
 	a.Master.DockerVolume.ResourceName = config.StackState.DockerVolumeResourceName
+	a.Master.DockerVolume.Volume = key.VolumeOutputValue(config.StackState.MasterDockerVolume)
+	a.Master.EtcdRestoreSnapshot = config.StackState.MasterEtcdRestoreSnapshot
+	a.Master.EtcdVolume.Volume = key.VolumeOutputValue(config.StackState.MasterEtcdVolume)
 	a.Master.ImageID = config.StackState.MasterImageID
 	a.Master.Instance.ResourceName = config.StackState.MasterInstanceResourceName
 	a.Master.Instance.Type = config.StackState.MasterInstanceType
+	a.Master.LogVolume.Volume = key.VolumeOutputValue(config.StackState.MasterLogVolume)
 	a.Master.CloudConfig.Version = config.StackState.MasterCloudConfigVersion
 
-	a.Worker.ASG.Ref = key.WorkerASGRef
 	a.Worker.CloudConfig.Version = config.StackState.WorkerCloudConfigVersion
-	a.Worker.DockerVolumeSizeGB = config.StackState.WorkerDockerVolumeSizeGB
 	a.Worker.ImageID = config.StackState.WorkerImageID
-	a.Worker.InstanceType = config.StackState.WorkerInstanceType
+	a.Worker.VolumeEncryption = key.WorkerVolumeEncryptionOutputValue(config.TenantClusterVolumeKMSKeyARN)
+	a.Worker.VolumeType = config.StackState.WorkerVolumeType
+
+	for _, p := range config.StackState.WorkerNodePools {
+		n := GuestOutputsAdapterWorkerNodePool{}
+		n.ASG.Ref = key.NodePoolResourceName(key.WorkerASGRef, p.Name)
+		n.DockerVolumeSizeGB = p.DockerVolumeSizeGB
+		n.InstanceType = p.InstanceType
+		n.InstanceTypes = strings.Join(p.InstanceTypes, ",")
+		n.KubeletVolumeSizeGB = p.KubeletVolumeSizeGB
+		n.OnDemandBaseCapacity = p.OnDemandBaseCapacity
+		n.OutputKeySuffix = key.NodePoolResourceName("", p.Name)
+		n.SpotPercentage = p.SpotPercentage
+
+		a.Worker.NodePools = append(a.Worker.NodePools, n)
+	}
 
 	a.VersionBundle.Version = config.StackState.VersionBundleVersion
 
@@ -31,10 +53,14 @@ func (a *GuestOutputsAdapter) Adapt(config Config) error {
 }
 
 type GuestOutputsAdapterMaster struct {
-	ImageID      string
-	Instance     GuestOutputsAdapterMasterInstance
-	CloudConfig  GuestOutputsAdapterMasterCloudConfig
-	DockerVolume GuestOutputsAdapterMasterDockerVolume
+	AvailabilityZones   string
+	ImageID             string
+	Instance            GuestOutputsAdapterMasterInstance
+	CloudConfig         GuestOutputsAdapterMasterCloudConfig
+	DockerVolume        GuestOutputsAdapterMasterDockerVolume
+	EtcdRestoreSnapshot string
+	EtcdVolume          GuestOutputsAdapterMasterVolume
+	LogVolume           GuestOutputsAdapterMasterVolume
 }
 
 type GuestOutputsAdapterMasterInstance struct {
@@ -48,14 +74,34 @@ type GuestOutputsAdapterMasterCloudConfig struct {
 
 type GuestOutputsAdapterMasterDockerVolume struct {
 	ResourceName string
+	Volume       string
+}
+
+type GuestOutputsAdapterMasterVolume struct {
+	Volume string
 }
 
 type GuestOutputsAdapterWorker struct {
-	ASG                GuestOutputsAdapterWorkerASG
-	CloudConfig        GuestOutputsAdapterWorkerCloudConfig
-	DockerVolumeSizeGB string
-	ImageID            string
-	InstanceType       string
+	CloudConfig GuestOutputsAdapterWorkerCloudConfig
+	ImageID     string
+	NodePools   []GuestOutputsAdapterWorkerNodePool
+	// VolumeEncryption is the KMS key the worker volumes are encrypted with as
+	// defined by key.WorkerVolumeEncryptionOutputValue.
+	VolumeEncryption string
+	VolumeType       string
+}
+
+type GuestOutputsAdapterWorkerNodePool struct {
+	ASG                  GuestOutputsAdapterWorkerASG
+	DockerVolumeSizeGB   string
+	InstanceType         string
+	InstanceTypes        string
+	KubeletVolumeSizeGB  string
+	OnDemandBaseCapacity int
+	// OutputKeySuffix is appended to the keys of the node pool's outputs. It is
+	// empty for the unnamed node pool.
+	OutputKeySuffix string
+	SpotPercentage  int
 }
 
 type GuestOutputsAdapterWorkerASG struct {
@@ -75,3 +75,43 @@ func Test_CloudFormation_Adapter_Outputs_WorkerCloudConfigVersion(t *testing.T)
 		})
 	}
 }
+
+func Test_CloudFormation_Adapter_Outputs_WorkerVolumeEncryption(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		Description                    string
+		Config                         Config
+		ExpectedWorkerVolumeEncryption string
+	}{
+		{
+			Description: "worker volume encryption should be the AWS managed EBS key without KMS key",
+			Config: Config{
+				CustomObject: v1alpha1.AWSConfig{},
+			},
+			ExpectedWorkerVolumeEncryption: "aws/ebs",
+		},
+		{
+			Description: "worker volume encryption should be the KMS key",
+			Config: Config{
+				CustomObject:                 v1alpha1.AWSConfig{},
+				TenantClusterVolumeKMSKeyARN: "arn:aws:kms:eu-central-1:000000000000:key/abc",
+			},
+			ExpectedWorkerVolumeEncryption: "arn:aws:kms:eu-central-1:000000000000:key/abc",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			a := &GuestOutputsAdapter{}
+
+			err := a.Adapt(tc.Config)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if a.Worker.VolumeEncryption != tc.ExpectedWorkerVolumeEncryption {
+				t.Fatalf("expected %s got %s", tc.ExpectedWorkerVolumeEncryption, a.Worker.VolumeEncryption)
+			}
+		})
+	}
+}
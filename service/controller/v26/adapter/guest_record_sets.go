@@ -1,21 +1,55 @@
 package adapter
 
-import "github.com/giantswarm/aws-operator/service/controller/v26/key"
+import (
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
 
 type GuestRecordSetsAdapter struct {
 	BaseDomain                 string
 	EtcdDomain                 string
+	EtcdMembers                []GuestRecordSetsAdapterEtcdMember
 	ClusterID                  string
+	IsNetworkLoadBalancer      bool
 	MasterInstanceResourceName string
 	Route53Enabled             bool
 }
 
+// GuestRecordSetsAdapterEtcdMember resolves the domain of an etcd member to
+// the private IP of the master the etcd member is running on.
+type GuestRecordSetsAdapterEtcdMember struct {
+	Domain               string
+	InstanceResourceName string
+	ResourceName         string
+}
+
 func (a *GuestRecordSetsAdapter) Adapt(config Config) error {
 	a.BaseDomain = key.BaseDomain(config.CustomObject)
 	a.EtcdDomain = key.EtcdDomain(config.CustomObject)
 	a.ClusterID = key.ClusterID(config.CustomObject)
+	a.IsNetworkLoadBalancer = key.IsNetworkLoadBalancer(config.CustomObject)
 	a.MasterInstanceResourceName = config.StackState.MasterInstanceResourceName
 	a.Route53Enabled = config.Route53Enabled
 
+	// The etcd members of multi master tenant clusters find their peers using
+	// the record sets of the etcd member domains, which require the tenant
+	// cluster's hosted zone.
+	if key.MasterReplicas(config.CustomObject) > 1 {
+		if !config.Route53Enabled {
+			return microerror.Maskf(invalidConfigError, "multi master tenant clusters require Route53 to be enabled")
+		}
+
+		for i := 0; i < key.MasterReplicas(config.CustomObject); i++ {
+			m := GuestRecordSetsAdapterEtcdMember{
+				Domain:               key.EtcdMemberDomain(config.CustomObject, i),
+				InstanceResourceName: key.MasterResourceName(config.StackState.MasterInstanceResourceName, i),
+				ResourceName:         key.MasterResourceName("EtcdMemberRecordSet", i),
+			}
+
+			a.EtcdMembers = append(a.EtcdMembers, m)
+		}
+	}
+
 	return nil
 }
@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
@@ -75,3 +76,103 @@ func TestAdapterRecordSetsRegularFields(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapterRecordSetsEtcdMembers(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description         string
+		masters             []v1alpha1.AWSConfigSpecAWSNode
+		route53Enabled      bool
+		expectedEtcdMembers []GuestRecordSetsAdapterEtcdMember
+		errorMatcher        func(error) bool
+	}{
+		{
+			description:         "case 0: single master has no etcd member record sets",
+			masters:             []v1alpha1.AWSConfigSpecAWSNode{{InstanceType: "m3.large"}},
+			route53Enabled:      true,
+			expectedEtcdMembers: nil,
+			errorMatcher:        nil,
+		},
+		{
+			description: "case 1: multi master has one etcd member record set per master",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+			},
+			route53Enabled: true,
+			expectedEtcdMembers: []GuestRecordSetsAdapterEtcdMember{
+				{
+					Domain:               "etcd0.test-cluster.k8s.installation.aws.eu-central-1.gigantic.io",
+					InstanceResourceName: "MasterInstanceTESTCLUSTERABCDE",
+					ResourceName:         "EtcdMemberRecordSet",
+				},
+				{
+					Domain:               "etcd1.test-cluster.k8s.installation.aws.eu-central-1.gigantic.io",
+					InstanceResourceName: "MasterInstanceTESTCLUSTERABCDE01",
+					ResourceName:         "EtcdMemberRecordSet01",
+				},
+				{
+					Domain:               "etcd2.test-cluster.k8s.installation.aws.eu-central-1.gigantic.io",
+					InstanceResourceName: "MasterInstanceTESTCLUSTERABCDE02",
+					ResourceName:         "EtcdMemberRecordSet02",
+				},
+			},
+			errorMatcher: nil,
+		},
+		{
+			description: "case 2: multi master requires Route53",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+			},
+			route53Enabled:      false,
+			expectedEtcdMembers: nil,
+			errorMatcher:        IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		a := Adapter{}
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := Config{
+				CustomObject: v1alpha1.AWSConfig{
+					Spec: v1alpha1.AWSConfigSpec{
+						Cluster: v1alpha1.Cluster{
+							ID: "test-cluster",
+						},
+						AWS: v1alpha1.AWSConfigSpecAWS{
+							HostedZones: v1alpha1.AWSConfigSpecAWSHostedZones{
+								API: v1alpha1.AWSConfigSpecAWSHostedZonesZone{
+									Name: "installation.aws.eu-central-1.gigantic.io",
+								},
+							},
+							Masters: tc.masters,
+						},
+					},
+				},
+				Route53Enabled: tc.route53Enabled,
+				StackState: StackState{
+					MasterInstanceResourceName: "MasterInstanceTESTCLUSTERABCDE",
+				},
+			}
+			err := a.Guest.RecordSets.Adapt(cfg)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(a.Guest.RecordSets.EtcdMembers, tc.expectedEtcdMembers) {
+				t.Fatalf("EtcdMembers == %#v, want %#v", a.Guest.RecordSets.EtcdMembers, tc.expectedEtcdMembers)
+			}
+		})
+	}
+}
@@ -5,19 +5,49 @@ import (
 )
 
 type RouteTableName struct {
-	ResourceName        string
-	TagName             string
-	VPCPeeringRouteName string
+	// ID is the ID of an existing route table. It is only set for route tables
+	// of existing VPCs, which are referenced directly instead of by ResourceName.
+	ID           string
+	ResourceName string
+	TagName      string
+	// TransitGatewayRouteName is the logical ID of the route to the control
+	// plane VPC via the Transit Gateway. It is only set in case the tenant
+	// cluster's VPC is attached to a Transit Gateway.
+	TransitGatewayRouteName string
+	VPCPeeringRouteName     string
 }
 
 type GuestRouteTablesAdapter struct {
-	HostClusterCIDR        string
-	PublicRouteTableName   RouteTableName
-	PrivateRouteTableNames []RouteTableName
+	ExistingPrivateRouteTables []RouteTableName
+	HostClusterCIDR            string
+	PublicRouteTableName       RouteTableName
+	PrivateRouteTableNames     []RouteTableName
+	TransitGatewayID           string
 }
 
 func (r *GuestRouteTablesAdapter) Adapt(cfg Config) error {
 	r.HostClusterCIDR = cfg.ControlPlaneVPCCidr
+	r.TransitGatewayID = cfg.ControlPlaneTransitGatewayID
+
+	// Route tables of existing VPCs are not managed by the tenant cluster. We
+	// only add the VPC peering routes to the route tables of the private
+	// subnets.
+	if key.IsExistingVPC(cfg.CustomObject) {
+		for i, id := range cfg.ExistingVPCPrivateRouteTableIDs {
+			rtName := RouteTableName{
+				ID: id,
+			}
+			if r.TransitGatewayID != "" {
+				rtName.TransitGatewayRouteName = key.TransitGatewayRouteName(i)
+			} else {
+				rtName.VPCPeeringRouteName = key.VPCPeeringRouteName(i)
+			}
+			r.ExistingPrivateRouteTables = append(r.ExistingPrivateRouteTables, rtName)
+		}
+
+		return nil
+	}
+
 	r.PublicRouteTableName = RouteTableName{
 		ResourceName: "PublicRouteTable",
 		TagName:      key.RouteTableName(cfg.CustomObject, suffixPublic, 0),
@@ -25,9 +55,13 @@ func (r *GuestRouteTablesAdapter) Adapt(cfg Config) error {
 
 	for i := 0; i < len(key.StatusAvailabilityZones(cfg.CustomObject)); i++ {
 		rtName := RouteTableName{
-			ResourceName:        key.PrivateRouteTableName(i),
-			TagName:             key.RouteTableName(cfg.CustomObject, suffixPrivate, i),
-			VPCPeeringRouteName: key.VPCPeeringRouteName(i),
+			ResourceName: key.PrivateRouteTableName(i),
+			TagName:      key.RouteTableName(cfg.CustomObject, suffixPrivate, i),
+		}
+		if r.TransitGatewayID != "" {
+			rtName.TransitGatewayRouteName = key.TransitGatewayRouteName(i)
+		} else {
+			rtName.VPCPeeringRouteName = key.VPCPeeringRouteName(i)
 		}
 		r.PrivateRouteTableNames = append(r.PrivateRouteTableNames, rtName)
 	}
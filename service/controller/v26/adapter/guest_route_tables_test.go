@@ -93,3 +93,105 @@ func TestAdapterRouteTablesRegularFields(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapterRouteTablesExistingVPC(t *testing.T) {
+	t.Parallel()
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				VPC: v1alpha1.AWSConfigSpecAWSVPC{
+					ID: "vpc-1",
+				},
+			},
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+		},
+	}
+
+	cfg := Config{
+		ControlPlaneVPCCidr:             "10.0.0.0/16",
+		CustomObject:                    customObject,
+		ExistingVPCPrivateRouteTableIDs: []string{"rtb-1", "rtb-2"},
+	}
+
+	a := Adapter{}
+	err := a.Guest.RouteTables.Adapt(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := GuestRouteTablesAdapter{
+		ExistingPrivateRouteTables: []RouteTableName{
+			{
+				ID:                  "rtb-1",
+				VPCPeeringRouteName: "VPCPeeringRoute",
+			},
+			{
+				ID:                  "rtb-2",
+				VPCPeeringRouteName: "VPCPeeringRoute01",
+			},
+		},
+		HostClusterCIDR: "10.0.0.0/16",
+	}
+
+	if !reflect.DeepEqual(a.Guest.RouteTables, expected) {
+		t.Errorf("unexpected route tables, got %#v, want %#v", a.Guest.RouteTables, expected)
+	}
+}
+
+func TestAdapterRouteTablesTransitGateway(t *testing.T) {
+	t.Parallel()
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+		},
+		Status: v1alpha1.AWSConfigStatus{
+			AWS: v1alpha1.AWSConfigStatusAWS{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					{
+						Name: "eu-central-1a",
+					},
+					{
+						Name: "eu-central-1b",
+					},
+				},
+			},
+		},
+	}
+
+	cfg := Config{
+		ControlPlaneTransitGatewayID: "tgw-1",
+		ControlPlaneVPCCidr:          "10.0.0.0/16",
+		CustomObject:                 customObject,
+	}
+
+	a := Adapter{}
+	err := a.Guest.RouteTables.Adapt(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []RouteTableName{
+		{
+			ResourceName:            "PrivateRouteTable",
+			TagName:                 "test-cluster-private",
+			TransitGatewayRouteName: "TransitGatewayRoute00",
+		},
+		{
+			ResourceName:            "PrivateRouteTable01",
+			TagName:                 "test-cluster-private01",
+			TransitGatewayRouteName: "TransitGatewayRoute01",
+		},
+	}
+
+	if a.Guest.RouteTables.TransitGatewayID != "tgw-1" {
+		t.Errorf("unexpected TransitGatewayID, got %q, want %q", a.Guest.RouteTables.TransitGatewayID, "tgw-1")
+	}
+
+	if !reflect.DeepEqual(a.Guest.RouteTables.PrivateRouteTableNames, expected) {
+		t.Errorf("unexpected PrivateRouteTableNames, got %#v, want %#v", a.Guest.RouteTables.PrivateRouteTableNames, expected)
+	}
+}
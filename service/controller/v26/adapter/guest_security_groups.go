@@ -110,11 +110,49 @@ func (s *GuestSecurityGroupsAdapter) getMasterRules(cfg Config, hostClusterCIDR
 		},
 	}
 
+	// NLBs preserve the client IPs and do not have security groups, so etcd
+	// traffic and the NLB health checks arrive from the tenant cluster's VPC.
+	if key.IsNetworkLoadBalancer(cfg.CustomObject) {
+		otherRules = append(otherRules, securityGroupRule{
+			Description: "Allow traffic from tenant cluster CIDR to 2379 for etcd.",
+			Port:        etcdPort,
+			Protocol:    tcpProtocol,
+			SourceCIDR:  key.StatusNetworkCIDR(cfg.CustomObject),
+		})
+		for _, cidr := range key.StatusNetworkSecondaryCIDRs(cfg.CustomObject) {
+			otherRules = append(otherRules, securityGroupRule{
+				Description: "Allow traffic from tenant cluster secondary CIDR to 2379 for etcd.",
+				Port:        etcdPort,
+				Protocol:    tcpProtocol,
+				SourceCIDR:  cidr,
+			})
+		}
+	}
+
+	// The etcd members of multi master tenant clusters communicate with their
+	// peers using the private IPs of the masters.
+	if key.MasterReplicas(cfg.CustomObject) > 1 {
+		otherRules = append(otherRules, securityGroupRule{
+			Description: "Allow traffic from tenant cluster CIDR to 2380 for etcd peers.",
+			Port:        key.EtcdPeerPort,
+			Protocol:    tcpProtocol,
+			SourceCIDR:  key.StatusNetworkCIDR(cfg.CustomObject),
+		})
+		for _, cidr := range key.StatusNetworkSecondaryCIDRs(cfg.CustomObject) {
+			otherRules = append(otherRules, securityGroupRule{
+				Description: "Allow traffic from tenant cluster secondary CIDR to 2380 for etcd peers.",
+				Port:        key.EtcdPeerPort,
+				Protocol:    tcpProtocol,
+				SourceCIDR:  cidr,
+			})
+		}
+	}
+
 	return append(apiRules, otherRules...), nil
 }
 
 func (s *GuestSecurityGroupsAdapter) getWorkerRules(customObject v1alpha1.AWSConfig, hostClusterCIDR string) []securityGroupRule {
-	return []securityGroupRule{
+	ingressRules := []securityGroupRule{
 		{
 			Description:         "Allow traffic from the ingress security group to the ingress controller port 443.",
 			Port:                key.IngressControllerSecurePort(customObject),
@@ -127,6 +165,28 @@ func (s *GuestSecurityGroupsAdapter) getWorkerRules(customObject v1alpha1.AWSCon
 			Protocol:            tcpProtocol,
 			SourceSecurityGroup: ingressSecurityGroupName,
 		},
+	}
+
+	// NLBs do not have security groups and preserve the client IPs, so the
+	// ingress controller ports must be reachable from anywhere.
+	if key.IsNetworkLoadBalancer(customObject) {
+		ingressRules = []securityGroupRule{
+			{
+				Description: "Allow all traffic to the ingress controller port 443.",
+				Port:        key.IngressControllerSecurePort(customObject),
+				Protocol:    tcpProtocol,
+				SourceCIDR:  defaultCIDR,
+			},
+			{
+				Description: "Allow all traffic to the ingress controller port 80.",
+				Port:        key.IngressControllerInsecurePort(customObject),
+				Protocol:    tcpProtocol,
+				SourceCIDR:  defaultCIDR,
+			},
+		}
+	}
+
+	otherRules := []securityGroupRule{
 		{
 			Description: "Allow traffic from control plane to ingress controller secure port for tenant cluster scraping.",
 			Port:        key.IngressControllerSecurePort(customObject),
@@ -164,6 +224,8 @@ func (s *GuestSecurityGroupsAdapter) getWorkerRules(customObject v1alpha1.AWSCon
 			SourceCIDR:  hostClusterCIDR,
 		},
 	}
+
+	return append(ingressRules, otherRules...)
 }
 
 func (s *GuestSecurityGroupsAdapter) getIngressRules(customObject v1alpha1.AWSConfig) []securityGroupRule {
@@ -226,6 +288,15 @@ func getKubernetesAPIRules(cfg Config, hostClusterCIDR string) ([]securityGroupR
 			},
 		}
 
+		for _, cidr := range key.StatusNetworkSecondaryCIDRs(cfg.CustomObject) {
+			rules = append(rules, securityGroupRule{
+				Description: "Allow traffic from tenant cluster secondary CIDR.",
+				Port:        key.KubernetesAPISecurePort(cfg.CustomObject),
+				Protocol:    tcpProtocol,
+				SourceCIDR:  cidr,
+			})
+		}
+
 		// Whitelist all configured subnets.
 		whitelistSubnets := strings.Split(cfg.APIWhitelist.SubnetList, ",")
 		for _, subnet := range whitelistSubnets {
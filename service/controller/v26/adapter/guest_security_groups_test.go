@@ -520,3 +520,82 @@ func TestAdapterSecurityGroupsKubernetesAPIRules(t *testing.T) {
 		})
 	}
 }
+
+func TestAdapterSecurityGroupsEtcdPeerRules(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description   string
+		masters       []v1alpha1.AWSConfigSpecAWSNode
+		expectedRules []securityGroupRule
+	}{
+		{
+			description:   "case 0: single master does not allow etcd peer traffic",
+			masters:       []v1alpha1.AWSConfigSpecAWSNode{{InstanceType: "m3.large"}},
+			expectedRules: nil,
+		},
+		{
+			description: "case 1: multi master allows etcd peer traffic from the tenant cluster CIDRs",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+				{InstanceType: "m3.large"},
+			},
+			expectedRules: []securityGroupRule{
+				{
+					Description: "Allow traffic from tenant cluster CIDR to 2380 for etcd peers.",
+					Port:        2380,
+					Protocol:    "tcp",
+					SourceCIDR:  "10.1.0.0/24",
+				},
+				{
+					Description: "Allow traffic from tenant cluster secondary CIDR to 2380 for etcd peers.",
+					Port:        2380,
+					Protocol:    "tcp",
+					SourceCIDR:  "10.1.1.0/24",
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		a := Adapter{}
+
+		t.Run(tc.description, func(t *testing.T) {
+			cfg := Config{
+				ControlPlaneVPCCidr: "10.0.0.0/16",
+				CustomObject: v1alpha1.AWSConfig{
+					Spec: v1alpha1.AWSConfigSpec{
+						Cluster: v1alpha1.Cluster{
+							ID: "test-cluster",
+						},
+						AWS: v1alpha1.AWSConfigSpecAWS{
+							Masters: tc.masters,
+						},
+					},
+					Status: v1alpha1.AWSConfigStatus{
+						Cluster: v1alpha1.StatusCluster{
+							Network: v1alpha1.StatusClusterNetwork{
+								CIDR:           "10.1.0.0/24",
+								SecondaryCIDRs: []string{"10.1.1.0/24"},
+							},
+						},
+					},
+				},
+			}
+			err := a.Guest.SecurityGroups.Adapt(cfg)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			var rules []securityGroupRule
+			for _, r := range a.Guest.SecurityGroups.MasterSecurityGroupRules {
+				if r.Port == 2380 {
+					rules = append(rules, r)
+				}
+			}
+
+			if !reflect.DeepEqual(rules, tc.expectedRules) {
+				t.Fatalf("unexpected etcd peer rules, got %v, want %v", rules, tc.expectedRules)
+			}
+		})
+	}
+}
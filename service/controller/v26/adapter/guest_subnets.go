@@ -1,15 +1,18 @@
 package adapter
 
 import (
-	"sort"
+	"net"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/microerror"
 )
 
 type Subnet struct {
-	AvailabilityZone      string
-	CIDR                  string
+	AvailabilityZone string
+	CIDR             string
+	// DependsOn is the resource name of the secondary VPC CIDR block the subnet
+	// is part of. It is empty for subnets of the VPC's primary CIDR block.
+	DependsOn             string
 	Name                  string
 	MapPublicIPOnLaunch   bool
 	RouteTableAssociation RouteTableAssociation
@@ -27,10 +30,10 @@ type GuestSubnetsAdapter struct {
 }
 
 func (s *GuestSubnetsAdapter) Adapt(cfg Config) error {
+	// The availability zones must not be sorted. Availability zones added to
+	// the tenant cluster are appended to the CR status and the resource names of
+	// the subnets are derived from the positions of their availability zones.
 	zones := key.StatusAvailabilityZones(cfg.CustomObject)
-	sort.Slice(zones, func(i, j int) bool {
-		return zones[i].Name < zones[j].Name
-	})
 
 	{
 		numAZs := len(zones)
@@ -39,11 +42,27 @@ func (s *GuestSubnetsAdapter) Adapt(cfg Config) error {
 		}
 	}
 
+	// Subnets of existing VPCs are not managed by the tenant cluster. They are
+	// rendered as template parameters by the existing VPC adapter.
+	if key.IsExistingVPC(cfg.CustomObject) {
+		return nil
+	}
+
+	var secondaryCIDRs []net.IPNet
+	for _, cidr := range key.StatusNetworkSecondaryCIDRs(cfg.CustomObject) {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		secondaryCIDRs = append(secondaryCIDRs, *n)
+	}
+
 	for i, az := range zones {
 		snetName := key.PublicSubnetName(i)
 		snet := Subnet{
 			AvailabilityZone:    az.Name,
 			CIDR:                az.Subnet.Public.CIDR,
+			DependsOn:           secondaryCIDRBlockName(secondaryCIDRs, az.Subnet.Public.CIDR),
 			Name:                snetName,
 			MapPublicIPOnLaunch: false,
 			RouteTableAssociation: RouteTableAssociation{
@@ -58,6 +77,7 @@ func (s *GuestSubnetsAdapter) Adapt(cfg Config) error {
 		snet = Subnet{
 			AvailabilityZone:    az.Name,
 			CIDR:                az.Subnet.Private.CIDR,
+			DependsOn:           secondaryCIDRBlockName(secondaryCIDRs, az.Subnet.Private.CIDR),
 			Name:                snetName,
 			MapPublicIPOnLaunch: false,
 			RouteTableAssociation: RouteTableAssociation{
@@ -71,3 +91,21 @@ func (s *GuestSubnetsAdapter) Adapt(cfg Config) error {
 
 	return nil
 }
+
+// secondaryCIDRBlockName returns the resource name of the secondary VPC CIDR
+// block containing the given subnet, or the empty string in case the subnet is
+// part of the VPC's primary CIDR block.
+func secondaryCIDRBlockName(secondaryCIDRs []net.IPNet, subnet string) string {
+	ip, _, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return ""
+	}
+
+	for i, n := range secondaryCIDRs {
+		if n.Contains(ip) {
+			return key.VPCCIDRBlockName(i)
+		}
+	}
+
+	return ""
+}
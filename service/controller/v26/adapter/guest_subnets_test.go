@@ -23,24 +23,24 @@ func TestAdapterSubnetsRegularFields(t *testing.T) {
 					AWS: v1alpha1.AWSConfigStatusAWS{
 						AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
 							v1alpha1.AWSConfigStatusAWSAvailabilityZone{
-								Name: "eu-west-1b",
+								Name: "eu-west-1a",
 								Subnet: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnet{
 									Public: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPublic{
-										CIDR: "10.100.1.0/25",
+										CIDR: "10.100.2.0/25",
 									},
 									Private: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPrivate{
-										CIDR: "10.100.1.128/25",
+										CIDR: "10.100.2.128/25",
 									},
 								},
 							},
 							v1alpha1.AWSConfigStatusAWSAvailabilityZone{
-								Name: "eu-west-1a",
+								Name: "eu-west-1b",
 								Subnet: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnet{
 									Public: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPublic{
-										CIDR: "10.100.2.0/25",
+										CIDR: "10.100.1.0/25",
 									},
 									Private: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPrivate{
-										CIDR: "10.100.2.128/25",
+										CIDR: "10.100.1.128/25",
 									},
 								},
 							},
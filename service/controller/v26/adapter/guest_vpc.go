@@ -5,15 +5,22 @@ import (
 )
 
 type GuestVPCAdapter struct {
-	CidrBlock        string
-	ClusterID        string
-	InstallationName string
-	HostAccountID    string
-	PeerVPCID        string
-	PeerRoleArn      string
-	Region           string
-	RegionARN        string
-	RouteTableNames  []RouteTableName
+	CidrBlock          string
+	ClusterID          string
+	InstallationName   string
+	HostAccountID      string
+	PeerVPCID          string
+	PeerRoleArn        string
+	PrivateSubnetNames []string
+	Region             string
+	RegionARN          string
+	RouteTableNames    []RouteTableName
+	// SecondaryCidrBlocks are the secondary network segments of the VPC
+	// allocated when adding availability zones to the tenant cluster.
+	SecondaryCidrBlocks []GuestVPCAdapterCidrBlock
+	// TransitGatewayID is the ID of the Transit Gateway the VPC is attached to
+	// instead of being peered with the control plane VPC.
+	TransitGatewayID string
 }
 
 func (v *GuestVPCAdapter) Adapt(cfg Config) error {
@@ -25,6 +32,15 @@ func (v *GuestVPCAdapter) Adapt(cfg Config) error {
 	v.Region = key.Region(cfg.CustomObject)
 	v.RegionARN = key.RegionARN(cfg.CustomObject)
 	v.PeerRoleArn = cfg.ControlPlanePeerRoleARN
+	v.TransitGatewayID = cfg.ControlPlaneTransitGatewayID
+
+	for i, cidr := range key.StatusNetworkSecondaryCIDRs(cfg.CustomObject) {
+		b := GuestVPCAdapterCidrBlock{
+			CidrBlock: cidr,
+			Name:      key.VPCCIDRBlockName(i),
+		}
+		v.SecondaryCidrBlocks = append(v.SecondaryCidrBlocks, b)
+	}
 
 	PublicRouteTable := RouteTableName{
 		ResourceName: key.PublicRouteTableName(0),
@@ -33,6 +49,8 @@ func (v *GuestVPCAdapter) Adapt(cfg Config) error {
 	v.RouteTableNames = append(v.RouteTableNames, PublicRouteTable)
 
 	for i := 0; i < len(key.StatusAvailabilityZones(cfg.CustomObject)); i++ {
+		v.PrivateSubnetNames = append(v.PrivateSubnetNames, key.PrivateSubnetName(i))
+
 		rtName := RouteTableName{
 			ResourceName:        key.PrivateRouteTableName(i),
 			TagName:             key.RouteTableName(cfg.CustomObject, suffixPrivate, i),
@@ -43,3 +61,8 @@ func (v *GuestVPCAdapter) Adapt(cfg Config) error {
 
 	return nil
 }
+
+type GuestVPCAdapterCidrBlock struct {
+	CidrBlock string
+	Name      string
+}
@@ -1,5 +1,9 @@
 package adapter
 
+import (
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
 const (
 	// asgMaxBatchSizeRatio is the % of instances to be updated during a
 	// rolling update.
@@ -11,8 +15,6 @@ const (
 	defaultEBSVolumeMountPoint = "/dev/xvdh"
 	// defaultEBSVolumeSize is expressed in GB.
 	defaultEBSVolumeSize = "100"
-	// defaultEBSVolumeType is the EBS volume type.
-	defaultEBSVolumeType = "gp2"
 	// rollingUpdatePauseTime is how long to pause ASG operations after creating
 	// new instances. This allows time for new nodes to join the cluster.
 	rollingUpdatePauseTime = "PT15M"
@@ -20,6 +22,8 @@ const (
 	logEBSVolumeMountPoint = "/dev/xvdf"
 	// kubeletEBSVolumeMountPoint is the path for mounting the log EBS volume
 	kubeletEBSVolumeMountPoint = "/dev/xvdg"
+	// rootEBSVolumeMountPoint is the device name of the root EBS volume.
+	rootEBSVolumeMountPoint = "/dev/xvda"
 
 	// Subnet keys
 	subnetDescription = "description"
@@ -43,6 +47,12 @@ const (
 
 	httpPort  = 80
 	httpsPort = 443
+
+	// Prefixes of the CloudFormation resource names of the load balancers and
+	// their NLB listeners and target groups.
+	apiLoadBalancerResourcePrefix     = "Api"
+	etcdLoadBalancerResourcePrefix    = "Etcd"
+	ingressLoadBalancerResourcePrefix = "Ingress"
 )
 
 // APIWhitelist defines guest cluster k8s api whitelisting.
@@ -60,10 +70,19 @@ type Hydrater func(config Config) error
 type StackState struct {
 	Name string
 
-	DockerVolumeResourceName   string
+	DockerVolumeResourceName string
+	// MasterAvailabilityZones are the availability zones the masters are
+	// placed in, ordered by master index. Masters must stay in their
+	// availability zones when availability zones are added to the tenant
+	// cluster.
+	MasterAvailabilityZones    []string
+	MasterDockerVolume         v1alpha1.AWSConfigSpecAWSVolume
+	MasterEtcdRestoreSnapshot  string
+	MasterEtcdVolume           v1alpha1.AWSConfigSpecAWSVolume
 	MasterImageID              string
 	MasterInstanceType         string
 	MasterInstanceResourceName string
+	MasterLogVolume            v1alpha1.AWSConfigSpecAWSVolume
 	// TODO the cloud config versions shouldn't be injected here. These should
 	// actually always only be the ones the operator has hard coded. No other
 	// version should be used here ever.
@@ -73,23 +92,45 @@ type StackState struct {
 	// TODO the cloud config versions shouldn't be injected here. These should
 	// actually always only be the ones the operator has hard coded. No other
 	// version should be used here ever.
-	WorkerCloudConfigVersion  string
-	WorkerDesired             int
-	WorkerDockerVolumeSizeGB  string
-	WorkerKubeletVolumeSizeGB string
-	WorkerLogVolumeSizeGB     string
-	WorkerImageID             string
-	WorkerInstanceMonitoring  bool
-	WorkerInstanceType        string
-	WorkerMax                 int
-	WorkerMin                 int
+	WorkerCloudConfigVersion string
+	WorkerImageID            string
+	WorkerInstanceMonitoring bool
+	WorkerLogVolumeSizeGB    string
+	// WorkerNodePools are the node pools of the tenant cluster. Each node pool
+	// gets its own launch template, auto scaling group and lifecycle hook.
+	WorkerNodePools  []StackStateNodePool
+	WorkerVolumeType string
 
 	VersionBundleVersion string
 }
 
+// StackStateNodePool is the state of a single worker node pool.
+type StackStateNodePool struct {
+	// Name is the name of the node pool. It is empty for the unnamed node pool
+	// of tenant clusters without configured node pools.
+	Name string
+
+	Desired             int
+	DockerVolumeSizeGB  string
+	InstanceType        string
+	KubeletVolumeSizeGB string
+	// InstanceTypes are all instance types the node pool's auto scaling group
+	// may launch, starting with InstanceType.
+	InstanceTypes        []string
+	Max                  int
+	Min                  int
+	OnDemandBaseCapacity int
+	SpotPercentage       int
+}
+
 // SmallCloudconfigConfig represents the data structure required for executing
 // the small cloudconfig template.
 type SmallCloudconfigConfig struct {
-	InstanceRole string
-	S3URL        string
+	// EtcdMemberEnvironment is the base64 encoded content of the etcd member
+	// environment file of a master of a multi master tenant cluster. It is
+	// empty for single master tenant clusters and workers.
+	EtcdMemberEnvironment     string
+	EtcdMemberEnvironmentFile string
+	InstanceRole              string
+	S3URL                     string
 }
@@ -0,0 +1,101 @@
+package v26
+
+import (
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller"
+
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/v26/adapter"
+	"github.com/giantswarm/aws-operator/service/controller/v26/cloudconfig"
+)
+
+// Bundle returns the declaration of the v26 version bundle, which creates its
+// resource sets out of the settings it supports.
+func Bundle() core.Bundle {
+	return core.Bundle{
+		VersionBundle: VersionBundle(),
+
+		NewClusterResourceSet: newClusterResourceSetFromCore,
+		NewDrainerResourceSet: newDrainerResourceSetFromCore,
+	}
+}
+
+func newClusterResourceSetFromCore(config core.ClusterResourceSetConfig) (*controller.ResourceSet, error) {
+	c := ClusterResourceSetConfig{
+		CertsSearcher:          config.CertsSearcher,
+		ControlPlaneAWSClients: config.ControlPlaneAWSClients,
+		EventRecorder:          config.EventRecorder,
+		G8sClient:              config.G8sClient,
+		HostAWSConfig:          config.HostAWSConfig,
+		K8sClient:              config.K8sClient,
+		Logger:                 config.Logger,
+		RandomKeysSearcher:     config.RandomKeysSearcher,
+
+		AccessLogsExpiration:  config.AccessLogsExpiration,
+		AdvancedMonitoringEC2: config.AdvancedMonitoringEC2,
+		APIWhitelist: adapter.APIWhitelist{
+			Enabled:    config.APIWhitelist.Enabled,
+			SubnetList: config.APIWhitelist.SubnetList,
+		},
+		DeleteLoggingBucket:        config.DeleteLoggingBucket,
+		EncrypterBackend:           config.EncrypterBackend,
+		EtcdBackupExpiration:       config.EtcdBackupExpiration,
+		GuestAvailabilityZones:     config.GuestAvailabilityZones,
+		GuestPrivateSubnetMaskBits: config.GuestPrivateSubnetMaskBits,
+		GuestPublicSubnetMaskBits:  config.GuestPublicSubnetMaskBits,
+		GuestSubnetMaskBits:        config.GuestSubnetMaskBits,
+		IgnitionPath:               config.IgnitionPath,
+		ImageName:                  config.ImageName,
+		ImageOwner:                 config.ImageOwner,
+		ImageResolver:              config.ImageResolver,
+		IncludeTags:                config.IncludeTags,
+		InstallationName:           config.InstallationName,
+		IPAMNetworkRange:           config.IPAMNetworkRange,
+		IPAMNetworkRanges:          config.IPAMNetworkRanges,
+		OIDC: cloudconfig.OIDCConfig{
+			ClientID:      config.OIDC.ClientID,
+			IssuerURL:     config.OIDC.IssuerURL,
+			UsernameClaim: config.OIDC.UsernameClaim,
+			GroupsClaim:   config.OIDC.GroupsClaim,
+		},
+		PodInfraContainerImage:     config.PodInfraContainerImage,
+		ProjectName:                config.ProjectName,
+		RegistryDomain:             config.RegistryDomain,
+		RollbackSkipResources:      config.RollbackSkipResources,
+		Route53Enabled:             config.Route53Enabled,
+		RouteTables:                config.RouteTables,
+		SSOPublicKey:               config.SSOPublicKey,
+		TransitGatewayID:           config.TransitGatewayID,
+		VaultAddress:               config.VaultAddress,
+		VolumeSnapshotDockerVolume: config.VolumeSnapshotDockerVolume,
+		VolumeSnapshotRetention:    config.VolumeSnapshotRetention,
+	}
+
+	resourceSet, err := NewClusterResourceSet(c)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resourceSet, nil
+}
+
+func newDrainerResourceSetFromCore(config core.DrainerResourceSetConfig) (*controller.ResourceSet, error) {
+	c := DrainerResourceSetConfig{
+		ControlPlaneAWSClients: config.ControlPlaneAWSClients,
+		EventRecorder:          config.EventRecorder,
+		G8sClient:              config.G8sClient,
+		HostAWSConfig:          config.HostAWSConfig,
+		K8sClient:              config.K8sClient,
+		Logger:                 config.Logger,
+
+		ProjectName:    config.ProjectName,
+		Route53Enabled: config.Route53Enabled,
+	}
+
+	resourceSet, err := NewDrainerResourceSet(c)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return resourceSet, nil
+}
@@ -2,6 +2,8 @@ package cloudconfig
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
@@ -13,9 +15,11 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger/microloggertest"
 	"github.com/giantswarm/randomkeys"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
 func Test_Service_CloudConfig_NewMasterTemplate(t *testing.T) {
@@ -65,6 +69,8 @@ func Test_Service_CloudConfig_NewMasterTemplate(t *testing.T) {
 			"/etc/kubernetes/ssl/etcd/client-crt.pem.enc",
 			"/etc/kubernetes/ssl/etcd/client-key.pem.enc",
 			"decrypt-tls-assets.service",
+			"etcd-backup.timer",
+			"etcd-restore.service",
 			"a2luZDogRW5jcnlwdGlvbkNvbmZpZwphcGlWZXJzaW9uOiB2MQpyZXNvdXJjZXM6CiAgLSByZXNvdXJjZXM6CiAgICAtIHNlY3JldHMKICAgIHByb3ZpZGVyczoKICAgIC0gYWVzY2JjOgogICAgICAgIGtleXM6CiAgICAgICAgLSBuYW1lOiBrZXkxCiAgICAgICAgICBzZWNyZXQ6IGZla2hmaXdvaXFob2lmaHdxZWZvaXF3ZWZvaWtxaHdlZgogICAgLSBpZGVudGl0eToge30=",
 		}
 		for _, expectedString := range expectedStrings {
@@ -75,6 +81,203 @@ func Test_Service_CloudConfig_NewMasterTemplate(t *testing.T) {
 	}
 }
 
+func Test_Service_CloudConfig_NewMasterTemplate_EtcdRestoreSnapshot(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		snapshot     string
+		masters      []v1alpha1.AWSConfigSpecAWSNode
+		errorMatcher func(error) bool
+	}{
+		{
+			name:         "case 0: valid snapshot name",
+			snapshot:     "etcd-backup-20190801T060000Z.db",
+			masters:      nil,
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 1: snapshot name with shell characters",
+			snapshot:     "etcd-backup.db; rm -rf /",
+			masters:      nil,
+			errorMatcher: IsInvalidConfig,
+		},
+		{
+			name:     "case 2: valid snapshot name with multiple masters",
+			snapshot: "etcd-backup-20190801T060000Z.db",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{
+					InstanceType: "m4.xlarge",
+				},
+				{
+					InstanceType: "m4.xlarge",
+				},
+			},
+			errorMatcher: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			customObject := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						key.AnnotationEtcdRestoreSnapshot: tc.snapshot,
+					},
+				},
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Masters: tc.masters,
+					},
+					Cluster: v1alpha1.Cluster{
+						ID: "al9qy",
+					},
+				},
+			}
+
+			ctlCtx := controllercontext.Context{}
+			ctx := controllercontext.NewContext(context.Background(), ctlCtx)
+
+			ccService, err := testNewCloudConfigService()
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			_, err = ccService.NewMasterTemplate(ctx, customObject, certs.Cluster{}, randomkeys.Cluster{})
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_Service_CloudConfig_NewMasterTemplate_EtcdMembers(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name                   string
+		masters                []v1alpha1.AWSConfigSpecAWSNode
+		expectedDropIn         bool
+		expectedInitialCluster string
+		expectedMemberName     string
+		expectedPeerURL        string
+	}{
+		{
+			name:                   "case 0: single master runs the single member etcd cluster",
+			masters:                nil,
+			expectedDropIn:         false,
+			expectedInitialCluster: "etcd0=https://127.0.0.1:2380",
+			expectedMemberName:     "etcd0",
+			expectedPeerURL:        "https://127.0.0.1:2380",
+		},
+		{
+			name: "case 1: three masters run one etcd member each",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{
+					InstanceType: "m4.xlarge",
+				},
+				{
+					InstanceType: "m4.xlarge",
+				},
+				{
+					InstanceType: "m4.xlarge",
+				},
+			},
+			expectedDropIn: true,
+			expectedInitialCluster: "etcd0=https://etcd0.al9qy.k8s.installation.eu-central-1.aws.gigantic.io:2380," +
+				"etcd1=https://etcd1.al9qy.k8s.installation.eu-central-1.aws.gigantic.io:2380," +
+				"etcd2=https://etcd2.al9qy.k8s.installation.eu-central-1.aws.gigantic.io:2380",
+			expectedMemberName: "${ETCD_MEMBER_NAME}",
+			expectedPeerURL:    "${ETCD_MEMBER_PEER_URL}",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			customObject := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						key.AnnotationEtcdRestoreSnapshot: "etcd-backup-20190801T060000Z.db",
+					},
+				},
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						HostedZones: v1alpha1.AWSConfigSpecAWSHostedZones{
+							API: v1alpha1.AWSConfigSpecAWSHostedZonesZone{
+								Name: "installation.eu-central-1.aws.gigantic.io",
+							},
+						},
+						Masters: tc.masters,
+					},
+					Cluster: v1alpha1.Cluster{
+						ID: "al9qy",
+						Etcd: v1alpha1.ClusterEtcd{
+							Domain: "etcd.al9qy.k8s.installation.eu-central-1.aws.gigantic.io",
+							Port:   2379,
+						},
+					},
+				},
+			}
+
+			ctlCtx := controllercontext.Context{}
+			ctx := controllercontext.NewContext(context.Background(), ctlCtx)
+
+			ccService, err := testNewCloudConfigService()
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			template, err := ccService.NewMasterTemplate(ctx, customObject, certs.Cluster{}, randomkeys.Cluster{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			dropIn, ok := testFileContent(t, template, "/etc/systemd/system/etcd3.service.d/10-etcd-member.conf")
+			if ok != tc.expectedDropIn {
+				t.Fatalf("expected etcd member drop-in to be rendered %t", tc.expectedDropIn)
+			}
+			if tc.expectedDropIn {
+				expectedStrings := []string{
+					"EnvironmentFile=" + key.EtcdMemberEnvironmentFile,
+					"--name " + tc.expectedMemberName + " ",
+					"--initial-advertise-peer-urls=" + tc.expectedPeerURL + " ",
+					"--initial-cluster " + tc.expectedInitialCluster + " ",
+					"--advertise-client-urls=https://etcd.al9qy.k8s.installation.eu-central-1.aws.gigantic.io:2379 ",
+				}
+				for _, s := range expectedStrings {
+					if !strings.Contains(dropIn, s) {
+						t.Fatalf("expected etcd member drop-in to contain %q, got %q", s, dropIn)
+					}
+				}
+			}
+
+			restore, ok := testFileContent(t, template, "/opt/bin/etcd-restore")
+			if !ok {
+				t.Fatalf("expected etcd restore script to be rendered")
+			}
+			expectedStrings := []string{
+				"--name " + tc.expectedMemberName + " ",
+				"--initial-cluster " + tc.expectedInitialCluster + " ",
+				"--initial-advertise-peer-urls " + tc.expectedPeerURL + " ",
+			}
+			if tc.expectedDropIn {
+				expectedStrings = append(expectedStrings, ". "+key.EtcdMemberEnvironmentFile+"\n")
+			}
+			for _, s := range expectedStrings {
+				if !strings.Contains(restore, s) {
+					t.Fatalf("expected etcd restore script to contain %q, got %q", s, restore)
+				}
+			}
+		})
+	}
+}
+
 func Test_Service_CloudConfig_NewWorkerTemplate(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -103,7 +306,7 @@ func Test_Service_CloudConfig_NewWorkerTemplate(t *testing.T) {
 			t.Fatalf("expected %#v got %#v", nil, err)
 		}
 
-		template, err := ccService.NewWorkerTemplate(ctx, tc.CustomObject, certs.Cluster{})
+		template, err := ccService.NewWorkerTemplate(ctx, tc.CustomObject, certs.Cluster{}, v1alpha1.AWSConfigSpecAWSNodePool{})
 		if err != nil {
 			t.Fatalf("expected %#v got %#v", nil, err)
 		}
@@ -145,3 +348,42 @@ func testNewCloudConfigService() (*CloudConfig, error) {
 
 	return ccService, nil
 }
+
+// testFileContent returns the decoded content of the file with the given path
+// in the given ignition config and whether the file was found.
+func testFileContent(t *testing.T, ignitionConfig string, path string) (string, bool) {
+	var config struct {
+		Storage struct {
+			Files []struct {
+				Path     string `json:"path"`
+				Contents struct {
+					Source string `json:"source"`
+				} `json:"contents"`
+			} `json:"files"`
+		} `json:"storage"`
+	}
+	err := json.Unmarshal([]byte(ignitionConfig), &config)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	for _, f := range config.Storage.Files {
+		if f.Path != path {
+			continue
+		}
+
+		prefix := "base64,"
+		i := strings.Index(f.Contents.Source, prefix)
+		if i < 0 {
+			t.Fatalf("expected base64 content of file %#q", path)
+		}
+		content, err := base64.StdEncoding.DecodeString(f.Contents.Source[i+len(prefix):])
+		if err != nil {
+			t.Fatalf("expected %#v got %#v", nil, err)
+		}
+
+		return string(content), true
+	}
+
+	return "", false
+}
@@ -10,5 +10,5 @@ import (
 
 type Interface interface {
 	NewMasterTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster, clusterKeys randomkeys.Cluster) (string, error)
-	NewWorkerTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster) (string, error)
+	NewWorkerTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster, nodePool v1alpha1.AWSConfigSpecAWSNodePool) (string, error)
 }
@@ -3,6 +3,8 @@ package cloudconfig
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
+	"regexp"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/certs"
@@ -12,9 +14,15 @@ import (
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter/vault"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/aws-operator/service/controller/v26/templates/cloudconfig"
 )
 
+// etcdSnapshotNameRegexp matches the names of etcd snapshots which can be
+// restored. The name is rendered into the restore script, so it must not
+// contain any characters with special meaning to the shell.
+var etcdSnapshotNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
+
 // NewMasterTemplate generates a new master cloud config template and returns it
 // as a string.
 func (c *CloudConfig) NewMasterTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster, clusterKeys randomkeys.Cluster) (string, error) {
@@ -25,6 +33,11 @@ func (c *CloudConfig) NewMasterTemplate(ctx context.Context, customObject v1alph
 		return "", microerror.Mask(err)
 	}
 
+	snapshot := key.EtcdRestoreSnapshot(customObject)
+	if snapshot != "" && !etcdSnapshotNameRegexp.MatchString(snapshot) {
+		return "", microerror.Maskf(invalidConfigError, "etcd snapshot name %#q must match %#q", snapshot, etcdSnapshotNameRegexp.String())
+	}
+
 	randomKeyTmplSet, err := renderRandomKeyTmplSet(ctx, c.encrypter, cc.Status.TenantCluster.Encryption.Key, clusterKeys)
 	if err != nil {
 		return "", microerror.Mask(err)
@@ -51,6 +64,7 @@ func (c *CloudConfig) NewMasterTemplate(ctx context.Context, customObject v1alph
 			ctlCtx:        cc,
 
 			ClusterCerts:     clusterCerts,
+			EtcdImage:        fmt.Sprintf("%s/%s", c.registryDomain, params.Images.Etcd),
 			RandomKeyTmplSet: randomKeyTmplSet,
 		}
 		params.Hyperkube.Apiserver.Pod.CommandExtraArgs = c.k8sAPIExtraArgs
@@ -100,7 +114,10 @@ type MasterExtension struct {
 	//
 	ctlCtx *controllercontext.Context
 
-	ClusterCerts     certs.Cluster
+	ClusterCerts certs.Cluster
+	// EtcdImage is the etcd image used to take and restore etcd snapshots. It
+	// matches the image of the etcd running on the master.
+	EtcdImage        string
 	RandomKeyTmplSet RandomKeyTmplSet
 }
 
@@ -195,6 +212,41 @@ func (e *MasterExtension) Files() ([]k8scloudconfig.FileAsset, error) {
 			},
 			Permissions: 0644,
 		},
+		// Scripts taking etcd snapshots periodically and restoring a chosen
+		// snapshot when the master is brought up.
+		{
+			AssetContent: cloudconfig.EtcdBackupScript,
+			Path:         "/opt/bin/etcd-backup",
+			Owner: k8scloudconfig.Owner{
+				User:  FileOwnerUser,
+				Group: FileOwnerGroup,
+			},
+			Permissions: FilePermission,
+		},
+		{
+			AssetContent: cloudconfig.EtcdRestoreScript,
+			Path:         "/opt/bin/etcd-restore",
+			Owner: k8scloudconfig.Owner{
+				User:  FileOwnerUser,
+				Group: FileOwnerGroup,
+			},
+			Permissions: FilePermission,
+		},
+	}
+
+	// k8scloudconfig configures a single member etcd cluster. Masters of multi
+	// master tenant clusters override it, so that their etcd members join a
+	// common cluster.
+	if key.MasterReplicas(e.customObject) > 1 {
+		filesMeta = append(filesMeta, k8scloudconfig.FileMetadata{
+			AssetContent: cloudconfig.EtcdMemberDropIn,
+			Path:         "/etc/systemd/system/etcd3.service.d/10-etcd-member.conf",
+			Owner: k8scloudconfig.Owner{
+				User:  FileOwnerUser,
+				Group: FileOwnerGroup,
+			},
+			Permissions: 0644,
+		})
 	}
 
 	certsMeta := []k8scloudconfig.FileMetadata{}
@@ -229,7 +281,7 @@ func (e *MasterExtension) Files() ([]k8scloudconfig.FileAsset, error) {
 
 	var fileAssets []k8scloudconfig.FileAsset
 
-	data := e.templateData()
+	data := e.masterTemplateData()
 
 	for _, fm := range filesMeta {
 		c, err := k8scloudconfig.RenderFileAssetContent(fm.AssetContent, data)
@@ -307,6 +359,23 @@ func (e *MasterExtension) Units() ([]k8scloudconfig.UnitAsset, error) {
 			Name:         "var-log.mount",
 			Enabled:      true,
 		},
+		// Restore etcd from a snapshot before etcd starts and take snapshots
+		// periodically.
+		{
+			AssetContent: cloudconfig.EtcdRestoreService,
+			Name:         "etcd-restore.service",
+			Enabled:      true,
+		},
+		{
+			AssetContent: cloudconfig.EtcdBackupService,
+			Name:         "etcd-backup.service",
+			Enabled:      false,
+		},
+		{
+			AssetContent: cloudconfig.EtcdBackupTimer,
+			Name:         "etcd-backup.timer",
+			Enabled:      true,
+		},
 	}
 
 	var newUnits []k8scloudconfig.UnitAsset
@@ -328,6 +397,36 @@ func (e *MasterExtension) Units() ([]k8scloudconfig.UnitAsset, error) {
 	return newUnits, nil
 }
 
+func (e *MasterExtension) masterTemplateData() masterTemplateData {
+	data := masterTemplateData{
+		templateData: e.templateData(),
+		EtcdBackup: etcdBackupTemplateData{
+			Bucket:          key.BucketName(e.customObject, e.ctlCtx.Status.TenantCluster.AWSAccountID),
+			Prefix:          key.EtcdBackupPrefix(e.customObject),
+			RestoreSnapshot: key.EtcdRestoreSnapshot(e.customObject),
+		},
+		EtcdImage: e.EtcdImage,
+		// Single master tenant clusters run the single member etcd cluster of
+		// k8scloudconfig.
+		EtcdMember: etcdMemberTemplateData{
+			InitialCluster: "etcd0=https://127.0.0.1:2380",
+			Name:           "etcd0",
+			PeerURL:        "https://127.0.0.1:2380",
+		},
+	}
+
+	if key.MasterReplicas(e.customObject) > 1 {
+		data.EtcdMember = etcdMemberTemplateData{
+			EnvironmentFile: key.EtcdMemberEnvironmentFile,
+			InitialCluster:  key.EtcdInitialCluster(e.customObject),
+			Name:            "${ETCD_MEMBER_NAME}",
+			PeerURL:         "${ETCD_MEMBER_PEER_URL}",
+		}
+	}
+
+	return data
+}
+
 func (e *MasterExtension) VerbatimSections() []k8scloudconfig.VerbatimSection {
 	newSections := []k8scloudconfig.VerbatimSection{}
 
@@ -10,3 +10,33 @@ type templateData struct {
 	VaultAddress  string
 	EncryptionKey string
 }
+
+// masterTemplateData extends templateData with settings which are only
+// relevant for master nodes.
+type masterTemplateData struct {
+	templateData
+	EtcdBackup etcdBackupTemplateData
+	EtcdImage  string
+	EtcdMember etcdMemberTemplateData
+}
+
+// etcdBackupTemplateData describes where etcd snapshots are stored and which
+// snapshot has to be restored, if any.
+type etcdBackupTemplateData struct {
+	Bucket          string
+	Prefix          string
+	RestoreSnapshot string
+}
+
+// etcdMemberTemplateData describes the etcd member running on the master.
+// Masters of multi master tenant clusters share the same cloud config, so
+// their member name and peer URL are shell variables defined in the etcd
+// member environment file of each master.
+type etcdMemberTemplateData struct {
+	// EnvironmentFile is the path of the etcd member environment file. It is
+	// empty for single master tenant clusters.
+	EnvironmentFile string
+	InitialCluster  string
+	Name            string
+	PeerURL         string
+}
@@ -3,6 +3,8 @@ package cloudconfig
 import (
 	"context"
 	"encoding/base64"
+	"fmt"
+	"strings"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/certs"
@@ -10,12 +12,14 @@ import (
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/aws-operator/service/controller/v26/templates/cloudconfig"
 )
 
-// NewWorkerTemplate generates a new worker cloud config template and returns it
-// as a string.
-func (c *CloudConfig) NewWorkerTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster) (string, error) {
+// NewWorkerTemplate generates a new worker cloud config template for the given
+// node pool and returns it as a string. The node pool's labels and taints are
+// registered by the kubelet of its nodes.
+func (c *CloudConfig) NewWorkerTemplate(ctx context.Context, customObject v1alpha1.AWSConfig, clusterCerts certs.Cluster, nodePool v1alpha1.AWSConfigSpecAWSNodePool) (string, error) {
 	var err error
 
 	cc, err := controllercontext.FromContext(ctx)
@@ -36,6 +40,7 @@ func (c *CloudConfig) NewWorkerTemplate(ctx context.Context, customObject v1alph
 		params = k8scloudconfig.DefaultParams()
 
 		params.Cluster = customObject.Spec.Cluster
+		params.Cluster.Kubernetes.Kubelet.Labels = joinLabels(params.Cluster.Kubernetes.Kubelet.Labels, key.NodePoolLabels(nodePool))
 		params.Extension = &WorkerExtension{
 			baseExtension: be,
 			ctlCtx:        cc,
@@ -43,6 +48,11 @@ func (c *CloudConfig) NewWorkerTemplate(ctx context.Context, customObject v1alph
 			ClusterCerts: clusterCerts,
 		}
 		params.Hyperkube.Kubelet.Docker.CommandExtraArgs = c.k8sKubeletExtraArgs
+		if taints := key.NodePoolTaints(nodePool); taints != "" {
+			args := append([]string{}, c.k8sKubeletExtraArgs...)
+			args = append(args, fmt.Sprintf("--register-with-taints=%s", taints))
+			params.Hyperkube.Kubelet.Docker.CommandExtraArgs = args
+		}
 		params.RegistryDomain = c.registryDomain
 		params.SSOPublicKey = c.SSOPublicKey
 
@@ -235,3 +245,16 @@ func (e *WorkerExtension) VerbatimSections() []k8scloudconfig.VerbatimSection {
 
 	return newSections
 }
+
+// joinLabels joins the given comma separated kubelet node labels, skipping
+// empty ones.
+func joinLabels(labels ...string) string {
+	var nonEmpty []string
+	for _, l := range labels {
+		if l != "" {
+			nonEmpty = append(nonEmpty, l)
+		}
+	}
+
+	return strings.Join(nonEmpty, ",")
+}
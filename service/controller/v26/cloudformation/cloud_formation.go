@@ -1,6 +1,8 @@
 package cloudformation
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/giantswarm/microerror"
@@ -83,6 +85,62 @@ func (c *CloudFormation) DescribeOutputsAndStatus(stackName string) ([]Output, s
 	return stackOutputs, stackStatus, nil
 }
 
+// DescribeFailure returns the resource which caused the last operation on the
+// given stack to fail. Stack events are returned in reverse chronological order.
+// They are inspected until the event initiating the last stack operation is
+// found. The oldest failed resource event of that operation is the root cause.
+// All later failures are usually follow-up failures like cancelled resource
+// creations. In case no failed resource event can be found,
+// failedResourceNotFoundError is returned.
+func (c *CloudFormation) DescribeFailure(stackName string) (StackFailure, error) {
+	var failure *StackFailure
+	var nextToken *string
+
+	for {
+		i := &cloudformation.DescribeStackEventsInput{
+			NextToken: nextToken,
+			StackName: aws.String(stackName),
+		}
+
+		o, err := c.client.DescribeStackEvents(i)
+		if IsStackNotFound(err) {
+			return StackFailure{}, microerror.Maskf(stackNotFoundError, "stack name '%s'", stackName)
+		} else if err != nil {
+			return StackFailure{}, microerror.Mask(err)
+		}
+
+		for _, e := range o.StackEvents {
+			if isOperationStart(stackName, e) {
+				if failure == nil {
+					return StackFailure{}, microerror.Maskf(failedResourceNotFoundError, "stack name '%s'", stackName)
+				}
+
+				return *failure, nil
+			}
+
+			if strings.HasSuffix(aws.StringValue(e.ResourceStatus), "_FAILED") && aws.StringValue(e.LogicalResourceId) != stackName {
+				failure = &StackFailure{
+					LogicalResourceID:    aws.StringValue(e.LogicalResourceId),
+					ResourceStatus:       aws.StringValue(e.ResourceStatus),
+					ResourceStatusReason: aws.StringValue(e.ResourceStatusReason),
+					ResourceType:         aws.StringValue(e.ResourceType),
+				}
+			}
+		}
+
+		if o.NextToken == nil {
+			break
+		}
+		nextToken = o.NextToken
+	}
+
+	if failure == nil {
+		return StackFailure{}, microerror.Maskf(failedResourceNotFoundError, "stack name '%s'", stackName)
+	}
+
+	return *failure, nil
+}
+
 func (c *CloudFormation) GetOutputValue(outputs []Output, key string) (string, error) {
 	for _, o := range outputs {
 		if o.OutputKey == key {
@@ -93,6 +151,40 @@ func (c *CloudFormation) GetOutputValue(outputs []Output, key string) (string, e
 	return "", microerror.Maskf(outputNotFoundError, "stack output value for key '%s'", key)
 }
 
+// IsFailedStackStatus returns true for stack statuses which indicate that the
+// last operation on the stack failed, regardless if the stack could be rolled
+// back or not.
+func IsFailedStackStatus(status string) bool {
+	switch status {
+	case cloudformation.StackStatusCreateFailed,
+		cloudformation.StackStatusDeleteFailed,
+		cloudformation.StackStatusRollbackComplete,
+		cloudformation.StackStatusRollbackFailed,
+		cloudformation.StackStatusUpdateRollbackComplete,
+		cloudformation.StackStatusUpdateRollbackFailed:
+		return true
+	}
+
+	return false
+}
+
+// isOperationStart returns true for the stack event marking the start of a
+// stack operation, e.g. the stack's own UPDATE_IN_PROGRESS event.
+func isOperationStart(stackName string, e *cloudformation.StackEvent) bool {
+	if aws.StringValue(e.LogicalResourceId) != stackName {
+		return false
+	}
+
+	switch aws.StringValue(e.ResourceStatus) {
+	case cloudformation.ResourceStatusCreateInProgress,
+		cloudformation.ResourceStatusDeleteInProgress,
+		cloudformation.ResourceStatusUpdateInProgress:
+		return true
+	}
+
+	return false
+}
+
 func toOutputs(outputs []*cloudformation.Output) []Output {
 	var newOutputs []Output
 
@@ -0,0 +1,120 @@
+package cloudformation
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+)
+
+type cfMock struct {
+	stackEvents []*cloudformation.StackEvent
+}
+
+func (c *cfMock) DescribeStackEvents(input *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error) {
+	o := &cloudformation.DescribeStackEventsOutput{
+		StackEvents: c.stackEvents,
+	}
+
+	return o, nil
+}
+
+func (c *cfMock) DescribeStacks(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error) {
+	return &cloudformation.DescribeStacksOutput{}, nil
+}
+
+func newStackEvent(logicalID, resourceType, status, reason string) *cloudformation.StackEvent {
+	return &cloudformation.StackEvent{
+		LogicalResourceId:    aws.String(logicalID),
+		ResourceStatus:       aws.String(status),
+		ResourceStatusReason: aws.String(reason),
+		ResourceType:         aws.String(resourceType),
+	}
+}
+
+func Test_CloudFormation_DescribeFailure(t *testing.T) {
+	stackName := "cluster-al9qy-guest-main"
+
+	testCases := []struct {
+		name            string
+		stackEvents     []*cloudformation.StackEvent
+		expectedFailure StackFailure
+		errorMatcher    func(error) bool
+	}{
+		{
+			name: "case 0: failed create, root cause is the oldest failure",
+			stackEvents: []*cloudformation.StackEvent{
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "ROLLBACK_COMPLETE", ""),
+				newStackEvent("IAMManager", "AWS::IAM::Role", "DELETE_COMPLETE", ""),
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "ROLLBACK_IN_PROGRESS", "The following resource(s) failed to create: [MasterInstance0]."),
+				newStackEvent("IAMManager", "AWS::IAM::Role", "CREATE_FAILED", "Resource creation cancelled"),
+				newStackEvent("MasterInstance0", "AWS::EC2::Instance", "CREATE_FAILED", "The requested configuration is currently not supported."),
+				newStackEvent("IAMManager", "AWS::IAM::Role", "CREATE_IN_PROGRESS", ""),
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "CREATE_IN_PROGRESS", "User Initiated"),
+			},
+			expectedFailure: StackFailure{
+				LogicalResourceID:    "MasterInstance0",
+				ResourceStatus:       "CREATE_FAILED",
+				ResourceStatusReason: "The requested configuration is currently not supported.",
+				ResourceType:         "AWS::EC2::Instance",
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: failures of earlier operations are ignored",
+			stackEvents: []*cloudformation.StackEvent{
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "UPDATE_ROLLBACK_COMPLETE", ""),
+				newStackEvent("WorkerLaunchTemplate", "AWS::EC2::LaunchTemplate", "UPDATE_FAILED", "Invalid instance type."),
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "UPDATE_IN_PROGRESS", "User Initiated"),
+				newStackEvent("MasterInstance0", "AWS::EC2::Instance", "UPDATE_FAILED", "Old failure."),
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "UPDATE_IN_PROGRESS", "User Initiated"),
+			},
+			expectedFailure: StackFailure{
+				LogicalResourceID:    "WorkerLaunchTemplate",
+				ResourceStatus:       "UPDATE_FAILED",
+				ResourceStatusReason: "Invalid instance type.",
+				ResourceType:         "AWS::EC2::LaunchTemplate",
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: no failed resource in last operation",
+			stackEvents: []*cloudformation.StackEvent{
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "UPDATE_COMPLETE", ""),
+				newStackEvent(stackName, "AWS::CloudFormation::Stack", "UPDATE_IN_PROGRESS", "User Initiated"),
+				newStackEvent("MasterInstance0", "AWS::EC2::Instance", "UPDATE_FAILED", "Old failure."),
+			},
+			errorMatcher: IsFailedResourceNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Config{
+				Client: &cfMock{stackEvents: tc.stackEvents},
+			}
+
+			cf, err := New(c)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			failure, err := cf.DescribeFailure(stackName)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if failure != tc.expectedFailure {
+				t.Fatalf("expected %#v got %#v", tc.expectedFailure, failure)
+			}
+		})
+	}
+}
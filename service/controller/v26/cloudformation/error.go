@@ -6,6 +6,15 @@ import (
 	"github.com/giantswarm/microerror"
 )
 
+var failedResourceNotFoundError = &microerror.Error{
+	Kind: "failedResourceNotFoundError",
+}
+
+// IsFailedResourceNotFound asserts failedResourceNotFoundError.
+func IsFailedResourceNotFound(err error) bool {
+	return microerror.Cause(err) == failedResourceNotFoundError
+}
+
 var invalidConfigError = &microerror.Error{
 	Kind: "invalidConfigError",
 }
@@ -6,5 +6,6 @@ import "github.com/aws/aws-sdk-go/service/cloudformation"
 // *CloudFormation struct from
 // "github.com/aws/aws-sdk-go/service/cloudformation" fulfils this interface.
 type CF interface {
+	DescribeStackEvents(input *cloudformation.DescribeStackEventsInput) (*cloudformation.DescribeStackEventsOutput, error)
 	DescribeStacks(input *cloudformation.DescribeStacksInput) (*cloudformation.DescribeStacksOutput, error)
 }
@@ -4,3 +4,12 @@ type Output struct {
 	OutputKey   string
 	OutputValue string
 }
+
+// StackFailure describes the resource which caused the last operation on a
+// stack to fail.
+type StackFailure struct {
+	LogicalResourceID    string
+	ResourceStatus       string
+	ResourceStatusReason string
+	ResourceType         string
+}
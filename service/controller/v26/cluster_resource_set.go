@@ -11,14 +11,17 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/controller"
-	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
-	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
 	"github.com/giantswarm/randomkeys"
 	"github.com/giantswarm/statusresource"
 	"github.com/giantswarm/tenantcluster"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+	"github.com/giantswarm/aws-operator/service/controller/core"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/migration"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/namespace"
+	"github.com/giantswarm/aws-operator/service/controller/core/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v26/adapter"
 	"github.com/giantswarm/aws-operator/service/controller/v26/cloudconfig"
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
@@ -27,6 +30,7 @@ import (
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter/kms"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter/vault"
+	"github.com/giantswarm/aws-operator/service/controller/v26/image"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/accountid"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/asgstatus"
@@ -36,21 +40,20 @@ import (
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/ebsvolume"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/encryption"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/endpoints"
+	"github.com/giantswarm/aws-operator/service/controller/v26/resource/existingvpc"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/ipam"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/loadbalancer"
-	"github.com/giantswarm/aws-operator/service/controller/v26/resource/migration"
-	"github.com/giantswarm/aws-operator/service/controller/v26/resource/namespace"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/natgatewayaddresses"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/peerrolearn"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/routetable"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/s3bucket"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/s3object"
-	"github.com/giantswarm/aws-operator/service/controller/v26/resource/service"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/tccp"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/tccpoutputs"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/tccpsubnet"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/vpccidr"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/workerasgname"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 const (
@@ -63,6 +66,7 @@ const (
 type ClusterResourceSetConfig struct {
 	CertsSearcher          certs.Interface
 	ControlPlaneAWSClients aws.Clients
+	EventRecorder          recorder.Interface
 	G8sClient              versioned.Interface
 	HostAWSConfig          aws.Config
 	K8sClient              kubernetes.Interface
@@ -73,14 +77,19 @@ type ClusterResourceSetConfig struct {
 	AdvancedMonitoringEC2      bool
 	APIWhitelist               adapter.APIWhitelist
 	EncrypterBackend           string
+	EtcdBackupExpiration       int
 	GuestAvailabilityZones     []string
 	GuestPrivateSubnetMaskBits int
 	GuestPublicSubnetMaskBits  int
 	GuestSubnetMaskBits        int
 	IncludeTags                bool
 	IgnitionPath               string
+	ImageName                  string
+	ImageOwner                 string
+	ImageResolver              string
 	InstallationName           string
 	IPAMNetworkRange           net.IPNet
+	IPAMNetworkRanges          map[string]net.IPNet
 	DeleteLoggingBucket        bool
 	OIDC                       cloudconfig.OIDCConfig
 	ProjectName                string
@@ -88,13 +97,20 @@ type ClusterResourceSetConfig struct {
 	RouteTables                string
 	PodInfraContainerImage     string
 	RegistryDomain             string
+	RollbackSkipResources      []string
 	SSOPublicKey               string
+	TransitGatewayID           string
 	VaultAddress               string
+	VolumeSnapshotDockerVolume bool
+	VolumeSnapshotRetention    int
 }
 
 func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.ResourceSet, error) {
 	var err error
 
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
 	if config.G8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
 	}
@@ -173,10 +189,50 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		}
 	}
 
+	var imageResolver image.Interface
+	{
+		var r image.Interface
+		switch config.ImageResolver {
+		case image.StaticResolver:
+			c := image.StaticConfig{}
+
+			r, err = image.NewStatic(c)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		case image.FilterResolver:
+			c := image.FilterConfig{
+				Logger: config.Logger,
+
+				Name:  config.ImageName,
+				Owner: config.ImageOwner,
+			}
+
+			r, err = image.NewFilter(c)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+		default:
+			return nil, microerror.Maskf(invalidConfigError, "unknown image resolver %q", config.ImageResolver)
+		}
+
+		c := image.OverrideConfig{
+			Resolver: r,
+		}
+
+		imageResolver, err = image.NewOverride(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
 	var detectionService *detection.Detection
 	{
 		c := detection.Config{
-			Logger: config.Logger,
+			ImageResolver: imageResolver,
+			Logger:        config.Logger,
+
+			TransitGatewayID: config.TransitGatewayID,
 		}
 
 		detectionService, err = detection.New(c)
@@ -215,6 +271,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := encryption.Config{
 			Encrypter: encrypterObject,
 			Logger:    config.Logger,
+
+			EncrypterBackend: config.EncrypterBackend,
 		}
 
 		encryptionResource, err = encryption.New(c)
@@ -228,6 +286,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := migration.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+
+			Name: "migrationv26",
 		}
 
 		migrationResource, err = migration.New(c)
@@ -236,15 +296,45 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		}
 	}
 
-	var ipamResource controller.Resource
+	var existingVPCResource controller.Resource
 	{
-		c := ipam.Config{
+		c := existingvpc.Config{
+			EventRecorder: config.EventRecorder,
+			G8sClient:     config.G8sClient,
+			Logger:        config.Logger,
+		}
+
+		existingVPCResource, err = existingvpc.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ipamAllocationService ipamallocation.Interface
+	{
+		c := ipamallocation.Config{
 			G8sClient: config.G8sClient,
 			Logger:    config.Logger,
+		}
+
+		ipamAllocationService, err = ipamallocation.New(c)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+	}
+
+	var ipamResource controller.Resource
+	{
+		c := ipam.Config{
+			EventRecorder:  config.EventRecorder,
+			G8sClient:      config.G8sClient,
+			IPAMAllocation: ipamAllocationService,
+			Logger:         config.Logger,
 
 			AllocatedSubnetMaskBits: config.GuestSubnetMaskBits,
 			AvailabilityZones:       config.GuestAvailabilityZones,
 			NetworkRange:            config.IPAMNetworkRange,
+			NetworkRanges:           config.IPAMNetworkRanges,
 		}
 
 		ipamResource, err = ipam.New(c)
@@ -272,10 +362,12 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 	var s3BucketResource controller.Resource
 	{
 		c := s3bucket.Config{
-			Logger: config.Logger,
+			EventRecorder: config.EventRecorder,
+			Logger:        config.Logger,
 
 			AccessLogsExpiration: config.AccessLogsExpiration,
 			DeleteLoggingBucket:  config.DeleteLoggingBucket,
+			EtcdBackupExpiration: config.EtcdBackupExpiration,
 			IncludeTags:          config.IncludeTags,
 			InstallationName:     config.InstallationName,
 		}
@@ -326,7 +418,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 	var ebsVolumeResource controller.Resource
 	{
 		c := ebsvolume.Config{
-			Logger: config.Logger,
+			EventRecorder: config.EventRecorder,
+			Logger:        config.Logger,
 		}
 
 		ebsVolumeResource, err = ebsvolume.New(c)
@@ -340,14 +433,22 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := tccp.Config{
 			APIWhitelist:         config.APIWhitelist,
 			EncrypterRoleManager: encrypterRoleManager,
+			EventRecorder:        config.EventRecorder,
+			G8sClient:            config.G8sClient,
+			ImageResolver:        imageResolver,
 			Logger:               config.Logger,
 
-			Detection:          detectionService,
-			EncrypterBackend:   config.EncrypterBackend,
-			InstallationName:   config.InstallationName,
-			InstanceMonitoring: config.AdvancedMonitoringEC2,
-			PublicRouteTables:  config.RouteTables,
-			Route53Enabled:     config.Route53Enabled,
+			Detection:             detectionService,
+			EncrypterBackend:      config.EncrypterBackend,
+			InstallationName:      config.InstallationName,
+			InstanceMonitoring:    config.AdvancedMonitoringEC2,
+			PublicRouteTables:     config.RouteTables,
+			RollbackSkipResources: config.RollbackSkipResources,
+			Route53Enabled:        config.Route53Enabled,
+			TransitGatewayID:      config.TransitGatewayID,
+
+			VolumeSnapshotDockerVolume: config.VolumeSnapshotDockerVolume,
+			VolumeSnapshotRetention:    config.VolumeSnapshotRetention,
 		}
 
 		tccpResource, err = tccp.New(c)
@@ -359,9 +460,11 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 	var tccpOutputsResource controller.Resource
 	{
 		c := tccpoutputs.Config{
-			Logger: config.Logger,
+			G8sClient: config.G8sClient,
+			Logger:    config.Logger,
 
-			Route53Enabled: config.Route53Enabled,
+			Route53Enabled:   config.Route53Enabled,
+			TransitGatewayID: config.TransitGatewayID,
 		}
 
 		tccpOutputsResource, err = tccpoutputs.New(c)
@@ -390,6 +493,7 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 			EncrypterBackend: config.EncrypterBackend,
 			InstallationName: config.InstallationName,
 			Route53Enabled:   config.Route53Enabled,
+			TransitGatewayID: config.TransitGatewayID,
 		}
 
 		cpfResource, err = cpf.New(c)
@@ -417,6 +521,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := namespace.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "namespacev26",
 		}
 
 		ops, err := namespace.New(c)
@@ -475,6 +581,8 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		c := service.Config{
 			K8sClient: config.K8sClient,
 			Logger:    config.Logger,
+
+			Name: "servicev26",
 		}
 
 		ops, err := service.New(c)
@@ -592,6 +700,7 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		asgStatusResource,
 		statusResource,
 		migrationResource,
+		existingVPCResource,
 		ipamResource,
 		bridgeZoneResource,
 		encryptionResource,
@@ -607,39 +716,6 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 		endpointsResource,
 	}
 
-	{
-		c := retryresource.WrapConfig{
-			Logger: config.Logger,
-		}
-
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	{
-		c := metricsresource.WrapConfig{}
-
-		resources, err = metricsresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	handlesFunc := func(obj interface{}) bool {
-		customObject, err := key.ToCustomObject(obj)
-		if err != nil {
-			return false
-		}
-
-		if key.VersionBundleVersion(customObject) == VersionBundle().Version {
-			return true
-		}
-
-		return false
-	}
-
 	initCtxFunc := func(ctx context.Context, obj interface{}) (context.Context, error) {
 		var tenantClusterAWSClients aws.Clients
 		{
@@ -673,14 +749,14 @@ func NewClusterResourceSet(config ClusterResourceSetConfig) (*controller.Resourc
 
 	var resourceSet *controller.ResourceSet
 	{
-		c := controller.ResourceSetConfig{
-			Handles:   handlesFunc,
+		c := core.ResourceSetConfig{
+			Logger: config.Logger,
+
 			InitCtx:   initCtxFunc,
-			Logger:    config.Logger,
 			Resources: resources,
 		}
 
-		resourceSet, err = controller.NewResourceSet(c)
+		resourceSet, err = core.NewResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -5,8 +5,30 @@ type ContextStatusTenantClusterTCCPASG struct {
 	MaxSize         int
 	MinSize         int
 	Name            string
+	// NodePool is the name of the node pool the ASG belongs to. It is empty for
+	// the unnamed node pool.
+	NodePool string
+
+	DockerVolumeSizeGB   string
+	InstanceType         string
+	InstanceTypes        []string
+	KubeletVolumeSizeGB  string
+	OnDemandBaseCapacity int
+	SpotPercentage       int
 }
 
 func (a ContextStatusTenantClusterTCCPASG) IsEmpty() bool {
 	return a.DesiredCapacity == 0 && a.MaxSize == 0 && a.MinSize == 0
 }
+
+// NodePoolASG returns the ASG of the node pool with the given name. The
+// returned boolean is false in case the node pool's ASG is not known.
+func (t ContextStatusTenantClusterTCCP) NodePoolASG(nodePool string) (ContextStatusTenantClusterTCCPASG, bool) {
+	for _, a := range t.ASGs {
+		if a.NodePool == nodePool {
+			return a, true
+		}
+	}
+
+	return ContextStatusTenantClusterTCCPASG{}, false
+}
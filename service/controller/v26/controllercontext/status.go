@@ -46,32 +46,62 @@ type ContextStatusTenantCluster struct {
 
 type ContextStatusTenantClusterEncryption struct {
 	Key string
+	// VolumeKey is the ARN of the KMS key the worker volumes are encrypted
+	// with. It is empty in case the AWS managed EBS key is used.
+	VolumeKey string
 }
 
 type ContextStatusTenantClusterMasterInstance struct {
+	// AvailabilityZones are the availability zones the masters are placed in,
+	// ordered by master index. It is empty for TCCP stacks which do not yet
+	// provide the information in their outputs.
+	AvailabilityZones        []string
+	DockerVolume             string
 	DockerVolumeResourceName string
+	EtcdRestoreSnapshot      string
+	EtcdVolume               string
 	Image                    string
+	LogVolume                string
 	ResourceName             string
 	Type                     string
 	CloudConfigVersion       string
 }
 
 type ContextStatusTenantClusterTCCP struct {
-	ASG             ContextStatusTenantClusterTCCPASG
+	// ASGs are the worker ASGs of all node pools of the tenant cluster. The
+	// information is gathered from the TCCP stack outputs and the ASGs
+	// themselves.
+	ASGs            []ContextStatusTenantClusterTCCPASG
 	IsTransitioning bool
 	RouteTables     []*ec2.RouteTable
-	Subnets         []*ec2.Subnet
-	VPC             ContextStatusTenantClusterTCCPVPC
+	// StackStatus is the Cloud Formation stack status of the TCCP stack, e.g.
+	// UPDATE_ROLLBACK_FAILED.
+	StackStatus string
+	Subnets     []*ec2.Subnet
+	VPC         ContextStatusTenantClusterTCCPVPC
 }
 
 type ContextStatusTenantClusterTCCPVPC struct {
 	ID                  string
 	PeeringConnectionID string
+	// PrivateRouteTableIDs are the IDs of the route tables associated with the
+	// private subnets of an existing VPC the tenant cluster is deployed into.
+	// They are managed by the existingvpc resource and are empty for VPCs
+	// managed by the operator.
+	PrivateRouteTableIDs []string
+	// TransitGatewayAttachmentID is the ID of the attachment of the tenant
+	// cluster's VPC to the Transit Gateway. It is only set in case the
+	// installation connects tenant clusters via Transit Gateway instead of VPC
+	// peering.
+	TransitGatewayAttachmentID string
 }
 
 type ContextStatusTenantClusterWorkerInstance struct {
-	DockerVolumeSizeGB string
 	CloudConfigVersion string
 	Image              string
-	Type               string
+	// VolumeEncryption is the KMS key the deployed worker volumes are encrypted
+	// with as defined by key.WorkerVolumeEncryptionOutputValue. It is empty in
+	// case the deployed worker volumes are not encrypted.
+	VolumeEncryption string
+	VolumeType       string
 }
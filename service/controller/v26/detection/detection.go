@@ -2,32 +2,52 @@ package detection
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/image"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
 type Config struct {
-	Logger micrologger.Logger
+	ImageResolver image.Interface
+	Logger        micrologger.Logger
+
+	// TransitGatewayID is the ID of the Transit Gateway tenant clusters are
+	// attached to. It is empty in case tenant clusters are peered with the
+	// control plane.
+	TransitGatewayID string
 }
 
 // Detection is a service implementation deciding if a tenant cluster should be
 // updated or scaled.
 type Detection struct {
-	logger micrologger.Logger
+	imageResolver image.Interface
+	logger        micrologger.Logger
+
+	transitGatewayID string
 }
 
 func New(config Config) (*Detection, error) {
+	if config.ImageResolver == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ImageResolver must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
 	d := &Detection{
-		logger: config.Logger,
+		imageResolver: config.ImageResolver,
+		logger:        config.Logger,
+
+		transitGatewayID: config.TransitGatewayID,
 	}
 
 	return d, nil
@@ -36,8 +56,8 @@ func New(config Config) (*Detection, error) {
 // ShouldScale determines whether the reconciled tenant cluster should be
 // scaled. A tenant cluster is only allowed to scale in the following cases.
 //
-//     The tenant cluster's scaling max changes.
-//     The tenant cluster's scaling min changes.
+//     A node pool's scaling max changes.
+//     A node pool's scaling min changes.
 //
 func (d *Detection) ShouldScale(ctx context.Context, cr v1alpha1.AWSConfig) (bool, error) {
 	cc, err := controllercontext.FromContext(ctx)
@@ -45,13 +65,20 @@ func (d *Detection) ShouldScale(ctx context.Context, cr v1alpha1.AWSConfig) (boo
 		return false, microerror.Mask(err)
 	}
 
-	if !cc.Status.TenantCluster.TCCP.ASG.IsEmpty() && cc.Status.TenantCluster.TCCP.ASG.MaxSize != key.ScalingMax(cr) {
-		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should scale due to scaling max changes")
-		return true, nil
-	}
-	if !cc.Status.TenantCluster.TCCP.ASG.IsEmpty() && cc.Status.TenantCluster.TCCP.ASG.MinSize != key.ScalingMin(cr) {
-		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should scale due to scaling min changes")
-		return true, nil
+	for _, p := range key.WorkerNodePools(cr) {
+		asg, ok := cc.Status.TenantCluster.TCCP.NodePoolASG(p.Name)
+		if !ok || asg.IsEmpty() {
+			continue
+		}
+
+		if asg.MaxSize != p.Scaling.Max {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should scale due to scaling max changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if asg.MinSize != p.Scaling.Min {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should scale due to scaling min changes of node pool %#q", p.Name))
+			return true, nil
+		}
 	}
 
 	return false, nil
@@ -60,10 +87,20 @@ func (d *Detection) ShouldScale(ctx context.Context, cr v1alpha1.AWSConfig) (boo
 // ShouldUpdate determines whether the reconciled tenant cluster should be
 // updated. A tenant cluster is only allowed to update in the following cases.
 //
+//     A restore of an etcd snapshot is requested.
+//     An availability zone is added.
 //     The master node's instance type changes.
-//     The worker node's docker volume size changes.
-//     The worker node's instance type changes.
+//     The EC2 AMI of the master or worker nodes changes.
+//     A master volume's size, type, IOPS or throughput changes.
+//     A node pool is added or removed.
+//     A node pool's docker volume size changes.
+//     A node pool's kubelet volume size changes.
+//     A node pool's instance type changes.
+//     A node pool's instance distribution changes.
+//     The worker volume type changes.
+//     The worker volume encryption changes.
 //     The tenant cluster's version changes.
+//     The tenant cluster is peered but a Transit Gateway is configured.
 //
 func (d *Detection) ShouldUpdate(ctx context.Context, cr v1alpha1.AWSConfig) (bool, error) {
 	cc, err := controllercontext.FromContext(ctx)
@@ -71,22 +108,111 @@ func (d *Detection) ShouldUpdate(ctx context.Context, cr v1alpha1.AWSConfig) (bo
 		return false, microerror.Mask(err)
 	}
 
+	if key.EtcdRestoreSnapshot(cr) != "" && cc.Status.TenantCluster.MasterInstance.EtcdRestoreSnapshot != key.EtcdRestoreSnapshot(cr) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to the requested restore of etcd snapshot %#q", key.EtcdRestoreSnapshot(cr)))
+		return true, nil
+	}
+	if len(cc.Status.TenantCluster.TCCP.Subnets) > 0 {
+		for _, az := range key.StatusAvailabilityZones(cr) {
+			if !hasSubnetInAZ(cc.Status.TenantCluster.TCCP.Subnets, az.Name) {
+				d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to the addition of availability zone %#q", az.Name))
+				return true, nil
+			}
+		}
+	}
 	if cc.Status.TenantCluster.MasterInstance.Type != key.MasterInstanceType(cr) {
 		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to master instance type changes")
 		return true, nil
 	}
-	if cc.Status.TenantCluster.WorkerInstance.DockerVolumeSizeGB != key.WorkerDockerVolumeSizeGB(cr) {
-		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to worker instance docker volume size changes")
+	{
+		imageID, err := d.imageResolver.ImageID(ctx, cr)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		if cc.Status.TenantCluster.MasterInstance.Image != imageID {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to master image changes to %#q", imageID))
+			return true, nil
+		}
+		if cc.Status.TenantCluster.WorkerInstance.Image != imageID {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to worker image changes to %#q", imageID))
+			return true, nil
+		}
+	}
+	if cc.Status.TenantCluster.MasterInstance.DockerVolume != key.VolumeOutputValue(key.MasterDockerVolume(cr)) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to master docker volume changes")
+		return true, nil
+	}
+	if cc.Status.TenantCluster.MasterInstance.EtcdVolume != key.VolumeOutputValue(key.MasterEtcdVolume(cr)) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to master etcd volume changes")
 		return true, nil
 	}
-	if cc.Status.TenantCluster.WorkerInstance.Type != key.WorkerInstanceType(cr) {
-		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to worker instance type changes")
+	if cc.Status.TenantCluster.MasterInstance.LogVolume != key.VolumeOutputValue(key.MasterLogVolume(cr)) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to master log volume changes")
+		return true, nil
+	}
+	if len(cc.Status.TenantCluster.TCCP.ASGs) != len(key.WorkerNodePools(cr)) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to node pool changes")
+		return true, nil
+	}
+	for _, p := range key.WorkerNodePools(cr) {
+		asg, ok := cc.Status.TenantCluster.TCCP.NodePoolASG(p.Name)
+		if !ok {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to the addition of node pool %#q", p.Name))
+			return true, nil
+		}
+
+		if asg.DockerVolumeSizeGB != key.NodePoolDockerVolumeSizeGB(p) {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to docker volume size changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if asg.KubeletVolumeSizeGB != key.NodePoolKubeletVolumeSizeGB(p) {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to kubelet volume size changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if asg.InstanceType != p.InstanceType {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to instance type changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if !reflect.DeepEqual(asg.InstanceTypes, key.NodePoolInstanceTypes(p)) {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to instance types changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if asg.OnDemandBaseCapacity != p.InstanceDistribution.OnDemandBaseCapacity {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to on-demand base capacity changes of node pool %#q", p.Name))
+			return true, nil
+		}
+		if asg.SpotPercentage != p.InstanceDistribution.SpotPercentage {
+			d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to spot percentage changes of node pool %#q", p.Name))
+			return true, nil
+		}
+	}
+	if cc.Status.TenantCluster.WorkerInstance.VolumeType != key.WorkerVolumeType(cr) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to worker volume type changes")
+		return true, nil
+	}
+	if cc.Status.TenantCluster.WorkerInstance.VolumeEncryption != key.WorkerVolumeEncryptionOutputValue(cc.Status.TenantCluster.Encryption.VolumeKey) {
+		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to worker volume encryption changes")
 		return true, nil
 	}
 	if cc.Status.TenantCluster.VersionBundleVersion != key.VersionBundleVersion(cr) {
 		d.logger.LogCtx(ctx, "level", "debug", "message", "detected the tenant cluster should update due to version bundle version changes")
 		return true, nil
 	}
+	if d.transitGatewayID != "" && cc.Status.TenantCluster.TCCP.VPC.TransitGatewayAttachmentID == "" {
+		d.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("detected the tenant cluster should update due to the migration from VPC peering to Transit Gateway %#q", d.transitGatewayID))
+		return true, nil
+	}
 
 	return false, nil
 }
+
+func hasSubnetInAZ(subnets []*ec2.Subnet, az string) bool {
+	for _, s := range subnets {
+		if aws.StringValue(s.AvailabilityZone) == az {
+			return true
+		}
+	}
+
+	return false
+}
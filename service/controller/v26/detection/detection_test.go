@@ -0,0 +1,288 @@
+package detection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/image"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+func Test_Detection_ShouldUpdate_Image(t *testing.T) {
+	cr := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Region: "eu-central-1",
+			},
+		},
+	}
+
+	resolver, err := image.NewStatic(image.StaticConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	imageID, err := resolver.ImageID(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name           string
+		masterImage    string
+		workerImage    string
+		expectedUpdate bool
+	}{
+		{
+			name:           "case 0: deployed images match the resolved image",
+			masterImage:    imageID,
+			workerImage:    imageID,
+			expectedUpdate: false,
+		},
+		{
+			name:           "case 1: deployed master image differs from the resolved image",
+			masterImage:    "ami-0123456789abcdef0",
+			workerImage:    imageID,
+			expectedUpdate: true,
+		},
+		{
+			name:           "case 2: deployed worker image differs from the resolved image",
+			masterImage:    imageID,
+			workerImage:    "ami-0123456789abcdef0",
+			expectedUpdate: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d *Detection
+			{
+				c := Config{
+					ImageResolver: resolver,
+					Logger:        microloggertest.New(),
+				}
+
+				d, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			// The controller context reflects the deployed state of the tenant
+			// cluster, which matches the CR apart from the EC2 AMIs.
+			cc := controllercontext.Context{}
+			cc.Status.TenantCluster.MasterInstance.DockerVolume = key.VolumeOutputValue(key.MasterDockerVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.EtcdVolume = key.VolumeOutputValue(key.MasterEtcdVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.Image = tc.masterImage
+			cc.Status.TenantCluster.MasterInstance.LogVolume = key.VolumeOutputValue(key.MasterLogVolume(cr))
+			cc.Status.TenantCluster.TCCP.ASGs = []controllercontext.ContextStatusTenantClusterTCCPASG{
+				{
+					DockerVolumeSizeGB:  key.NodePoolDockerVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+					KubeletVolumeSizeGB: key.NodePoolKubeletVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+				},
+			}
+			cc.Status.TenantCluster.WorkerInstance.Image = tc.workerImage
+			cc.Status.TenantCluster.WorkerInstance.VolumeEncryption = key.WorkerVolumeEncryptionOutputValue("")
+			cc.Status.TenantCluster.WorkerInstance.VolumeType = key.WorkerVolumeType(cr)
+
+			ctx := controllercontext.NewContext(context.Background(), cc)
+
+			update, err := d.ShouldUpdate(ctx, cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if update != tc.expectedUpdate {
+				t.Fatalf("expected %t got %t", tc.expectedUpdate, update)
+			}
+		})
+	}
+}
+
+func Test_Detection_ShouldUpdate_TransitGateway(t *testing.T) {
+	cr := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Region: "eu-central-1",
+			},
+		},
+	}
+
+	resolver, err := image.NewStatic(image.StaticConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	imageID, err := resolver.ImageID(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name             string
+		transitGatewayID string
+		attachmentID     string
+		expectedUpdate   bool
+	}{
+		{
+			name:             "case 0: peered tenant cluster without Transit Gateway",
+			transitGatewayID: "",
+			attachmentID:     "",
+			expectedUpdate:   false,
+		},
+		{
+			name:             "case 1: attached tenant cluster with Transit Gateway",
+			transitGatewayID: "tgw-0123456789abcdef0",
+			attachmentID:     "tgw-attach-0123456789abcdef0",
+			expectedUpdate:   false,
+		},
+		{
+			name:             "case 2: peered tenant cluster with Transit Gateway",
+			transitGatewayID: "tgw-0123456789abcdef0",
+			attachmentID:     "",
+			expectedUpdate:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d *Detection
+			{
+				c := Config{
+					ImageResolver: resolver,
+					Logger:        microloggertest.New(),
+
+					TransitGatewayID: tc.transitGatewayID,
+				}
+
+				d, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cc := controllercontext.Context{}
+			cc.Status.TenantCluster.MasterInstance.DockerVolume = key.VolumeOutputValue(key.MasterDockerVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.EtcdVolume = key.VolumeOutputValue(key.MasterEtcdVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.Image = imageID
+			cc.Status.TenantCluster.MasterInstance.LogVolume = key.VolumeOutputValue(key.MasterLogVolume(cr))
+			cc.Status.TenantCluster.TCCP.ASGs = []controllercontext.ContextStatusTenantClusterTCCPASG{
+				{
+					DockerVolumeSizeGB:  key.NodePoolDockerVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+					KubeletVolumeSizeGB: key.NodePoolKubeletVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+				},
+			}
+			cc.Status.TenantCluster.TCCP.VPC.TransitGatewayAttachmentID = tc.attachmentID
+			cc.Status.TenantCluster.WorkerInstance.Image = imageID
+			cc.Status.TenantCluster.WorkerInstance.VolumeEncryption = key.WorkerVolumeEncryptionOutputValue("")
+			cc.Status.TenantCluster.WorkerInstance.VolumeType = key.WorkerVolumeType(cr)
+
+			ctx := controllercontext.NewContext(context.Background(), cc)
+
+			update, err := d.ShouldUpdate(ctx, cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if update != tc.expectedUpdate {
+				t.Fatalf("expected %t got %t", tc.expectedUpdate, update)
+			}
+		})
+	}
+}
+
+func Test_Detection_ShouldUpdate_VolumeEncryption(t *testing.T) {
+	cr := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Region: "eu-central-1",
+			},
+		},
+	}
+
+	resolver, err := image.NewStatic(image.StaticConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	imageID, err := resolver.ImageID(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyARN := "arn:aws:kms:eu-central-1:000000000000:key/abc"
+
+	testCases := []struct {
+		name             string
+		volumeKey        string
+		volumeEncryption string
+		expectedUpdate   bool
+	}{
+		{
+			name:             "case 0: worker volumes are encrypted with the AWS managed EBS key",
+			volumeKey:        "",
+			volumeEncryption: key.AWSManagedEBSKeyAlias,
+			expectedUpdate:   false,
+		},
+		{
+			name:             "case 1: worker volumes are encrypted with the KMS key",
+			volumeKey:        keyARN,
+			volumeEncryption: keyARN,
+			expectedUpdate:   false,
+		},
+		{
+			name:             "case 2: deployed worker volumes are not encrypted",
+			volumeKey:        "",
+			volumeEncryption: "",
+			expectedUpdate:   true,
+		},
+		{
+			name:             "case 3: deployed worker volumes are encrypted with another key",
+			volumeKey:        keyARN,
+			volumeEncryption: key.AWSManagedEBSKeyAlias,
+			expectedUpdate:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var d *Detection
+			{
+				c := Config{
+					ImageResolver: resolver,
+					Logger:        microloggertest.New(),
+				}
+
+				d, err = New(c)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cc := controllercontext.Context{}
+			cc.Status.TenantCluster.Encryption.VolumeKey = tc.volumeKey
+			cc.Status.TenantCluster.MasterInstance.DockerVolume = key.VolumeOutputValue(key.MasterDockerVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.EtcdVolume = key.VolumeOutputValue(key.MasterEtcdVolume(cr))
+			cc.Status.TenantCluster.MasterInstance.Image = imageID
+			cc.Status.TenantCluster.MasterInstance.LogVolume = key.VolumeOutputValue(key.MasterLogVolume(cr))
+			cc.Status.TenantCluster.TCCP.ASGs = []controllercontext.ContextStatusTenantClusterTCCPASG{
+				{
+					DockerVolumeSizeGB:  key.NodePoolDockerVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+					KubeletVolumeSizeGB: key.NodePoolKubeletVolumeSizeGB(key.WorkerNodePools(cr)[0]),
+				},
+			}
+			cc.Status.TenantCluster.WorkerInstance.Image = imageID
+			cc.Status.TenantCluster.WorkerInstance.VolumeEncryption = tc.volumeEncryption
+			cc.Status.TenantCluster.WorkerInstance.VolumeType = key.WorkerVolumeType(cr)
+
+			ctx := controllercontext.NewContext(context.Background(), cc)
+
+			update, err := d.ShouldUpdate(ctx, cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if update != tc.expectedUpdate {
+				t.Fatalf("expected %t got %t", tc.expectedUpdate, update)
+			}
+		})
+	}
+}
@@ -7,14 +7,13 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 	"github.com/giantswarm/operatorkit/controller"
-	"github.com/giantswarm/operatorkit/controller/resource/metricsresource"
-	"github.com/giantswarm/operatorkit/controller/resource/retryresource"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+	"github.com/giantswarm/aws-operator/service/controller/core"
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/credential"
-	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/drainer"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/drainfinisher"
 	"github.com/giantswarm/aws-operator/service/controller/v26/resource/tccpoutputs"
@@ -23,6 +22,7 @@ import (
 
 type DrainerResourceSetConfig struct {
 	ControlPlaneAWSClients aws.Clients
+	EventRecorder          recorder.Interface
 	G8sClient              versioned.Interface
 	HostAWSConfig          aws.Config
 	K8sClient              kubernetes.Interface
@@ -38,8 +38,9 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 	var drainerResource controller.Resource
 	{
 		c := drainer.ResourceConfig{
-			G8sClient: config.G8sClient,
-			Logger:    config.Logger,
+			EventRecorder: config.EventRecorder,
+			G8sClient:     config.G8sClient,
+			Logger:        config.Logger,
 		}
 
 		drainerResource, err = drainer.NewResource(c)
@@ -51,8 +52,9 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 	var drainFinisherResource controller.Resource
 	{
 		c := drainfinisher.ResourceConfig{
-			G8sClient: config.G8sClient,
-			Logger:    config.Logger,
+			EventRecorder: config.EventRecorder,
+			G8sClient:     config.G8sClient,
+			Logger:        config.Logger,
 		}
 
 		drainFinisherResource, err = drainfinisher.NewResource(c)
@@ -64,7 +66,8 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 	var tccpOutputsResource controller.Resource
 	{
 		c := tccpoutputs.Config{
-			Logger: config.Logger,
+			G8sClient: config.G8sClient,
+			Logger:    config.Logger,
 
 			Route53Enabled: config.Route53Enabled,
 		}
@@ -95,39 +98,6 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 		drainFinisherResource,
 	}
 
-	{
-		c := retryresource.WrapConfig{
-			Logger: config.Logger,
-		}
-
-		resources, err = retryresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	{
-		c := metricsresource.WrapConfig{}
-
-		resources, err = metricsresource.Wrap(resources, c)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-	}
-
-	handlesFunc := func(obj interface{}) bool {
-		customObject, err := key.ToCustomObject(obj)
-		if err != nil {
-			return false
-		}
-
-		if key.VersionBundleVersion(customObject) == VersionBundle().Version {
-			return true
-		}
-
-		return false
-	}
-
 	initCtxFunc := func(ctx context.Context, obj interface{}) (context.Context, error) {
 		var tenantClusterAWSClients aws.Clients
 		{
@@ -161,14 +131,14 @@ func NewDrainerResourceSet(config DrainerResourceSetConfig) (*controller.Resourc
 
 	var resourceSet *controller.ResourceSet
 	{
-		c := controller.ResourceSetConfig{
-			Handles:   handlesFunc,
+		c := core.ResourceSetConfig{
+			Logger: config.Logger,
+
 			InitCtx:   initCtxFunc,
-			Logger:    config.Logger,
 			Resources: resources,
 		}
 
-		resourceSet, err = controller.NewResourceSet(c)
+		resourceSet, err = core.NewResourceSet(c)
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
@@ -3,6 +3,7 @@ package ebs
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -12,6 +13,7 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/aws-operator/pkg/awstags"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
@@ -45,6 +47,26 @@ func New(config Config) (*EBS, error) {
 	return e, nil
 }
 
+// DeleteSnapshot deletes an EBS snapshot.
+func (e *EBS) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleting EBS snapshot %#q", snapshotID))
+
+	i := &ec2.DeleteSnapshotInput{
+		SnapshotId: aws.String(snapshotID),
+	}
+
+	_, err := e.client.DeleteSnapshot(i)
+	if IsSnapshotNotFound(err) {
+		// Fall through.
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted EBS snapshot %#q", snapshotID))
+
+	return nil
+}
+
 // DeleteVolume deletes an EBS volume with retry logic.
 func (e *EBS) DeleteVolume(ctx context.Context, volumeID string) error {
 	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleting EBS volume %#q", volumeID))
@@ -177,7 +199,9 @@ func (e *EBS) ListVolumes(customObject v1alpha1.AWSConfig, filterFuncs ...func(t
 
 		volume := Volume{
 			VolumeID:    *v.VolumeId,
+			Name:        tagValue(v.Tags, nameTagKey),
 			Attachments: attachments,
+			Tags:        tagMap(v.Tags),
 		}
 
 		volumes = append(volumes, volume)
@@ -185,3 +209,267 @@ func (e *EBS) ListVolumes(customObject v1alpha1.AWSConfig, filterFuncs ...func(t
 
 	return volumes, nil
 }
+
+// ListSnapshots lists the EBS snapshots of the master volumes of a guest
+// cluster created by SnapshotVolume.
+func (e *EBS) ListSnapshots(customObject v1alpha1.AWSConfig) ([]Snapshot, error) {
+	var snapshots []Snapshot
+
+	i := &ec2.DescribeSnapshotsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name: aws.String(fmt.Sprintf("tag:%s", key.ClusterCloudProviderTag(customObject))),
+				Values: []*string{
+					aws.String(cloudProviderClusterTagValue),
+				},
+			},
+			{
+				Name: aws.String("tag-key"),
+				Values: []*string{
+					aws.String(key.MasterVolumeTagName),
+				},
+			},
+		},
+		OwnerIds: []*string{
+			aws.String("self"),
+		},
+	}
+
+	o, err := e.client.DescribeSnapshots(i)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for _, s := range o.Snapshots {
+		snapshot := Snapshot{
+			SnapshotID: aws.StringValue(s.SnapshotId),
+			StartTime:  aws.TimeValue(s.StartTime),
+			State:      aws.StringValue(s.State),
+			VolumeID:   aws.StringValue(s.VolumeId),
+			VolumeName: tagValue(s.Tags, key.MasterVolumeTagName),
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// ListPersistentVolumeSnapshots lists the EBS snapshots of the persistent
+// volumes of a guest cluster created by SnapshotPersistentVolume.
+func (e *EBS) ListPersistentVolumeSnapshots(customObject v1alpha1.AWSConfig) ([]Snapshot, error) {
+	var snapshots []Snapshot
+
+	i := &ec2.DescribeSnapshotsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name: aws.String(fmt.Sprintf("tag:%s", key.OrphanedClusterTagName)),
+				Values: []*string{
+					aws.String(key.ClusterID(customObject)),
+				},
+			},
+		},
+		OwnerIds: []*string{
+			aws.String("self"),
+		},
+	}
+
+	o, err := e.client.DescribeSnapshots(i)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	for _, s := range o.Snapshots {
+		snapshot := Snapshot{
+			SnapshotID: aws.StringValue(s.SnapshotId),
+			StartTime:  aws.TimeValue(s.StartTime),
+			State:      aws.StringValue(s.State),
+			VolumeID:   aws.StringValue(s.VolumeId),
+			VolumeName: tagValue(s.Tags, nameTagKey),
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
+
+// OrphanVolume removes the cluster ownership tag of an EBS volume and tags it
+// as orphaned, so that it outlives the guest cluster.
+func (e *EBS) OrphanVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volumeID string) error {
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("orphaning EBS volume %#q", volumeID))
+
+	{
+		i := &ec2.CreateTagsInput{
+			Resources: []*string{
+				aws.String(volumeID),
+			},
+			Tags: awstags.NewEC2(map[string]string{
+				key.OrphanedClusterTagName: key.ClusterID(customObject),
+			}),
+		}
+
+		_, err := e.client.CreateTags(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	{
+		i := &ec2.DeleteTagsInput{
+			Resources: []*string{
+				aws.String(volumeID),
+			},
+			Tags: []*ec2.Tag{
+				{
+					Key: aws.String(key.ClusterCloudProviderTag(customObject)),
+				},
+			},
+		}
+
+		_, err := e.client.DeleteTags(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("orphaned EBS volume %#q", volumeID))
+
+	return nil
+}
+
+// SnapshotPersistentVolume creates a snapshot of the EBS volume of a
+// persistent volume and returns its ID without waiting for it to complete. The
+// snapshot is tagged as orphaned, so that it outlives the guest cluster.
+func (e *EBS) SnapshotPersistentVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volume Volume) (string, error) {
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("creating snapshot of EBS volume %#q", volume.VolumeID))
+
+	i := &ec2.CreateSnapshotInput{
+		Description: aws.String(fmt.Sprintf("Snapshot of EBS volume %s", volume.VolumeID)),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+				Tags:         awstags.NewEC2(OrphanedTags(customObject, volume.Tags)),
+			},
+		},
+		VolumeId: aws.String(volume.VolumeID),
+	}
+
+	o, err := e.client.CreateSnapshot(i)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	snapshotID := aws.StringValue(o.SnapshotId)
+
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("created snapshot %#q of EBS volume %#q", snapshotID, volume.VolumeID))
+
+	return snapshotID, nil
+}
+
+// SnapshotVolume creates a snapshot of an EBS volume with the given tags and
+// returns its ID without waiting for it to complete. The snapshot is tagged
+// with the name of the volume, so that snapshots of the same master volume can
+// be found across master replacements.
+func (e *EBS) SnapshotVolume(ctx context.Context, volume Volume, tags map[string]string) (string, error) {
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("creating snapshot of EBS volume %#q", volume.VolumeID))
+
+	t := map[string]string{
+		key.MasterVolumeTagName: volume.Name,
+		nameTagKey:              volume.Name,
+	}
+	for k, v := range tags {
+		t[k] = v
+	}
+
+	i := &ec2.CreateSnapshotInput{
+		Description: aws.String(fmt.Sprintf("Snapshot of EBS volume %s", volume.Name)),
+		TagSpecifications: []*ec2.TagSpecification{
+			{
+				ResourceType: aws.String(ec2.ResourceTypeSnapshot),
+				Tags:         awstags.NewEC2(t),
+			},
+		},
+		VolumeId: aws.String(volume.VolumeID),
+	}
+
+	o, err := e.client.CreateSnapshot(i)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+	snapshotID := aws.StringValue(o.SnapshotId)
+
+	e.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("created snapshot %#q of EBS volume %#q", snapshotID, volume.VolumeID))
+
+	return snapshotID, nil
+}
+
+// OutdatedSnapshots returns the snapshots exceeding the given number of
+// snapshots to keep per master volume. The most recent snapshots of each
+// master volume are kept.
+func OutdatedSnapshots(snapshots []Snapshot, keep int) []Snapshot {
+	byVolume := map[string][]Snapshot{}
+	for _, s := range snapshots {
+		byVolume[s.VolumeName] = append(byVolume[s.VolumeName], s)
+	}
+
+	var outdated []Snapshot
+	for _, l := range byVolume {
+		sort.Slice(l, func(i, j int) bool {
+			return l[i].StartTime.After(l[j].StartTime)
+		})
+
+		if len(l) > keep {
+			outdated = append(outdated, l[keep:]...)
+		}
+	}
+
+	sort.Slice(outdated, func(i, j int) bool {
+		return outdated[i].SnapshotID < outdated[j].SnapshotID
+	})
+
+	return outdated
+}
+
+// OrphanedTags returns the given tags of an EBS volume with the cluster
+// ownership tag replaced by the orphaned tag. The tags of persistent volumes,
+// e.g. the name and namespace of their persistent volume claims, are
+// preserved.
+func OrphanedTags(customObject v1alpha1.AWSConfig, tags map[string]string) map[string]string {
+	orphaned := map[string]string{
+		key.OrphanedClusterTagName: key.ClusterID(customObject),
+	}
+	for k, v := range tags {
+		if k == key.ClusterCloudProviderTag(customObject) {
+			continue
+		}
+		orphaned[k] = v
+	}
+
+	return orphaned
+}
+
+// IsPersistentVolume returns true in case the EBS volume was created for a
+// Kubernetes persistent volume.
+func IsPersistentVolume(volume Volume) bool {
+	_, ok := volume.Tags[cloudProviderPersistentVolumeTagKey]
+	return ok
+}
+
+func tagMap(tags []*ec2.Tag) map[string]string {
+	m := map[string]string{}
+	for _, t := range tags {
+		m[aws.StringValue(t.Key)] = aws.StringValue(t.Value)
+	}
+
+	return m
+}
+
+func tagValue(tags []*ec2.Tag, k string) string {
+	for _, t := range tags {
+		if aws.StringValue(t.Key) == k {
+			return aws.StringValue(t.Value)
+		}
+	}
+
+	return ""
+}
@@ -3,6 +3,7 @@ package ebs
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -48,6 +49,10 @@ func Test_ListVolumes(t *testing.T) {
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-1234",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-1234",
+					},
 				},
 			},
 			ebsVolumes: []ebsVolumeMock{
@@ -77,14 +82,27 @@ func Test_ListVolumes(t *testing.T) {
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-1234",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-1234",
+					},
 				},
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-5678",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-5678",
+					},
 				},
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-6789",
+					Name:        "test-cluster-etcd",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"Name":                               "test-cluster-etcd",
+					},
 				},
 			},
 			ebsVolumes: []ebsVolumeMock{
@@ -189,6 +207,10 @@ func Test_ListVolumes(t *testing.T) {
 						},
 					},
 					VolumeID: "vol-1234",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-1234",
+					},
 				},
 				{
 					Attachments: []VolumeAttachment{
@@ -198,6 +220,10 @@ func Test_ListVolumes(t *testing.T) {
 						},
 					},
 					VolumeID: "vol-5678",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-5678",
+					},
 				},
 			},
 			ebsVolumes: []ebsVolumeMock{
@@ -251,6 +277,11 @@ func Test_ListVolumes(t *testing.T) {
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-6789",
+					Name:        "test-cluster-etcd",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"Name":                               "test-cluster-etcd",
+					},
 				},
 			},
 			ebsVolumes: []ebsVolumeMock{
@@ -292,6 +323,10 @@ func Test_ListVolumes(t *testing.T) {
 				{
 					Attachments: []VolumeAttachment{},
 					VolumeID:    "vol-1234",
+					Tags: map[string]string{
+						"kubernetes.io/cluster/test-cluster": "owned",
+						"kubernetes.io/created-for/pv/name":  "pvc-1234",
+					},
 				},
 			},
 			ebsVolumes: []ebsVolumeMock{
@@ -350,3 +385,89 @@ func Test_ListVolumes(t *testing.T) {
 		})
 	}
 }
+
+func Test_OutdatedSnapshots(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	snapshots := []Snapshot{
+		{SnapshotID: "snap-1", StartTime: now.Add(-3 * time.Hour), VolumeName: "test-cluster-etcd"},
+		{SnapshotID: "snap-2", StartTime: now.Add(-1 * time.Hour), VolumeName: "test-cluster-etcd"},
+		{SnapshotID: "snap-3", StartTime: now.Add(-2 * time.Hour), VolumeName: "test-cluster-etcd"},
+		{SnapshotID: "snap-4", StartTime: now.Add(-4 * time.Hour), VolumeName: "test-cluster-docker"},
+		{SnapshotID: "snap-5", StartTime: now.Add(-1 * time.Hour), VolumeName: "test-cluster-docker"},
+	}
+
+	testCases := []struct {
+		description       string
+		keep              int
+		expectedSnapshots []Snapshot
+	}{
+		{
+			description: "case 0: keep more snapshots than there are",
+			keep:        3,
+		},
+		{
+			description: "case 1: keep the two most recent snapshots per volume",
+			keep:        2,
+			expectedSnapshots: []Snapshot{
+				{SnapshotID: "snap-1", StartTime: now.Add(-3 * time.Hour), VolumeName: "test-cluster-etcd"},
+			},
+		},
+		{
+			description: "case 2: keep the most recent snapshot per volume",
+			keep:        1,
+			expectedSnapshots: []Snapshot{
+				{SnapshotID: "snap-1", StartTime: now.Add(-3 * time.Hour), VolumeName: "test-cluster-etcd"},
+				{SnapshotID: "snap-3", StartTime: now.Add(-2 * time.Hour), VolumeName: "test-cluster-etcd"},
+				{SnapshotID: "snap-4", StartTime: now.Add(-4 * time.Hour), VolumeName: "test-cluster-docker"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			input := make([]Snapshot, len(snapshots))
+			copy(input, snapshots)
+
+			result := OutdatedSnapshots(input, tc.keep)
+
+			if !reflect.DeepEqual(result, tc.expectedSnapshots) {
+				t.Fatalf("expected snapshots '%#v', got '%#v'", tc.expectedSnapshots, result)
+			}
+		})
+	}
+}
+
+func Test_OrphanedTags(t *testing.T) {
+	t.Parallel()
+
+	customObject := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "test-cluster",
+			},
+		},
+	}
+
+	tags := map[string]string{
+		"kubernetes.io/cluster/test-cluster":      "owned",
+		"kubernetes.io/created-for/pv/name":       "pvc-1234",
+		"kubernetes.io/created-for/pvc/name":      "data",
+		"kubernetes.io/created-for/pvc/namespace": "default",
+	}
+
+	expectedTags := map[string]string{
+		"giantswarm.io/orphaned-cluster":          "test-cluster",
+		"kubernetes.io/created-for/pv/name":       "pvc-1234",
+		"kubernetes.io/created-for/pvc/name":      "data",
+		"kubernetes.io/created-for/pvc/namespace": "default",
+	}
+
+	result := OrphanedTags(customObject, tags)
+
+	if !reflect.DeepEqual(result, expectedTags) {
+		t.Fatalf("expected tags '%#v', got '%#v'", expectedTags, result)
+	}
+}
@@ -14,6 +14,20 @@ func IsInvalidConfig(err error) bool {
 	return microerror.Cause(err) == invalidConfigError
 }
 
+// IsSnapshotNotFound asserts snapshot not found error from upstream's API
+// code.
+func IsSnapshotNotFound(err error) bool {
+	aerr, ok := microerror.Cause(err).(awserr.Error)
+	if !ok {
+		return false
+	}
+	if aerr.Code() == "InvalidSnapshot.NotFound" {
+		return true
+	}
+
+	return false
+}
+
 var volumeNotFoundError = &microerror.Error{
 	Kind: "volumeNotFoundError",
 }
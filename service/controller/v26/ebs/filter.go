@@ -26,8 +26,13 @@ func IsFiltered(vol *ec2.Volume, filterFuncs []func(t *ec2.Tag) bool) bool {
 
 func NewDockerVolumeFilter(customObject v1alpha1.AWSConfig) func(t *ec2.Tag) bool {
 	return func(t *ec2.Tag) bool {
-		if *t.Key == nameTagKey && *t.Value == key.DockerVolumeName(customObject) {
-			return true
+		if *t.Key != nameTagKey {
+			return false
+		}
+		for i := 0; i < key.MasterReplicas(customObject); i++ {
+			if *t.Value == key.DockerVolumeName(customObject, i) {
+				return true
+			}
 		}
 		return false
 	}
@@ -35,8 +40,13 @@ func NewDockerVolumeFilter(customObject v1alpha1.AWSConfig) func(t *ec2.Tag) boo
 
 func NewEtcdVolumeFilter(customObject v1alpha1.AWSConfig) func(t *ec2.Tag) bool {
 	return func(t *ec2.Tag) bool {
-		if *t.Key == nameTagKey && *t.Value == key.EtcdVolumeName(customObject) {
-			return true
+		if *t.Key != nameTagKey {
+			return false
+		}
+		for i := 0; i < key.MasterReplicas(customObject); i++ {
+			if *t.Value == key.EtcdVolumeName(customObject, i) {
+				return true
+			}
 		}
 		return false
 	}
@@ -2,6 +2,7 @@ package ebs
 
 import (
 	"context"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
@@ -9,6 +10,8 @@ import (
 
 // Interface describes the methods provided by the helm client.
 type Interface interface {
+	// DeleteSnapshot deletes an EBS snapshot.
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
 	// DeleteVolume deletes an EBS volume with retry logic.
 	DeleteVolume(ctx context.Context, volumeID string) error
 	// DetachVolume detaches an EBS volume. If force is specified data loss may
@@ -19,21 +22,55 @@ type Interface interface {
 	// persistentVolume is true then any Persistent Volumes associated with the
 	// cluster will be returned.
 	ListVolumes(customObject v1alpha1.AWSConfig, filterFuncs ...func(t *ec2.Tag) bool) ([]Volume, error)
+	// ListSnapshots lists the EBS snapshots of the master volumes of a guest
+	// cluster created by SnapshotVolume.
+	ListSnapshots(customObject v1alpha1.AWSConfig) ([]Snapshot, error)
+	// ListPersistentVolumeSnapshots lists the EBS snapshots of the persistent
+	// volumes of a guest cluster created by SnapshotPersistentVolume.
+	ListPersistentVolumeSnapshots(customObject v1alpha1.AWSConfig) ([]Snapshot, error)
+	// OrphanVolume removes the cluster ownership tag of an EBS volume and tags
+	// it as orphaned, so that it outlives the guest cluster.
+	OrphanVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volumeID string) error
+	// SnapshotPersistentVolume creates a snapshot of the EBS volume of a
+	// persistent volume and returns its ID without waiting for it to complete.
+	// The snapshot is tagged as orphaned, so that it outlives the guest cluster.
+	SnapshotPersistentVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volume Volume) (string, error)
+	// SnapshotVolume creates a snapshot of an EBS volume with the given tags
+	// and returns its ID without waiting for it to complete.
+	SnapshotVolume(ctx context.Context, volume Volume, tags map[string]string) (string, error)
 }
 
 // EC2Client describes the methods required to be implemented by an EC2 AWS client.
 type EC2Client interface {
+	CreateSnapshot(*ec2.CreateSnapshotInput) (*ec2.Snapshot, error)
+	CreateTags(*ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
+	DeleteSnapshot(*ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error)
+	DeleteTags(*ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
 	DeleteVolume(*ec2.DeleteVolumeInput) (*ec2.DeleteVolumeOutput, error)
+	DescribeSnapshots(*ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error)
 	DescribeVolumes(*ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error)
 	DetachVolume(*ec2.DetachVolumeInput) (*ec2.VolumeAttachment, error)
 	StopInstances(*ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error)
 	WaitUntilInstanceStopped(*ec2.DescribeInstancesInput) error
 }
 
+// Snapshot is an EBS snapshot of a master volume.
+type Snapshot struct {
+	SnapshotID string
+	StartTime  time.Time
+	// State is the state of the snapshot, which is one of pending, completed
+	// or error.
+	State      string
+	VolumeID   string
+	VolumeName string
+}
+
 // Volume is an EBS volume and its attachments.
 type Volume struct {
 	VolumeID    string
+	Name        string
 	Attachments []VolumeAttachment
+	Tags        map[string]string
 }
 
 // VolumeAttachment is an EBS volume attached to an EC2 instance.
@@ -0,0 +1,21 @@
+package image
+
+import "github.com/giantswarm/microerror"
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var imageNotFoundError = &microerror.Error{
+	Kind: "imageNotFoundError",
+}
+
+// IsImageNotFound asserts imageNotFoundError.
+func IsImageNotFound(err error) bool {
+	return microerror.Cause(err) == imageNotFoundError
+}
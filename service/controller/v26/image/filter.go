@@ -0,0 +1,153 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+const (
+	// DefaultCacheTTL is the time a resolved EC2 AMI is cached per region, when
+	// no other cache TTL is configured.
+	DefaultCacheTTL = 1 * time.Hour
+)
+
+type FilterConfig struct {
+	Logger micrologger.Logger
+
+	// CacheTTL is the time a resolved EC2 AMI is cached per region. Defaults to
+	// DefaultCacheTTL.
+	CacheTTL time.Duration
+	// Name is the EC2 AMI name filter, e.g. container-linux-hardened-*.
+	Name string
+	// Owner is the AWS account ID owning the EC2 AMIs.
+	Owner string
+}
+
+// Filter resolves the most recent available EC2 AMI matching the configured
+// owner and name filter. Results are cached per region in order to not query
+// the EC2 API on every reconciliation loop.
+type Filter struct {
+	logger micrologger.Logger
+
+	cache    map[string]filterCacheEntry
+	cacheTTL time.Duration
+	mutex    sync.Mutex
+	name     string
+	owner    string
+}
+
+type filterCacheEntry struct {
+	expires time.Time
+	imageID string
+}
+
+func NewFilter(config FilterConfig) (*Filter, error) {
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	if config.CacheTTL == 0 {
+		config.CacheTTL = DefaultCacheTTL
+	}
+	if config.Name == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Name must not be empty", config)
+	}
+	if config.Owner == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Owner must not be empty", config)
+	}
+
+	f := &Filter{
+		logger: config.Logger,
+
+		cache:    map[string]filterCacheEntry{},
+		cacheTTL: config.CacheTTL,
+		name:     config.Name,
+		owner:    config.Owner,
+	}
+
+	return f, nil
+}
+
+func (f *Filter) ImageID(ctx context.Context, customObject v1alpha1.AWSConfig) (string, error) {
+	region := key.Region(customObject)
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	e, ok := f.cache[region]
+	if ok && time.Now().Before(e.expires) {
+		return e.imageID, nil
+	}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	f.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding EC2 AMI owned by %#q matching %#q in region %#q", f.owner, f.name, region))
+
+	imageID, err := f.searchImageID(cc.Client.TenantCluster.AWS.EC2)
+	if IsImageNotFound(err) {
+		return "", microerror.Maskf(imageNotFoundError, "no image owned by %#q matching %#q in region %#q", f.owner, f.name, region)
+	} else if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	f.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found EC2 AMI %#q", imageID))
+
+	f.cache[region] = filterCacheEntry{
+		expires: time.Now().Add(f.cacheTTL),
+		imageID: imageID,
+	}
+
+	return imageID, nil
+}
+
+// searchImageID returns the most recently created available EC2 AMI matching
+// the configured owner and name filter.
+func (f *Filter) searchImageID(client EC2Client) (string, error) {
+	i := &ec2.DescribeImagesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("name"),
+				Values: []*string{aws.String(f.name)},
+			},
+			{
+				Name:   aws.String("state"),
+				Values: []*string{aws.String(ec2.ImageStateAvailable)},
+			},
+		},
+		Owners: []*string{aws.String(f.owner)},
+	}
+
+	o, err := client.DescribeImages(i)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	// The creation date is formatted as ISO 8601 timestamp and can therefore be
+	// compared lexicographically.
+	var latest *ec2.Image
+	for _, image := range o.Images {
+		if latest == nil || aws.StringValue(image.CreationDate) > aws.StringValue(latest.CreationDate) {
+			latest = image
+		}
+	}
+
+	if latest == nil {
+		return "", microerror.Mask(imageNotFoundError)
+	}
+
+	return aws.StringValue(latest.ImageId), nil
+}
@@ -0,0 +1,171 @@
+package image
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+
+	awsclient "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+)
+
+type ec2ClientMock struct {
+	ec2iface.EC2API
+
+	calls  int
+	images []*ec2.Image
+}
+
+func (m *ec2ClientMock) DescribeImages(*ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	m.calls++
+
+	o := &ec2.DescribeImagesOutput{
+		Images: m.images,
+	}
+
+	return o, nil
+}
+
+func Test_Filter_ImageID(t *testing.T) {
+	testCases := []struct {
+		description     string
+		images          []*ec2.Image
+		errorMatcher    func(error) bool
+		expectedImageID string
+	}{
+		{
+			description: "case 0: single image",
+			images: []*ec2.Image{
+				{
+					CreationDate: aws.String("2019-03-01T10:00:00.000Z"),
+					ImageId:      aws.String("ami-1"),
+				},
+			},
+			expectedImageID: "ami-1",
+		},
+		{
+			description: "case 1: most recent image",
+			images: []*ec2.Image{
+				{
+					CreationDate: aws.String("2019-03-01T10:00:00.000Z"),
+					ImageId:      aws.String("ami-1"),
+				},
+				{
+					CreationDate: aws.String("2019-04-01T10:00:00.000Z"),
+					ImageId:      aws.String("ami-2"),
+				},
+				{
+					CreationDate: aws.String("2019-02-01T10:00:00.000Z"),
+					ImageId:      aws.String("ami-3"),
+				},
+			},
+			expectedImageID: "ami-2",
+		},
+		{
+			description:  "case 2: no image",
+			images:       nil,
+			errorMatcher: IsImageNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			m := &ec2ClientMock{
+				images: tc.images,
+			}
+
+			ctx := controllercontext.NewContext(context.Background(), controllercontext.Context{
+				Client: controllercontext.ContextClient{
+					TenantCluster: controllercontext.ContextClientTenantCluster{
+						AWS: awsclient.Clients{
+							EC2: m,
+						},
+					},
+				},
+			})
+
+			f, err := NewFilter(FilterConfig{
+				Logger: microloggertest.New(),
+				Name:   "container-linux-*",
+				Owner:  "123456789012",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cr := v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Region: "eu-central-1",
+					},
+				},
+			}
+
+			// The second lookup must be served from the cache.
+			for i := 0; i < 2; i++ {
+				imageID, err := f.ImageID(ctx, cr)
+
+				switch {
+				case err == nil && tc.errorMatcher == nil:
+					// correct; carry on
+				case err != nil && tc.errorMatcher == nil:
+					t.Fatalf("error == %#v, want nil", err)
+				case err == nil && tc.errorMatcher != nil:
+					t.Fatalf("error == nil, want non-nil")
+				case !tc.errorMatcher(err):
+					t.Fatalf("error == %#v, want matching", err)
+				}
+
+				if imageID != tc.expectedImageID {
+					t.Fatalf("imageID == %#q, want %#q", imageID, tc.expectedImageID)
+				}
+			}
+
+			if tc.errorMatcher == nil && m.calls != 1 {
+				t.Fatalf("calls == %d, want %d", m.calls, 1)
+			}
+		})
+	}
+}
+
+func Test_Override_ImageID(t *testing.T) {
+	s, err := NewStatic(StaticConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := NewOverride(OverrideConfig{Resolver: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cr := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				Region: "eu-central-1",
+			},
+		},
+	}
+
+	imageID, err := o.ImageID(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imageID != "ami-015e6cb33a709348e" {
+		t.Fatalf("imageID == %#q, want %#q", imageID, "ami-015e6cb33a709348e")
+	}
+
+	cr.Spec.AWS.ImageID = "ami-hardened"
+
+	imageID, err = o.ImageID(context.Background(), cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imageID != "ami-hardened" {
+		t.Fatalf("imageID == %#q, want %#q", imageID, "ami-hardened")
+	}
+}
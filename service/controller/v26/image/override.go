@@ -0,0 +1,48 @@
+package image
+
+import (
+	"context"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+type OverrideConfig struct {
+	// Resolver is used for all tenant clusters which do not explicitly define
+	// their EC2 AMI in the CR spec.
+	Resolver Interface
+}
+
+// Override resolves the EC2 AMI explicitly defined in the CR spec of a tenant
+// cluster and falls back to the configured resolver otherwise.
+type Override struct {
+	resolver Interface
+}
+
+func NewOverride(config OverrideConfig) (*Override, error) {
+	if config.Resolver == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Resolver must not be empty", config)
+	}
+
+	o := &Override{
+		resolver: config.Resolver,
+	}
+
+	return o, nil
+}
+
+func (o *Override) ImageID(ctx context.Context, customObject v1alpha1.AWSConfig) (string, error) {
+	imageID := key.ImageIDOverride(customObject)
+	if imageID != "" {
+		return imageID, nil
+	}
+
+	imageID, err := o.resolver.ImageID(ctx, customObject)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return imageID, nil
+}
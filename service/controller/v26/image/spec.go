@@ -0,0 +1,26 @@
+package image
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
+const (
+	FilterResolver = "filter"
+	StaticResolver = "static"
+)
+
+// Interface describes the methods provided by an image resolver.
+type Interface interface {
+	// ImageID returns the EC2 AMI the tenant cluster's EC2 instances are
+	// launched from.
+	ImageID(ctx context.Context, customObject v1alpha1.AWSConfig) (string, error)
+}
+
+// EC2Client describes the methods required to be implemented by an EC2 AWS
+// client.
+type EC2Client interface {
+	DescribeImages(*ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error)
+}
@@ -0,0 +1,75 @@
+package image
+
+import (
+	"context"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+/*
+Container Linux AMIs for each active AWS region.
+
+NOTE 1: AMIs should always be for HVM virtualisation and not PV.
+NOTE 2: You also need to update the tests.
+
+service/controller/v26/image/static_test.go
+
+Current Release: CoreOS Container Linux stable 2023.4.0 (HVM)
+AMI IDs copied from https://stable.release.core-os.net/amd64-usr/2023.4.0/coreos_production_ami_hvm.txt.
+*/
+var DefaultImageIDs = map[string]string{
+	"ap-northeast-1": "ami-003b3a37a48d799cf",
+	"ap-northeast-2": "ami-0c2d3bd39b13c3b2d",
+	"ap-south-1":     "ami-0bd5eb3e67407e0df",
+	"ap-southeast-1": "ami-07aafbd1f2a182cd4",
+	"ap-southeast-2": "ami-0cb589c5f6134f078",
+	"ca-central-1":   "ami-0952a9471ff71919e",
+	"cn-north-1":     "ami-0caaf17a3032c1b56",
+	"cn-northwest-1": "ami-0a863f3b0a0720e6a",
+	"eu-central-1":   "ami-015e6cb33a709348e",
+	"eu-west-1":      "ami-04d747d892ccd652a",
+	"eu-west-2":      "ami-056a316ba69c9d9e8",
+	"eu-west-3":      "ami-026d41122f47f745e",
+	"sa-east-1":      "ami-0e9521088a80c2a02",
+	"us-east-1":      "ami-09d5d3bcd3e0e5c30",
+	"us-east-2":      "ami-02accfa372062664b",
+	"us-gov-west-1":  "ami-07600866",
+	"us-west-1":      "ami-0481a60675f6ea007",
+	"us-west-2":      "ami-025acbb0fb1db6a27",
+}
+
+type StaticConfig struct {
+	// ImageIDs maps AWS regions to EC2 AMIs. Defaults to DefaultImageIDs.
+	ImageIDs map[string]string
+}
+
+// Static resolves EC2 AMIs using a fixed map of AWS regions to EC2 AMIs.
+type Static struct {
+	imageIDs map[string]string
+}
+
+func NewStatic(config StaticConfig) (*Static, error) {
+	if config.ImageIDs == nil {
+		config.ImageIDs = DefaultImageIDs
+	}
+
+	s := &Static{
+		imageIDs: config.ImageIDs,
+	}
+
+	return s, nil
+}
+
+func (s *Static) ImageID(ctx context.Context, customObject v1alpha1.AWSConfig) (string, error) {
+	region := key.Region(customObject)
+
+	imageID, ok := s.imageIDs[region]
+	if !ok {
+		return "", microerror.Maskf(imageNotFoundError, "no image id for region %#q", region)
+	}
+
+	return imageID, nil
+}
@@ -0,0 +1,76 @@
+package image
+
+import (
+	"context"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
+func Test_Static_ImageID(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description     string
+		customObject    v1alpha1.AWSConfig
+		errorMatcher    func(error) bool
+		expectedImageID string
+	}{
+		{
+			description: "basic match",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Region: "eu-central-1",
+					},
+				},
+			},
+			errorMatcher:    nil,
+			expectedImageID: "ami-015e6cb33a709348e",
+		},
+		{
+			description: "different region",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Region: "eu-west-1",
+					},
+				},
+			},
+			errorMatcher:    nil,
+			expectedImageID: "ami-04d747d892ccd652a",
+		},
+		{
+			description: "invalid region",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Region: "invalid-1",
+					},
+				},
+			},
+			errorMatcher: IsImageNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := NewStatic(StaticConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			imageID, err := s.ImageID(context.Background(), tc.customObject)
+			if tc.errorMatcher != nil && err == nil {
+				t.Error("expected error didn't happen")
+			}
+
+			if tc.errorMatcher != nil && !tc.errorMatcher(err) {
+				t.Error("expected", true, "got", false)
+			}
+
+			if tc.expectedImageID != imageID {
+				t.Errorf("unexpected imageID, expecting %q, want %q", tc.expectedImageID, imageID)
+			}
+		})
+	}
+}
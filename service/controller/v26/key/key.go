@@ -3,6 +3,7 @@ package key
 import (
 	"crypto/sha1"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,9 +30,34 @@ const (
 	// and managed by a cluster.
 	CloudProviderTagOwnedValue = "owned"
 
+	// AutoScalingServiceLinkedRoleName is the name of the service-linked role
+	// EC2 auto scaling uses to launch instances on behalf of the tenant
+	// cluster's auto scaling groups.
+	AutoScalingServiceLinkedRoleName = "AWSServiceRoleForAutoScaling"
+
+	// AWSManagedEBSKeyAlias is the alias of the AWS managed KMS key EBS volumes
+	// are encrypted with when no other KMS key is specified.
+	AWSManagedEBSKeyAlias = "aws/ebs"
+
 	// EnableTerminationProtection is used to protect the CF stacks from deletion.
 	EnableTerminationProtection = true
 
+	// EtcdMemberEnvironmentFile is the path of the file defining the name and
+	// the peer URL of the etcd member running on a master of a multi master
+	// tenant cluster.
+	EtcdMemberEnvironmentFile = "/etc/etcd-member.env"
+	// EtcdPeerPort is the port etcd members use to communicate with their peers.
+	EtcdPeerPort = 2380
+
+	// MasterVolumeTagName is used to tag EBS snapshots of master volumes with
+	// the name of the volume they were taken from.
+	MasterVolumeTagName = "giantswarm.io/master-volume"
+
+	// OrphanedClusterTagName is used to tag EBS volumes and snapshots of
+	// persistent volumes kept after the deletion of the tenant cluster with
+	// the ID of the deleted tenant cluster.
+	OrphanedClusterTagName = "giantswarm.io/orphaned-cluster"
+
 	// InstallationTagName is used for AWS resource tagging.
 	InstallationTagName = "giantswarm.io/installation"
 
@@ -47,19 +73,29 @@ const (
 	// LogDeliveryURI is used for setting the correct ACL in the access log bucket
 	LogDeliveryURI = "uri=http://acs.amazonaws.com/groups/s3/LogDelivery"
 
-	InstanceIDAnnotation = "aws-operator.giantswarm.io/instance"
+	AutoScalingGroupAnnotation = "aws-operator.giantswarm.io/asg"
+	InstanceIDAnnotation       = "aws-operator.giantswarm.io/instance"
 
 	chinaAWSCliContainerRegistry   = "docker://registry-intl.cn-shanghai.aliyuncs.com/giantswarm/awscli:latest"
 	defaultAWSCliContainerRegistry = "quay.io/coreos/awscli:025a357f05242fdad6a81e8a6b520098aa65a600"
 	defaultDockerVolumeSizeGB      = "100"
+
+	defaultMasterDockerVolumeSizeGB = 50
+	defaultMasterEtcdVolumeSizeGB   = 100
+	defaultMasterLogVolumeSizeGB    = 100
 )
 
 const (
 	DockerVolumeResourceNameKey   = "DockerVolumeResourceName"
+	MasterAvailabilityZonesKey    = "MasterAvailabilityZones"
+	MasterDockerVolumeKey         = "MasterDockerVolume"
+	MasterEtcdRestoreSnapshotKey  = "MasterEtcdRestoreSnapshot"
+	MasterEtcdVolumeKey           = "MasterEtcdVolume"
 	MasterImageIDKey              = "MasterImageID"
 	MasterInstanceResourceNameKey = "MasterInstanceResourceName"
 	MasterInstanceTypeKey         = "MasterInstanceType"
 	MasterInstanceMonitoring      = "Monitoring"
+	MasterLogVolumeKey            = "MasterLogVolume"
 	MasterCloudConfigVersionKey   = "MasterCloudConfigVersion"
 	VersionBundleVersionKey       = "VersionBundleVersion"
 	WorkerCountKey                = "WorkerCount"
@@ -67,20 +103,103 @@ const (
 	WorkerMinKey                  = "WorkerMin"
 	WorkerDockerVolumeSizeKey     = "WorkerDockerVolumeSizeGB"
 	WorkerImageIDKey              = "WorkerImageID"
+	WorkerKubeletVolumeSizeKey    = "WorkerKubeletVolumeSizeGB"
 	WorkerInstanceMonitoring      = "Monitoring"
 	WorkerInstanceTypeKey         = "WorkerInstanceType"
+	WorkerInstanceTypesKey        = "WorkerInstanceTypes"
+	WorkerOnDemandBaseCapacityKey = "WorkerOnDemandBaseCapacity"
+	WorkerSpotPercentageKey       = "WorkerSpotPercentage"
+	WorkerVolumeEncryptionKey     = "WorkerVolumeEncryption"
+	WorkerVolumeTypeKey           = "WorkerVolumeType"
 	WorkerCloudConfigVersionKey   = "WorkerCloudConfigVersion"
 )
 
+// Conditions of the tenant cluster's control plane stack persisted in the
+// resources of the AWSConfig CR status.
+const (
+	StatusConditionTypeStackFailed = "StackFailed"
+	StatusResourceNameTCCP         = "tccp"
+)
+
+// Load balancer types which can be configured in the AWSConfig CR.
+const (
+	LoadBalancerTypeClassic = "classic"
+	LoadBalancerTypeNetwork = "network"
+)
+
+// EBS volume types which can be configured in the AWSConfig CR.
+const (
+	VolumeTypeGP2 = "gp2"
+	VolumeTypeGP3 = "gp3"
+	VolumeTypeIO1 = "io1"
+)
+
+// Deletion policies of the EBS volumes of the tenant cluster's persistent
+// volumes which can be configured on the AWSConfig CR.
+const (
+	PersistentVolumeDeletionPolicyDelete   = "Delete"
+	PersistentVolumeDeletionPolicyRetain   = "Retain"
+	PersistentVolumeDeletionPolicySnapshot = "Snapshot"
+)
+
+// Event reasons of the Kubernetes events emitted on the AWSConfig CR.
+const (
+	EventReasonAvailabilityZoneInvalid = "AvailabilityZoneInvalid"
+	EventReasonAvailabilityZonesAdded  = "AvailabilityZonesAdded"
+	EventReasonDrainFinished           = "DrainFinished"
+	EventReasonDrainStarted            = "DrainStarted"
+	EventReasonDrainTimedOut           = "DrainTimedOut"
+	EventReasonExistingVPCInvalid      = "ExistingVPCInvalid"
+	EventReasonExistingVPCValidated    = "ExistingVPCValidated"
+	EventReasonNetworkRangeExhausted   = "NetworkRangeExhausted"
+	EventReasonNetworkRangeInvalid     = "NetworkRangeInvalid"
+	EventReasonPersistentVolumesKept   = "PersistentVolumesKept"
+	EventReasonS3BucketCreated         = "S3BucketCreated"
+	EventReasonStackCreationFailed     = "StackCreationFailed"
+	EventReasonStackCreationRequested  = "StackCreationRequested"
+	EventReasonStackFailed             = "StackFailed"
+	EventReasonStackPlanExecuted       = "StackPlanExecuted"
+	EventReasonStackPlanned            = "StackPlanned"
+	EventReasonStackRecoveryFailed     = "StackRecoveryFailed"
+	EventReasonStackRecoveryRequested  = "StackRecoveryRequested"
+	EventReasonStackScalingFailed      = "StackScalingFailed"
+	EventReasonStackScalingRequested   = "StackScalingRequested"
+	EventReasonStackUpdateFailed       = "StackUpdateFailed"
+	EventReasonStackUpdateRequested    = "StackUpdateRequested"
+	EventReasonSubnetAllocated         = "SubnetAllocated"
+	EventReasonVolumeSnapshotsCreated  = "VolumeSnapshotsCreated"
+	EventReasonVolumeSnapshotsFailed   = "VolumeSnapshotsFailed"
+)
+
 const (
 	ClusterIDLabel = "giantswarm.io/cluster"
 
 	AnnotationEtcdDomain        = "giantswarm.io/etcd-domain"
 	AnnotationPrometheusCluster = "giantswarm.io/prometheus-cluster"
 
+	// AnnotationEtcdRestoreSnapshot is set on the AWSConfig CR to the name of
+	// the etcd snapshot in the tenant cluster's S3 bucket the master should be
+	// restored from. Setting it replaces the master, which restores the snapshot
+	// before etcd starts.
+	AnnotationEtcdRestoreSnapshot = "aws-operator.giantswarm.io/etcd-restore-snapshot"
+
+	// AnnotationPersistentVolumeDeletionPolicy is set on the AWSConfig CR to
+	// define what happens to the EBS volumes of the tenant cluster's persistent
+	// volumes when the tenant cluster is deleted. See the
+	// PersistentVolumeDeletionPolicy constants for the supported values.
+	AnnotationPersistentVolumeDeletionPolicy = "aws-operator.giantswarm.io/persistent-volume-deletion-policy"
+
+	// AnnotationPlanApproved is set on the AWSConfig CR to the name of the
+	// planned change set which should be executed.
+	AnnotationPlanApproved = "aws-operator.giantswarm.io/plan-approved"
+	// AnnotationPlanMode enables plan mode for the tenant cluster's control
+	// plane stack when set to "true" on the AWSConfig CR.
+	AnnotationPlanMode = "aws-operator.giantswarm.io/plan-mode"
+
 	LabelApp           = "app"
 	LabelCluster       = "giantswarm.io/cluster"
 	LabelCustomer      = "customer"
+	LabelNodePool      = "giantswarm.io/node-pool"
 	LabelOrganization  = "giantswarm.io/organization"
 	LabelVersionBundle = "giantswarm.io/version-bundle"
 
@@ -90,6 +209,7 @@ const (
 const (
 	NodeDrainerLifecycleHookName = "NodeDrainer"
 	WorkerASGRef                 = "workerAutoScalingGroup"
+	WorkerLaunchTemplateRef      = "workerLaunchTemplate"
 )
 
 const (
@@ -107,6 +227,12 @@ func AutoScalingGroupName(customObject v1alpha1.AWSConfig, groupName string) str
 	return fmt.Sprintf("%s-%s", ClusterID(customObject), groupName)
 }
 
+// AutoScalingServiceLinkedRoleARN returns the ARN of the service-linked role
+// of EC2 auto scaling in the given account.
+func AutoScalingServiceLinkedRoleARN(customObject v1alpha1.AWSConfig, accountID string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:role/aws-service-role/autoscaling.amazonaws.com/%s", RegionARN(customObject), accountID, AutoScalingServiceLinkedRoleName)
+}
+
 func AvailabilityZone(customObject v1alpha1.AWSConfig) string {
 	return customObject.Spec.AWS.AZ
 }
@@ -148,10 +274,11 @@ func CloudConfigSmallTemplates() []string {
 func CloudFormationGuestTemplates() []string {
 	return []string{
 		tccp.AutoScalingGroup,
+		tccp.ExistingVPC,
 		tccp.IAMPolicies,
 		tccp.Instance,
 		tccp.InternetGateway,
-		tccp.LaunchConfiguration,
+		tccp.LaunchTemplate,
 		tccp.LoadBalancers,
 		tccp.Main,
 		tccp.NatGateway,
@@ -211,16 +338,36 @@ func DockerVolumeResourceName(customObject v1alpha1.AWSConfig) string {
 	return getResourcenameWithTimeHash("DockerVolume", customObject)
 }
 
-func DockerVolumeName(customObject v1alpha1.AWSConfig) string {
-	return fmt.Sprintf("%s-docker", ClusterID(customObject))
+func DockerVolumeName(customObject v1alpha1.AWSConfig, idx int) string {
+	return masterIndexedName(fmt.Sprintf("%s-docker", ClusterID(customObject)), idx)
+}
+
+// EtcdBackupPrefix returns the prefix of the etcd snapshots of the tenant
+// cluster within its S3 bucket.
+func EtcdBackupPrefix(customObject v1alpha1.AWSConfig) string {
+	return fmt.Sprintf("etcd-backups/%s/", ClusterID(customObject))
+}
+
+// EtcdRestoreSnapshot returns the name of the etcd snapshot the master should
+// be restored from. An empty string is returned when no restore is requested.
+func EtcdRestoreSnapshot(customObject v1alpha1.AWSConfig) string {
+	return customObject.GetAnnotations()[AnnotationEtcdRestoreSnapshot]
 }
 
-func EtcdVolumeName(customObject v1alpha1.AWSConfig) string {
-	return fmt.Sprintf("%s-etcd", ClusterID(customObject))
+func EtcdVolumeName(customObject v1alpha1.AWSConfig, idx int) string {
+	return masterIndexedName(fmt.Sprintf("%s-etcd", ClusterID(customObject)), idx)
 }
 
-func LogVolumeName(customObject v1alpha1.AWSConfig) string {
-	return fmt.Sprintf("%s-log", ClusterID(customObject))
+func EtcdVolumeResourceName(idx int) string {
+	return MasterResourceName("EtcdVolume", idx)
+}
+
+func LogVolumeName(customObject v1alpha1.AWSConfig, idx int) string {
+	return masterIndexedName(fmt.Sprintf("%s-log", ClusterID(customObject)), idx)
+}
+
+func LogVolumeResourceName(idx int) string {
+	return MasterResourceName("LogVolume", idx)
 }
 
 func EC2ServiceDomain(customObject v1alpha1.AWSConfig) string {
@@ -253,6 +400,12 @@ func HostedZoneNameIngress(customObject v1alpha1.AWSConfig) string {
 	return customObject.Spec.AWS.HostedZones.Ingress.Name
 }
 
+// ImageIDOverride returns the EC2 AMI explicitly defined in the CR spec, if
+// any.
+func ImageIDOverride(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.AWS.ImageID
+}
+
 func IngressControllerInsecurePort(customObject v1alpha1.AWSConfig) int {
 	return customObject.Spec.Cluster.Kubernetes.IngressController.InsecurePort
 }
@@ -273,6 +426,18 @@ func IsDeleted(customObject v1alpha1.AWSConfig) bool {
 	return customObject.GetDeletionTimestamp() != nil
 }
 
+// IsExistingVPC returns true when the tenant cluster is deployed into an
+// existing VPC instead of a VPC managed by the operator.
+func IsExistingVPC(customObject v1alpha1.AWSConfig) bool {
+	return ExistingVPCID(customObject) != ""
+}
+
+// IsPlanMode returns true when updates of the tenant cluster's control plane
+// stack have to be planned and approved before being applied.
+func IsPlanMode(customObject v1alpha1.AWSConfig) bool {
+	return customObject.GetAnnotations()[AnnotationPlanMode] == "true"
+}
+
 func KubernetesAPISecurePort(customObject v1alpha1.AWSConfig) int {
 	return customObject.Spec.Cluster.Kubernetes.API.SecurePort
 }
@@ -281,10 +446,61 @@ func EtcdDomain(customObject v1alpha1.AWSConfig) string {
 	return strings.Join([]string{"etcd", ClusterID(customObject), "k8s", BaseDomain(customObject)}, ".")
 }
 
+// EtcdInitialCluster returns the initial cluster of the tenant cluster's
+// etcd. It lists the name and peer URL of the etcd member of every master.
+func EtcdInitialCluster(customObject v1alpha1.AWSConfig) string {
+	var members []string
+	for i := 0; i < MasterReplicas(customObject); i++ {
+		members = append(members, fmt.Sprintf("%s=%s", EtcdMemberName(i), EtcdMemberPeerURL(customObject, i)))
+	}
+
+	return strings.Join(members, ",")
+}
+
+// EtcdMemberDomain returns the domain of the etcd member running on the
+// master with the given index. It resolves to the private IP of the master, so
+// that etcd members find their peers across master replacements.
+func EtcdMemberDomain(customObject v1alpha1.AWSConfig, idx int) string {
+	return strings.Join([]string{EtcdMemberName(idx), ClusterID(customObject), "k8s", BaseDomain(customObject)}, ".")
+}
+
+// EtcdMemberName returns the name of the etcd member running on the master
+// with the given index.
+func EtcdMemberName(idx int) string {
+	return fmt.Sprintf("etcd%d", idx)
+}
+
+// EtcdMemberPeerURL returns the URL the etcd member running on the master with
+// the given index advertises to its peers.
+func EtcdMemberPeerURL(customObject v1alpha1.AWSConfig, idx int) string {
+	return fmt.Sprintf("https://%s:%d", EtcdMemberDomain(customObject, idx), EtcdPeerPort)
+}
+
 func EtcdPort(customObject v1alpha1.AWSConfig) int {
 	return 2379
 }
 
+// ExistingVPCID returns the ID of the existing VPC the tenant cluster is
+// deployed into. An empty string is returned in case the tenant cluster's VPC
+// is managed by the operator.
+func ExistingVPCID(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.AWS.VPC.ID
+}
+
+// ExistingVPCSubnets returns the existing subnets the tenant cluster is
+// deployed into, sorted by availability zone name. The order matches the
+// order of the availability zones in the tenant cluster's status.
+func ExistingVPCSubnets(customObject v1alpha1.AWSConfig) []v1alpha1.AWSConfigSpecAWSVPCSubnet {
+	subnets := make([]v1alpha1.AWSConfigSpecAWSVPCSubnet, len(customObject.Spec.AWS.VPC.Subnets))
+	copy(subnets, customObject.Spec.AWS.VPC.Subnets)
+
+	sort.Slice(subnets, func(i, j int) bool {
+		return subnets[i].AvailabilityZone < subnets[j].AvailabilityZone
+	})
+
+	return subnets
+}
+
 // LoadBalancerName produces a unique name for the load balancer.
 // It takes the domain name, extracts the first subdomain, and combines it with the cluster name.
 func LoadBalancerName(domainName string, cluster v1alpha1.AWSConfig) (string, error) {
@@ -302,6 +518,22 @@ func LoadBalancerName(domainName string, cluster v1alpha1.AWSConfig) (string, er
 	return lbName, nil
 }
 
+// LoadBalancerType returns the kind of load balancers fronting the tenant
+// cluster. Classic ELBs are used unless NLBs are explicitly requested.
+func LoadBalancerType(customObject v1alpha1.AWSConfig) string {
+	if customObject.Spec.AWS.LoadBalancerType == LoadBalancerTypeNetwork {
+		return LoadBalancerTypeNetwork
+	}
+
+	return LoadBalancerTypeClassic
+}
+
+// IsNetworkLoadBalancer returns true when the tenant cluster's Kubernetes API,
+// etcd and ingress controller are fronted by NLBs.
+func IsNetworkLoadBalancer(customObject v1alpha1.AWSConfig) bool {
+	return LoadBalancerType(customObject) == LoadBalancerTypeNetwork
+}
+
 func MainGuestStackName(customObject v1alpha1.AWSConfig) string {
 	clusterID := ClusterID(customObject)
 
@@ -324,6 +556,17 @@ func MasterCount(customObject v1alpha1.AWSConfig) int {
 	return len(customObject.Spec.AWS.Masters)
 }
 
+// MasterReplicas returns the number of master instances managed in the tenant
+// cluster's control plane stack. Custom objects not specifying any master still
+// get a single master, which is the behaviour of single master clusters.
+func MasterReplicas(customObject v1alpha1.AWSConfig) int {
+	if MasterCount(customObject) < 1 {
+		return 1
+	}
+
+	return MasterCount(customObject)
+}
+
 func MasterImageID(customObject v1alpha1.AWSConfig) string {
 	var imageID string
 
@@ -338,10 +581,40 @@ func MasterInstanceResourceName(customObject v1alpha1.AWSConfig) string {
 	return getResourcenameWithTimeHash("MasterInstance", customObject)
 }
 
-func MasterInstanceName(customObject v1alpha1.AWSConfig) string {
+func MasterInstanceName(customObject v1alpha1.AWSConfig, idx int) string {
 	clusterID := ClusterID(customObject)
 
-	return fmt.Sprintf("%s-master", clusterID)
+	return masterIndexedName(fmt.Sprintf("%s-master", clusterID), idx)
+}
+
+// MasterInstanceNames returns the Name tags of all master instances of the
+// tenant cluster.
+func MasterInstanceNames(customObject v1alpha1.AWSConfig) []string {
+	var names []string
+
+	for i := 0; i < MasterReplicas(customObject); i++ {
+		names = append(names, MasterInstanceName(customObject, i))
+	}
+
+	return names
+}
+
+// MasterDockerVolume returns the configuration of the masters' docker volume
+// with defaults applied.
+func MasterDockerVolume(customObject v1alpha1.AWSConfig) v1alpha1.AWSConfigSpecAWSVolume {
+	return volumeWithDefaults(customObject.Spec.AWS.MasterVolumes.Docker, defaultMasterDockerVolumeSizeGB)
+}
+
+// MasterEtcdVolume returns the configuration of the masters' etcd volume with
+// defaults applied.
+func MasterEtcdVolume(customObject v1alpha1.AWSConfig) v1alpha1.AWSConfigSpecAWSVolume {
+	return volumeWithDefaults(customObject.Spec.AWS.MasterVolumes.Etcd, defaultMasterEtcdVolumeSizeGB)
+}
+
+// MasterLogVolume returns the configuration of the masters' log volume with
+// defaults applied.
+func MasterLogVolume(customObject v1alpha1.AWSConfig) v1alpha1.AWSConfigSpecAWSVolume {
+	return volumeWithDefaults(customObject.Spec.AWS.MasterVolumes.Log, defaultMasterLogVolumeSizeGB)
 }
 
 func MasterInstanceType(customObject v1alpha1.AWSConfig) string {
@@ -354,6 +627,18 @@ func MasterInstanceType(customObject v1alpha1.AWSConfig) string {
 	return instanceType
 }
 
+// MasterResourceName returns the Cloud Formation resource name of the master
+// resource with the given index, e.g. the master instance or the master's etcd
+// volume.
+func MasterResourceName(resourceName string, idx int) string {
+	// Since CloudFormation cannot recognize resource renaming, use non-indexed
+	// resource name for first master.
+	if idx < 1 {
+		return resourceName
+	}
+	return fmt.Sprintf("%s%02d", resourceName, idx)
+}
+
 func MasterRoleARN(customObject v1alpha1.AWSConfig, accountID string) string {
 	return baseRoleARN(customObject, accountID, "master")
 }
@@ -385,6 +670,102 @@ func NATRouteName(idx int) string {
 	return fmt.Sprintf("NATRoute%02d", idx)
 }
 
+// NodePoolDockerVolumeSizeGB returns the docker volume size of the given
+// node pool's nodes.
+func NodePoolDockerVolumeSizeGB(nodePool v1alpha1.AWSConfigSpecAWSNodePool) string {
+	if nodePool.DockerVolumeSizeGB <= 0 {
+		return defaultDockerVolumeSizeGB
+	}
+
+	return strconv.Itoa(nodePool.DockerVolumeSizeGB)
+}
+
+// NodePoolKubeletVolumeSizeGB returns the kubelet volume size of the given
+// node pool. Without a configured kubelet volume size the kubelet volume has
+// the size of the node pool's docker volume.
+func NodePoolKubeletVolumeSizeGB(nodePool v1alpha1.AWSConfigSpecAWSNodePool) string {
+	if nodePool.KubeletVolumeSizeGB <= 0 {
+		return NodePoolDockerVolumeSizeGB(nodePool)
+	}
+
+	return strconv.Itoa(nodePool.KubeletVolumeSizeGB)
+}
+
+// NodePoolInstanceTypes returns the instance types the given node pool's auto
+// scaling group may launch. The instance type configured for the node pool
+// always comes first and is followed by the additional instance types of its
+// instance distribution. Duplicates are removed.
+func NodePoolInstanceTypes(nodePool v1alpha1.AWSConfigSpecAWSNodePool) []string {
+	var instanceTypes []string
+
+	seen := map[string]bool{}
+	candidates := append([]string{nodePool.InstanceType}, nodePool.InstanceDistribution.InstanceTypes...)
+	for _, t := range candidates {
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		instanceTypes = append(instanceTypes, t)
+	}
+
+	return instanceTypes
+}
+
+// NodePoolLabels returns the additional Kubernetes node labels of the given
+// node pool in the format of the kubelet's --node-labels flag. Named node
+// pools are labelled with their name.
+func NodePoolLabels(nodePool v1alpha1.AWSConfigSpecAWSNodePool) string {
+	var labels []string
+
+	if nodePool.Name != "" {
+		labels = append(labels, fmt.Sprintf("%s=%s", LabelNodePool, nodePool.Name))
+	}
+
+	var keys []string
+	for k := range nodePool.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		labels = append(labels, fmt.Sprintf("%s=%s", k, nodePool.Labels[k]))
+	}
+
+	return strings.Join(labels, ",")
+}
+
+// NodePoolResourceName returns the name of a per node pool resource, e.g. a
+// CloudFormation resource or output, for the node pool with the given name.
+// The unnamed node pool keeps the plain resource name so that existing
+// resources are not replaced.
+//
+//     NodePoolResourceName("workerAutoScalingGroup", "")        workerAutoScalingGroup
+//     NodePoolResourceName("workerAutoScalingGroup", "highmem") workerAutoScalingGroupHighmem
+//
+func NodePoolResourceName(resourceName string, nodePool string) string {
+	if nodePool == "" {
+		return resourceName
+	}
+
+	return resourceName + strings.Title(nodePool)
+}
+
+// NodePoolRole returns the role of the given node pool's nodes. It is used to
+// name their cloud config S3 objects and AWS resources. The unnamed node pool
+// has the plain worker role.
+func NodePoolRole(nodePool string) string {
+	if nodePool == "" {
+		return KindWorker
+	}
+
+	return fmt.Sprintf("%s-%s", KindWorker, nodePool)
+}
+
+// NodePoolTaints returns the Kubernetes node taints of the given node pool in
+// the format of the kubelet's --register-with-taints flag.
+func NodePoolTaints(nodePool v1alpha1.AWSConfigSpecAWSNodePool) string {
+	return strings.Join(nodePool.Taints, ",")
+}
+
 func PeerAccessRoleName(customObject v1alpha1.AWSConfig) string {
 	return fmt.Sprintf("%s-vpc-peer-access", ClusterID(customObject))
 }
@@ -393,6 +774,22 @@ func PeerID(customObject v1alpha1.AWSConfig) string {
 	return customObject.Spec.AWS.VPC.PeerID
 }
 
+// PersistentVolumeDeletionPolicy returns the deletion policy of the EBS volumes
+// of the tenant cluster's persistent volumes. Volumes are deleted together
+// with the tenant cluster unless defined otherwise.
+func PersistentVolumeDeletionPolicy(customObject v1alpha1.AWSConfig) string {
+	p := customObject.GetAnnotations()[AnnotationPersistentVolumeDeletionPolicy]
+	if p == "" {
+		return PersistentVolumeDeletionPolicyDelete
+	}
+
+	return p
+}
+
+func PlanApprovedChangeSetName(customObject v1alpha1.AWSConfig) string {
+	return customObject.GetAnnotations()[AnnotationPlanApproved]
+}
+
 func PolicyName(customObject v1alpha1.AWSConfig, profileType string) string {
 	return fmt.Sprintf("%s-%s-%s", ClusterID(customObject), profileType, PolicyNameTemplate)
 }
@@ -524,10 +921,42 @@ func SmallCloudConfigS3URL(customObject v1alpha1.AWSConfig, accountID string, ro
 	return fmt.Sprintf("s3://%s", SmallCloudConfigPath(customObject, accountID, role))
 }
 
+// SpecAvailabilityZoneNames returns the availability zone names or IDs the
+// tenant cluster is explicitly placed into. An empty list means the
+// availability zones are selected randomly.
+func SpecAvailabilityZoneNames(customObject v1alpha1.AWSConfig) []string {
+	return customObject.Spec.AWS.AvailabilityZoneNames
+}
+
+// SpecAvailabilityZoneCount returns the number of availability zones the
+// tenant cluster is supposed to span. Explicitly configured availability zones
+// take precedence over the configured number of availability zones.
+func SpecAvailabilityZoneCount(customObject v1alpha1.AWSConfig) int {
+	if len(customObject.Spec.AWS.AvailabilityZoneNames) > 0 {
+		return len(customObject.Spec.AWS.AvailabilityZoneNames)
+	}
+
+	return customObject.Spec.AWS.AvailabilityZones
+}
+
 func SpecAvailabilityZones(customObject v1alpha1.AWSConfig) int {
 	return customObject.Spec.AWS.AvailabilityZones
 }
 
+// SpecNetworkRange returns the name of the network range the tenant cluster's
+// network segment is allocated from. An empty name means the default network
+// range of the installation.
+func SpecNetworkRange(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.AWS.IPAM.NetworkRange
+}
+
+// SpecSubnetMaskBits returns the number of bits in the network mask of the
+// tenant cluster's network segment. 0 means the default size of the
+// installation.
+func SpecSubnetMaskBits(customObject v1alpha1.AWSConfig) int {
+	return customObject.Spec.AWS.IPAM.SubnetMaskBits
+}
+
 func StatusAvailabilityZones(customObject v1alpha1.AWSConfig) []v1alpha1.AWSConfigStatusAWSAvailabilityZone {
 	return customObject.Status.AWS.AvailabilityZones
 }
@@ -537,6 +966,12 @@ func StatusNetworkCIDR(customObject v1alpha1.AWSConfig) string {
 	return customObject.Status.Cluster.Network.CIDR
 }
 
+// StatusNetworkSecondaryCIDRs returns the secondary tenant cluster subnet CIDRs
+// allocated when adding availability zones.
+func StatusNetworkSecondaryCIDRs(customObject v1alpha1.AWSConfig) []string {
+	return customObject.Status.Cluster.Network.SecondaryCIDRs
+}
+
 func StatusScalingDesiredCapacity(customObject v1alpha1.AWSConfig) int {
 	return customObject.Status.Cluster.Scaling.DesiredCapacity
 }
@@ -612,11 +1047,24 @@ func ToVersionBundleVersion(v interface{}) (string, error) {
 	return VersionBundleVersion(customObject), nil
 }
 
+// TransitGatewayRouteName returns the logical ID of the route from the private
+// route table of the given availability zone to the control plane VPC via the
+// Transit Gateway.
+func TransitGatewayRouteName(idx int) string {
+	return fmt.Sprintf("TransitGatewayRoute%02d", idx)
+}
+
 // VersionBundleVersion returns the version contained in the Version Bundle.
 func VersionBundleVersion(customObject v1alpha1.AWSConfig) string {
 	return customObject.Spec.VersionBundle.Version
 }
 
+// VPCCIDRBlockName returns the CloudFormation resource name of the secondary
+// VPC CIDR block with the given index.
+func VPCCIDRBlockName(idx int) string {
+	return fmt.Sprintf("VPCCIDRBlock%02d", idx)
+}
+
 func VPCPeeringRouteName(idx int) string {
 	// Since CloudFormation cannot recognize resource renaming, use non-indexed
 	// resource name for first AZ.
@@ -666,6 +1114,42 @@ func WorkerInstanceType(customObject v1alpha1.AWSConfig) string {
 	return instanceType
 }
 
+// WorkerKubeletVolumeSizeGB returns the configured kubelet volume size of the
+// worker nodes. Zero means the kubelet volume size is not configured.
+func WorkerKubeletVolumeSizeGB(customObject v1alpha1.AWSConfig) int {
+	if len(customObject.Spec.AWS.Workers) <= 0 {
+		return 0
+	}
+
+	return customObject.Spec.AWS.Workers[0].KubeletVolumeSizeGB
+}
+
+// WorkerNodePools returns the worker node pools of the tenant cluster. In
+// case no node pools are configured, a single unnamed node pool is derived
+// from the worker, worker instance distribution and scaling settings. The
+// unnamed node pool maps to the worker resources of tenant clusters created
+// before node pools existed.
+func WorkerNodePools(customObject v1alpha1.AWSConfig) []v1alpha1.AWSConfigSpecAWSNodePool {
+	if len(customObject.Spec.AWS.NodePools) > 0 {
+		return customObject.Spec.AWS.NodePools
+	}
+
+	p := v1alpha1.AWSConfigSpecAWSNodePool{
+		InstanceType:         WorkerInstanceType(customObject),
+		InstanceDistribution: customObject.Spec.AWS.WorkerInstanceDistribution,
+		Scaling: v1alpha1.AWSConfigSpecAWSNodePoolScaling{
+			Max: ScalingMax(customObject),
+			Min: ScalingMin(customObject),
+		},
+	}
+	if len(customObject.Spec.AWS.Workers) > 0 {
+		p.DockerVolumeSizeGB = customObject.Spec.AWS.Workers[0].DockerVolumeSizeGB
+		p.KubeletVolumeSizeGB = customObject.Spec.AWS.Workers[0].KubeletVolumeSizeGB
+	}
+
+	return []v1alpha1.AWSConfigSpecAWSNodePool{p}
+}
+
 func WorkerRoleARN(customObject v1alpha1.AWSConfig, accountID string) string {
 	return baseRoleARN(customObject, accountID, "worker")
 }
@@ -689,50 +1173,14 @@ func componentName(domainName string) (string, error) {
 	return splits[0], nil
 }
 
-// ImageID returns the EC2 AMI for the configured region.
-func ImageID(customObject v1alpha1.AWSConfig) (string, error) {
-	region := Region(customObject)
-
-	/*
-		Container Linux AMIs for each active AWS region.
-
-		NOTE 1: AMIs should always be for HVM virtualisation and not PV.
-		NOTE 2: You also need to update the tests.
-
-		service/controller/v26/key/key_test.go
-		service/controller/v26/adapter/adapter_test.go
-		service/controller/v26/resource/cloudformation/main_stack_test.go
-
-		Current Release: CoreOS Container Linux stable 2023.4.0 (HVM)
-		AMI IDs copied from https://stable.release.core-os.net/amd64-usr/2023.4.0/coreos_production_ami_hvm.txt.
-	*/
-	imageIDs := map[string]string{
-		"ap-northeast-1": "ami-003b3a37a48d799cf",
-		"ap-northeast-2": "ami-0c2d3bd39b13c3b2d",
-		"ap-south-1":     "ami-0bd5eb3e67407e0df",
-		"ap-southeast-1": "ami-07aafbd1f2a182cd4",
-		"ap-southeast-2": "ami-0cb589c5f6134f078",
-		"ca-central-1":   "ami-0952a9471ff71919e",
-		"cn-north-1":     "ami-0caaf17a3032c1b56",
-		"cn-northwest-1": "ami-0a863f3b0a0720e6a",
-		"eu-central-1":   "ami-015e6cb33a709348e",
-		"eu-west-1":      "ami-04d747d892ccd652a",
-		"eu-west-2":      "ami-056a316ba69c9d9e8",
-		"eu-west-3":      "ami-026d41122f47f745e",
-		"sa-east-1":      "ami-0e9521088a80c2a02",
-		"us-east-1":      "ami-09d5d3bcd3e0e5c30",
-		"us-east-2":      "ami-02accfa372062664b",
-		"us-gov-west-1":  "ami-07600866",
-		"us-west-1":      "ami-0481a60675f6ea007",
-		"us-west-2":      "ami-025acbb0fb1db6a27",
-	}
-
-	imageID, ok := imageIDs[region]
-	if !ok {
-		return "", microerror.Maskf(invalidConfigError, "no image id for region '%s'", region)
+// masterIndexedName returns the AWS resource name used for tagging the master
+// resource with the given index. The first master keeps the non-indexed name
+// in order to be compatible with existing single master clusters.
+func masterIndexedName(name string, idx int) string {
+	if idx < 1 {
+		return name
 	}
-
-	return imageID, nil
+	return fmt.Sprintf("%s-%02d", name, idx)
 }
 
 // getResourcenameWithTimeHash returns the string compared from specific prefix,
@@ -749,3 +1197,56 @@ func getResourcenameWithTimeHash(prefix string, customObject v1alpha1.AWSConfig)
 
 	return fmt.Sprintf("%s%s%s", prefix, upperClusterID, upperTimeHash)
 }
+
+// VolumeEncryptionKeyARN returns the ARN of the customer managed KMS key the
+// worker volumes should be encrypted with, if any.
+func VolumeEncryptionKeyARN(customObject v1alpha1.AWSConfig) string {
+	return customObject.Spec.AWS.VolumeEncryptionKeyARN
+}
+
+// VolumeEncryptionKeyGrantName returns the name of the KMS grant allowing EC2
+// auto scaling to use the worker volume encryption key.
+func VolumeEncryptionKeyGrantName(customObject v1alpha1.AWSConfig) string {
+	return fmt.Sprintf("%s-autoscaling-volumes", ClusterID(customObject))
+}
+
+// VolumeOutputValue returns the representation of the given EBS volume
+// configuration used as Cloud Formation stack output value, e.g.
+//
+//     type=gp3,sizeGB=100,iops=3000,throughput=125
+//
+func VolumeOutputValue(volume v1alpha1.AWSConfigSpecAWSVolume) string {
+	return fmt.Sprintf("type=%s,sizeGB=%d,iops=%d,throughput=%d", volume.Type, volume.SizeGB, volume.IOPS, volume.Throughput)
+}
+
+// WorkerVolumeEncryptionOutputValue returns the representation of the
+// encryption of the worker volumes used as Cloud Formation stack output value.
+// It is the ARN of the given KMS key or aws/ebs in case the worker volumes are
+// encrypted with the AWS managed EBS key.
+func WorkerVolumeEncryptionOutputValue(volumeKey string) string {
+	if volumeKey == "" {
+		return AWSManagedEBSKeyAlias
+	}
+
+	return volumeKey
+}
+
+// WorkerVolumeType returns the EBS volume type of the worker nodes' volumes.
+func WorkerVolumeType(customObject v1alpha1.AWSConfig) string {
+	if customObject.Spec.AWS.WorkerVolumeType == "" {
+		return VolumeTypeGP2
+	}
+
+	return customObject.Spec.AWS.WorkerVolumeType
+}
+
+func volumeWithDefaults(volume v1alpha1.AWSConfigSpecAWSVolume, sizeGB int) v1alpha1.AWSConfigSpecAWSVolume {
+	if volume.SizeGB <= 0 {
+		volume.SizeGB = sizeGB
+	}
+	if volume.Type == "" {
+		volume.Type = VolumeTypeGP2
+	}
+
+	return volume
+}
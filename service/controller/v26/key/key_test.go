@@ -10,6 +10,7 @@ import (
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func Test_AutoScalingGroupName(t *testing.T) {
@@ -309,6 +310,57 @@ func Test_DockerVolumeResourceName_Inequivalence(t *testing.T) {
 	}
 }
 
+func Test_EtcdInitialCluster(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name                   string
+		masters                []v1alpha1.AWSConfigSpecAWSNode
+		expectedInitialCluster string
+	}{
+		{
+			name:                   "case 0: single master",
+			masters:                nil,
+			expectedInitialCluster: "etcd0=https://etcd0.test-cluster.k8s.installation.eu-central-1.aws.gigantic.io:2380",
+		},
+		{
+			name: "case 1: three masters",
+			masters: []v1alpha1.AWSConfigSpecAWSNode{
+				{},
+				{},
+				{},
+			},
+			expectedInitialCluster: "etcd0=https://etcd0.test-cluster.k8s.installation.eu-central-1.aws.gigantic.io:2380," +
+				"etcd1=https://etcd1.test-cluster.k8s.installation.eu-central-1.aws.gigantic.io:2380," +
+				"etcd2=https://etcd2.test-cluster.k8s.installation.eu-central-1.aws.gigantic.io:2380",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			customObject := v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						HostedZones: v1alpha1.AWSConfigSpecAWSHostedZones{
+							API: v1alpha1.AWSConfigSpecAWSHostedZonesZone{
+								Name: "installation.eu-central-1.aws.gigantic.io",
+							},
+						},
+						Masters: tc.masters,
+					},
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+				},
+			}
+
+			initialCluster := EtcdInitialCluster(customObject)
+			if initialCluster != tc.expectedInitialCluster {
+				t.Fatalf("expected %#q got %#q", tc.expectedInitialCluster, initialCluster)
+			}
+		})
+	}
+}
+
 func Test_EtcdVolumeName(t *testing.T) {
 	t.Parallel()
 	expectedName := "test-cluster-etcd"
@@ -326,8 +378,8 @@ func Test_EtcdVolumeName(t *testing.T) {
 		},
 	}
 
-	if EtcdVolumeName(customObject) != expectedName {
-		t.Fatalf("Expected Etcd volume name %s but was %s", expectedName, EtcdVolumeName(customObject))
+	if EtcdVolumeName(customObject, 0) != expectedName {
+		t.Fatalf("Expected Etcd volume name %s but was %s", expectedName, EtcdVolumeName(customObject, 0))
 	}
 }
 
@@ -348,8 +400,8 @@ func Test_LogVolumeName(t *testing.T) {
 		},
 	}
 
-	if LogVolumeName(customObject) != expectedName {
-		t.Fatalf("Expected Log volume name %s but was %s", expectedName, EtcdVolumeName(customObject))
+	if LogVolumeName(customObject, 0) != expectedName {
+		t.Fatalf("Expected Log volume name %s but was %s", expectedName, LogVolumeName(customObject, 0))
 	}
 }
 
@@ -455,6 +507,96 @@ func Test_IsChinaRegion(t *testing.T) {
 	}
 }
 
+func Test_IsPlanMode(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		expectedResult bool
+	}{
+		{
+			description:    "no annotations",
+			customObject:   v1alpha1.AWSConfig{},
+			expectedResult: false,
+		},
+		{
+			description: "plan mode disabled",
+			customObject: v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationPlanMode: "false",
+					},
+				},
+			},
+			expectedResult: false,
+		},
+		{
+			description: "plan mode enabled",
+			customObject: v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationPlanMode: "true",
+					},
+				},
+			},
+			expectedResult: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.expectedResult != IsPlanMode(tc.customObject) {
+				t.Errorf("unexpected result, expecting %t, want %t", tc.expectedResult, IsPlanMode(tc.customObject))
+			}
+		})
+	}
+}
+
+func Test_PersistentVolumeDeletionPolicy(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		expectedResult string
+	}{
+		{
+			description:    "no annotations",
+			customObject:   v1alpha1.AWSConfig{},
+			expectedResult: PersistentVolumeDeletionPolicyDelete,
+		},
+		{
+			description: "retain policy",
+			customObject: v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationPersistentVolumeDeletionPolicy: "Retain",
+					},
+				},
+			},
+			expectedResult: PersistentVolumeDeletionPolicyRetain,
+		},
+		{
+			description: "snapshot policy",
+			customObject: v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						AnnotationPersistentVolumeDeletionPolicy: "Snapshot",
+					},
+				},
+			},
+			expectedResult: PersistentVolumeDeletionPolicySnapshot,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if tc.expectedResult != PersistentVolumeDeletionPolicy(tc.customObject) {
+				t.Errorf("unexpected result, expecting %q, want %q", tc.expectedResult, PersistentVolumeDeletionPolicy(tc.customObject))
+			}
+		})
+	}
+}
+
 func Test_KubernetesAPISecurePort(t *testing.T) {
 	t.Parallel()
 	expectedPort := 443
@@ -515,6 +657,7 @@ func Test_MasterInstanceName(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
 		customObject         v1alpha1.AWSConfig
+		idx                  int
 		expectedInstanceName string
 	}{
 		{
@@ -525,13 +668,25 @@ func Test_MasterInstanceName(t *testing.T) {
 					},
 				},
 			},
+			idx:                  0,
 			expectedInstanceName: "test-cluster-master",
 		},
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "test-cluster",
+					},
+				},
+			},
+			idx:                  2,
+			expectedInstanceName: "test-cluster-master-02",
+		},
 	}
 
 	for _, tc := range tests {
-		if MasterInstanceName(tc.customObject) != tc.expectedInstanceName {
-			t.Fatalf("Expected master instance name %s but was %s", tc.expectedInstanceName, MasterInstanceName(tc.customObject))
+		if MasterInstanceName(tc.customObject, tc.idx) != tc.expectedInstanceName {
+			t.Fatalf("Expected master instance name %s but was %s", tc.expectedInstanceName, MasterInstanceName(tc.customObject, tc.idx))
 		}
 	}
 }
@@ -968,6 +1123,107 @@ func Test_WorkerInstanceType(t *testing.T) {
 	}
 }
 
+func Test_LoadBalancerType(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		expectedResult string
+	}{
+		{
+			description:    "default to classic load balancers",
+			customObject:   v1alpha1.AWSConfig{},
+			expectedResult: LoadBalancerTypeClassic,
+		},
+		{
+			description: "explicit classic load balancers",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						LoadBalancerType: "classic",
+					},
+				},
+			},
+			expectedResult: LoadBalancerTypeClassic,
+		},
+		{
+			description: "network load balancers",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						LoadBalancerType: "network",
+					},
+				},
+			},
+			expectedResult: LoadBalancerTypeNetwork,
+		},
+		{
+			description: "unknown load balancer type",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						LoadBalancerType: "application",
+					},
+				},
+			},
+			expectedResult: LoadBalancerTypeClassic,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result := LoadBalancerType(tc.customObject)
+			if result != tc.expectedResult {
+				t.Errorf("expected %#q got %#q", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func Test_ExistingVPCSubnets(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		expectedResult []v1alpha1.AWSConfigSpecAWSVPCSubnet
+	}{
+		{
+			description:    "no existing subnets",
+			customObject:   v1alpha1.AWSConfig{},
+			expectedResult: []v1alpha1.AWSConfigSpecAWSVPCSubnet{},
+		},
+		{
+			description: "existing subnets sorted by availability zone",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						VPC: v1alpha1.AWSConfigSpecAWSVPC{
+							ID: "vpc-1",
+							Subnets: []v1alpha1.AWSConfigSpecAWSVPCSubnet{
+								{AvailabilityZone: "eu-central-1c", PrivateSubnetID: "subnet-c-private", PublicSubnetID: "subnet-c-public"},
+								{AvailabilityZone: "eu-central-1a", PrivateSubnetID: "subnet-a-private", PublicSubnetID: "subnet-a-public"},
+							},
+						},
+					},
+				},
+			},
+			expectedResult: []v1alpha1.AWSConfigSpecAWSVPCSubnet{
+				{AvailabilityZone: "eu-central-1a", PrivateSubnetID: "subnet-a-private", PublicSubnetID: "subnet-a-public"},
+				{AvailabilityZone: "eu-central-1c", PrivateSubnetID: "subnet-c-private", PublicSubnetID: "subnet-c-public"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result := ExistingVPCSubnets(tc.customObject)
+			if !reflect.DeepEqual(result, tc.expectedResult) {
+				t.Errorf("expected %#v got %#v", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
 func Test_MainGuestStackName(t *testing.T) {
 	t.Parallel()
 	expected := "cluster-xyz-guest-main"
@@ -1292,6 +1548,76 @@ func Test_MasterCount(t *testing.T) {
 	}
 }
 
+func Test_MasterReplicas(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		customObject     v1alpha1.AWSConfig
+		expectedReplicas int
+	}{
+		{
+			customObject:     v1alpha1.AWSConfig{},
+			expectedReplicas: 1,
+		},
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Masters: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "m3.medium",
+							},
+							{
+								InstanceType: "m3.medium",
+							},
+							{
+								InstanceType: "m3.medium",
+							},
+						},
+					},
+				},
+			},
+			expectedReplicas: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		if MasterReplicas(tc.customObject) != tc.expectedReplicas {
+			t.Fatalf("Expected master replicas %d but was %d", tc.expectedReplicas, MasterReplicas(tc.customObject))
+		}
+	}
+}
+
+func Test_MasterResourceName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		resourceName         string
+		idx                  int
+		expectedResourceName string
+	}{
+		{
+			resourceName:         "EtcdVolume",
+			idx:                  0,
+			expectedResourceName: "EtcdVolume",
+		},
+		{
+			resourceName:         "EtcdVolume",
+			idx:                  1,
+			expectedResourceName: "EtcdVolume01",
+		},
+		{
+			resourceName:         "MasterInstanceTESTCLUSTERABCDE",
+			idx:                  2,
+			expectedResourceName: "MasterInstanceTESTCLUSTERABCDE02",
+		},
+	}
+
+	for _, tc := range tests {
+		if MasterResourceName(tc.resourceName, tc.idx) != tc.expectedResourceName {
+			t.Fatalf("Expected master resource name %s but was %s", tc.expectedResourceName, MasterResourceName(tc.resourceName, tc.idx))
+		}
+	}
+}
+
 func Test_PrivateSubnetCIDR(t *testing.T) {
 	t.Parallel()
 	customObject := v1alpha1.AWSConfig{
@@ -1349,69 +1675,6 @@ func Test_PeerID(t *testing.T) {
 	}
 }
 
-func Test_ImageID(t *testing.T) {
-	t.Parallel()
-	testCases := []struct {
-		description     string
-		customObject    v1alpha1.AWSConfig
-		errorMatcher    func(error) bool
-		expectedImageID string
-	}{
-		{
-			description: "basic match",
-			customObject: v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					AWS: v1alpha1.AWSConfigSpecAWS{
-						Region: "eu-central-1",
-					},
-				},
-			},
-			errorMatcher:    nil,
-			expectedImageID: "ami-015e6cb33a709348e",
-		},
-		{
-			description: "different region",
-			customObject: v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					AWS: v1alpha1.AWSConfigSpecAWS{
-						Region: "eu-west-1",
-					},
-				},
-			},
-			errorMatcher:    nil,
-			expectedImageID: "ami-04d747d892ccd652a",
-		},
-		{
-			description: "invalid region",
-			customObject: v1alpha1.AWSConfig{
-				Spec: v1alpha1.AWSConfigSpec{
-					AWS: v1alpha1.AWSConfigSpecAWS{
-						Region: "invalid-1",
-					},
-				},
-			},
-			errorMatcher: IsInvalidConfig,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.description, func(t *testing.T) {
-			imageID, err := ImageID(tc.customObject)
-			if tc.errorMatcher != nil && err == nil {
-				t.Error("expected error didn't happen")
-			}
-
-			if tc.errorMatcher != nil && !tc.errorMatcher(err) {
-				t.Error("expected", true, "got", false)
-			}
-
-			if tc.expectedImageID != imageID {
-				t.Errorf("unexpected imageID, expecting %q, want %q", tc.expectedImageID, imageID)
-			}
-		})
-	}
-}
-
 func Test_TargetLogBucketName(t *testing.T) {
 	t.Parallel()
 	expectedName := "test-cluster-g8s-access-logs"
@@ -1598,3 +1861,370 @@ func Test_getResourcenameWithTimeHash_Format(t *testing.T) {
 		t.Fatalf("expected %s to not contain dashes", n)
 	}
 }
+
+func Test_NodePoolInstanceTypes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		customObject          v1alpha1.AWSConfig
+		expectedInstanceTypes []string
+	}{
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "m5.xlarge",
+							},
+						},
+					},
+				},
+			},
+			expectedInstanceTypes: []string{"m5.xlarge"},
+		},
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "m5.xlarge",
+							},
+						},
+						WorkerInstanceDistribution: v1alpha1.AWSConfigSpecAWSWorkerInstanceDistribution{
+							InstanceTypes: []string{"m4.xlarge", "m5.xlarge", "m5a.xlarge"},
+						},
+					},
+				},
+			},
+			expectedInstanceTypes: []string{"m5.xlarge", "m4.xlarge", "m5a.xlarge"},
+		},
+	}
+
+	for _, tc := range tests {
+		actual := NodePoolInstanceTypes(WorkerNodePools(tc.customObject)[0])
+		if !reflect.DeepEqual(actual, tc.expectedInstanceTypes) {
+			t.Fatalf("Expected worker instance types %v but was %v", tc.expectedInstanceTypes, actual)
+		}
+	}
+}
+
+func Test_NodePoolLabels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		nodePool       v1alpha1.AWSConfigSpecAWSNodePool
+		expectedLabels string
+	}{
+		{
+			nodePool:       v1alpha1.AWSConfigSpecAWSNodePool{},
+			expectedLabels: "",
+		},
+		{
+			nodePool: v1alpha1.AWSConfigSpecAWSNodePool{
+				Name: "highmem",
+			},
+			expectedLabels: "giantswarm.io/node-pool=highmem",
+		},
+		{
+			nodePool: v1alpha1.AWSConfigSpecAWSNodePool{
+				Name: "ingress",
+				Labels: map[string]string{
+					"role":        "ingress",
+					"example.com": "true",
+				},
+			},
+			expectedLabels: "giantswarm.io/node-pool=ingress,example.com=true,role=ingress",
+		},
+	}
+
+	for _, tc := range tests {
+		actual := NodePoolLabels(tc.nodePool)
+		if actual != tc.expectedLabels {
+			t.Fatalf("Expected node pool labels %q but was %q", tc.expectedLabels, actual)
+		}
+	}
+}
+
+func Test_NodePoolResourceName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		resourceName string
+		nodePool     string
+		expectedName string
+	}{
+		{
+			resourceName: "workerAutoScalingGroup",
+			nodePool:     "",
+			expectedName: "workerAutoScalingGroup",
+		},
+		{
+			resourceName: "workerAutoScalingGroup",
+			nodePool:     "highmem",
+			expectedName: "workerAutoScalingGroupHighmem",
+		},
+		{
+			resourceName: "WorkerASGName",
+			nodePool:     "gp1",
+			expectedName: "WorkerASGNameGp1",
+		},
+	}
+
+	for _, tc := range tests {
+		actual := NodePoolResourceName(tc.resourceName, tc.nodePool)
+		if actual != tc.expectedName {
+			t.Fatalf("Expected node pool resource name %q but was %q", tc.expectedName, actual)
+		}
+	}
+}
+
+func Test_WorkerNodePools(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		customObject      v1alpha1.AWSConfig
+		expectedNodePools []v1alpha1.AWSConfigSpecAWSNodePool
+	}{
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						Scaling: v1alpha1.ClusterScaling{
+							Max: 5,
+							Min: 3,
+						},
+					},
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								DockerVolumeSizeGB: 50,
+								InstanceType:       "m5.xlarge",
+							},
+						},
+					},
+				},
+			},
+			expectedNodePools: []v1alpha1.AWSConfigSpecAWSNodePool{
+				{
+					DockerVolumeSizeGB: 50,
+					InstanceType:       "m5.xlarge",
+					Scaling: v1alpha1.AWSConfigSpecAWSNodePoolScaling{
+						Max: 5,
+						Min: 3,
+					},
+				},
+			},
+		},
+		{
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						NodePools: []v1alpha1.AWSConfigSpecAWSNodePool{
+							{
+								Name:         "general",
+								InstanceType: "m5.xlarge",
+							},
+							{
+								Name:         "highmem",
+								InstanceType: "r5.xlarge",
+							},
+						},
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								InstanceType: "m5.large",
+							},
+						},
+					},
+				},
+			},
+			expectedNodePools: []v1alpha1.AWSConfigSpecAWSNodePool{
+				{
+					Name:         "general",
+					InstanceType: "m5.xlarge",
+				},
+				{
+					Name:         "highmem",
+					InstanceType: "r5.xlarge",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		actual := WorkerNodePools(tc.customObject)
+		if !reflect.DeepEqual(actual, tc.expectedNodePools) {
+			t.Fatalf("Expected worker node pools %#v but was %#v", tc.expectedNodePools, actual)
+		}
+	}
+}
+
+func Test_MasterEtcdVolume(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		expectedResult string
+	}{
+		{
+			description:    "defaults",
+			customObject:   v1alpha1.AWSConfig{},
+			expectedResult: "type=gp2,sizeGB=100,iops=0,throughput=0",
+		},
+		{
+			description: "gp3 with throughput",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						MasterVolumes: v1alpha1.AWSConfigSpecAWSMasterVolumes{
+							Etcd: v1alpha1.AWSConfigSpecAWSVolume{
+								IOPS:       4000,
+								Throughput: 250,
+								Type:       "gp3",
+							},
+						},
+					},
+				},
+			},
+			expectedResult: "type=gp3,sizeGB=100,iops=4000,throughput=250",
+		},
+		{
+			description: "io1 with custom size",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						MasterVolumes: v1alpha1.AWSConfigSpecAWSMasterVolumes{
+							Etcd: v1alpha1.AWSConfigSpecAWSVolume{
+								IOPS:   5000,
+								SizeGB: 200,
+								Type:   "io1",
+							},
+						},
+					},
+				},
+			},
+			expectedResult: "type=io1,sizeGB=200,iops=5000,throughput=0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			result := VolumeOutputValue(MasterEtcdVolume(tc.customObject))
+			if tc.expectedResult != result {
+				t.Errorf("unexpected result, expecting %q, got %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
+
+func Test_NodePoolKubeletVolumeSizeGB(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		description    string
+		customObject   v1alpha1.AWSConfig
+		nodePool       string
+		expectedResult string
+	}{
+		{
+			description:    "no volume sizes configured",
+			customObject:   v1alpha1.AWSConfig{},
+			nodePool:       "",
+			expectedResult: "100",
+		},
+		{
+			description: "docker volume size of the workers",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								DockerVolumeSizeGB: 150,
+							},
+						},
+					},
+				},
+			},
+			nodePool:       "",
+			expectedResult: "150",
+		},
+		{
+			description: "kubelet volume size of the workers",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								DockerVolumeSizeGB:  150,
+								KubeletVolumeSizeGB: 250,
+							},
+						},
+					},
+				},
+			},
+			nodePool:       "",
+			expectedResult: "250",
+		},
+		{
+			description: "kubelet volume size of the node pool",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						NodePools: []v1alpha1.AWSConfigSpecAWSNodePool{
+							{
+								Name:                "default",
+								DockerVolumeSizeGB:  150,
+								KubeletVolumeSizeGB: 200,
+							},
+							{
+								Name:                "highmem",
+								DockerVolumeSizeGB:  150,
+								KubeletVolumeSizeGB: 300,
+							},
+						},
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								KubeletVolumeSizeGB: 250,
+							},
+						},
+					},
+				},
+			},
+			nodePool:       "highmem",
+			expectedResult: "300",
+		},
+		{
+			description: "docker volume size of the node pool",
+			customObject: v1alpha1.AWSConfig{
+				Spec: v1alpha1.AWSConfigSpec{
+					AWS: v1alpha1.AWSConfigSpecAWS{
+						NodePools: []v1alpha1.AWSConfigSpecAWSNodePool{
+							{
+								Name:               "highmem",
+								DockerVolumeSizeGB: 150,
+							},
+						},
+						Workers: []v1alpha1.AWSConfigSpecAWSNode{
+							{
+								KubeletVolumeSizeGB: 250,
+							},
+						},
+					},
+				},
+			},
+			nodePool:       "highmem",
+			expectedResult: "150",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			var result string
+			for _, p := range WorkerNodePools(tc.customObject) {
+				if p.Name == tc.nodePool {
+					result = NodePoolKubeletVolumeSizeGB(p)
+				}
+			}
+
+			if tc.expectedResult != result {
+				t.Errorf("unexpected result, expecting %q, got %q", tc.expectedResult, result)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
@@ -23,60 +24,74 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	workerASGName := cc.Status.TenantCluster.TCCP.ASG.Name
-	if workerASGName == "" {
-		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG name is not available yet")
+	if len(cc.Status.TenantCluster.TCCP.ASGs) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG names are not available yet")
 		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 		return nil
 	}
 
-	var asg *autoscaling.Group
+	asgs := map[string]*autoscaling.Group{}
 	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding ASG %#q", workerASGName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding %d worker ASGs", len(cc.Status.TenantCluster.TCCP.ASGs)))
 
-		i := &autoscaling.DescribeAutoScalingGroupsInput{
-			AutoScalingGroupNames: []*string{
-				&workerASGName,
-			},
+		i := &autoscaling.DescribeAutoScalingGroupsInput{}
+		for _, a := range cc.Status.TenantCluster.TCCP.ASGs {
+			i.AutoScalingGroupNames = append(i.AutoScalingGroupNames, aws.String(a.Name))
 		}
+
 		o, err := cc.Client.TenantCluster.AWS.AutoScaling.DescribeAutoScalingGroups(i)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 
-		if len(o.AutoScalingGroups) != 1 {
-			return microerror.Maskf(executionFailedError, "there must be one item for ASG %#q", workerASGName)
+		for _, g := range o.AutoScalingGroups {
+			asgs[*g.AutoScalingGroupName] = g
 		}
-		asg = o.AutoScalingGroups[0]
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found ASG %#q", workerASGName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d worker ASGs", len(asgs)))
 	}
 
-	var desiredCapacity int
-	{
-		if asg.DesiredCapacity == nil {
-			return microerror.Maskf(executionFailedError, "desired capacity must not be empty for ASG %#q", workerASGName)
+	var totalDesiredCapacity int
+	var desiredCapacities, maxSizes, minSizes []int
+	for _, a := range cc.Status.TenantCluster.TCCP.ASGs {
+		workerASGName := a.Name
+
+		asg, ok := asgs[workerASGName]
+		if !ok {
+			return microerror.Maskf(executionFailedError, "there must be one item for ASG %#q", workerASGName)
 		}
-		desiredCapacity = int(*asg.DesiredCapacity)
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("desired capacity of %#q is %d", workerASGName, desiredCapacity))
-	}
 
-	var maxSize int
-	{
-		if asg.MaxSize == nil {
-			return microerror.Maskf(executionFailedError, "max size must not be empty for ASG %#q", workerASGName)
+		var desiredCapacity int
+		{
+			if asg.DesiredCapacity == nil {
+				return microerror.Maskf(executionFailedError, "desired capacity must not be empty for ASG %#q", workerASGName)
+			}
+			desiredCapacity = int(*asg.DesiredCapacity)
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("desired capacity of %#q is %d", workerASGName, desiredCapacity))
 		}
-		maxSize = int(*asg.MaxSize)
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("max size of %#q is %d", workerASGName, maxSize))
-	}
 
-	var minSize int
-	{
-		if asg.MinSize == nil {
-			return microerror.Maskf(executionFailedError, "min size must not be empty for ASG %#q", workerASGName)
+		var maxSize int
+		{
+			if asg.MaxSize == nil {
+				return microerror.Maskf(executionFailedError, "max size must not be empty for ASG %#q", workerASGName)
+			}
+			maxSize = int(*asg.MaxSize)
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("max size of %#q is %d", workerASGName, maxSize))
+		}
+
+		var minSize int
+		{
+			if asg.MinSize == nil {
+				return microerror.Maskf(executionFailedError, "min size must not be empty for ASG %#q", workerASGName)
+			}
+			minSize = int(*asg.MinSize)
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("min size of %#q is %d", workerASGName, minSize))
 		}
-		minSize = int(*asg.MinSize)
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("min size of %#q is %d", workerASGName, minSize))
+
+		totalDesiredCapacity += desiredCapacity
+		desiredCapacities = append(desiredCapacities, desiredCapacity)
+		maxSizes = append(maxSizes, maxSize)
+		minSizes = append(minSizes, minSize)
 	}
 
 	{
@@ -87,8 +102,8 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			return microerror.Mask(err)
 		}
 
-		if newObj.Status.Cluster.Scaling.DesiredCapacity != desiredCapacity {
-			newObj.Status.Cluster.Scaling.DesiredCapacity = desiredCapacity
+		if newObj.Status.Cluster.Scaling.DesiredCapacity != totalDesiredCapacity {
+			newObj.Status.Cluster.Scaling.DesiredCapacity = totalDesiredCapacity
 			_, err = r.g8sClient.ProviderV1alpha1().AWSConfigs(newObj.GetNamespace()).UpdateStatus(newObj)
 			if err != nil {
 				return microerror.Mask(err)
@@ -105,10 +120,10 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		}
 	}
 
-	{
-		cc.Status.TenantCluster.TCCP.ASG.DesiredCapacity = desiredCapacity
-		cc.Status.TenantCluster.TCCP.ASG.MaxSize = maxSize
-		cc.Status.TenantCluster.TCCP.ASG.MinSize = minSize
+	for i := range cc.Status.TenantCluster.TCCP.ASGs {
+		cc.Status.TenantCluster.TCCP.ASGs[i].DesiredCapacity = desiredCapacities[i]
+		cc.Status.TenantCluster.TCCP.ASGs[i].MaxSize = maxSizes[i]
+		cc.Status.TenantCluster.TCCP.ASGs[i].MinSize = minSizes[i]
 	}
 
 	return nil
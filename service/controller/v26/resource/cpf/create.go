@@ -2,6 +2,8 @@ package cpf
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -24,14 +26,16 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	{
-		if cc.Status.TenantCluster.TCCP.VPC.PeeringConnectionID == "" {
-			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the VPC Peering Connection ID in the controller context")
-			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
-			return nil
-		}
+	// Tenant clusters not yet attached to the configured Transit Gateway keep
+	// routing the control plane traffic through their VPC peering connection
+	// until the update of their TCCP stack migrated them.
+	if cc.Status.TenantCluster.TCCP.VPC.TransitGatewayAttachmentID == "" && cc.Status.TenantCluster.TCCP.VPC.PeeringConnectionID == "" {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the Transit Gateway Attachment ID or the VPC Peering Connection ID in the controller context")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		return nil
 	}
 
+	var stackExists bool
 	{
 		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the tenant cluster's control plane finalizer cloud formation stack")
 
@@ -50,16 +54,44 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			return microerror.Maskf(executionFailedError, "expected one stack, got %d", len(o.Stacks))
 
 		} else if *o.Stacks[0].StackStatus == cloudformation.StackStatusCreateFailed {
-			return microerror.Maskf(executionFailedError, "expected successful status, got %#q", o.Stacks[0].StackStatus)
+			return microerror.Maskf(executionFailedError, "expected successful status, got %#q", *o.Stacks[0].StackStatus)
 
-		} else {
-			r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster's control plane finalizer cloud formation stack already exists")
+		} else if strings.HasSuffix(*o.Stacks[0].StackStatus, "_IN_PROGRESS") {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the tenant cluster's control plane finalizer cloud formation stack in status %#q", *o.Stacks[0].StackStatus))
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 
 			return nil
+
+		} else {
+			stackExists = true
+		}
+
+		if stackExists {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster's control plane finalizer cloud formation stack already exists")
+		} else {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster's control plane finalizer cloud formation stack")
+		}
+	}
+
+	var currentRoutes []template.ParamsMainRouteTablesRoute
+	if stackExists {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the routes of the tenant cluster's control plane finalizer cloud formation stack")
+
+		i := &cloudformation.GetTemplateInput{
+			StackName: aws.String(key.MainHostPostStackName(cr)),
+		}
+
+		o, err := cc.Client.ControlPlane.AWS.CloudFormation.GetTemplate(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		currentRoutes, err = routesFromTemplate(*o.TemplateBody)
+		if err != nil {
+			return microerror.Mask(err)
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster's control plane finalizer cloud formation stack")
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d routes of the tenant cluster's control plane finalizer cloud formation stack", len(currentRoutes)))
 	}
 
 	var templateBody string
@@ -83,6 +115,22 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			}
 		}
 
+		var added int
+		{
+			added += nameRoutes(params.RouteTables.PrivateRoutes, currentRoutes, privateRoutePrefix)
+			added += nameRoutes(params.RouteTables.PublicRoutes, currentRoutes, publicRoutePrefix)
+		}
+
+		// The control plane finalizer stack is only updated in order to add
+		// routes, e.g. when availability zones got added to the tenant
+		// cluster. Updating the stack for other reasons is not supported.
+		if stackExists && added == 0 {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster's control plane finalizer cloud formation stack does not need to be updated")
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+			return nil
+		}
+
 		templateBody, err = template.Render(params)
 		if err != nil {
 			return microerror.Mask(err)
@@ -91,6 +139,27 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "computed the template of the tenant cluster's control plane finalizer cloud formation stack")
 	}
 
+	if stackExists {
+		err = r.updateStack(ctx, cr, templateBody)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	} else {
+		err = r.createStack(ctx, cr, templateBody)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Resource) createStack(ctx context.Context, cr v1alpha1.AWSConfig, templateBody string) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	{
 		r.logger.LogCtx(ctx, "level", "debug", "message", "requesting the creation of the tenant cluster's control plane finalizer cloud formation stack")
 
@@ -127,6 +196,47 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
+func (r *Resource) updateStack(ctx context.Context, cr v1alpha1.AWSConfig, templateBody string) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "requesting the update of the tenant cluster's control plane finalizer cloud formation stack")
+
+		i := &cloudformation.UpdateStackInput{
+			StackName:    aws.String(key.MainHostPostStackName(cr)),
+			Tags:         r.getCloudFormationTags(cr),
+			TemplateBody: aws.String(templateBody),
+		}
+
+		_, err = cc.Client.ControlPlane.AWS.CloudFormation.UpdateStack(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "requested the update of the tenant cluster's control plane finalizer cloud formation stack")
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "waiting for the update of the tenant cluster's control plane finalizer cloud formation stack")
+
+		i := &cloudformation.DescribeStacksInput{
+			StackName: aws.String(key.MainHostPostStackName(cr)),
+		}
+
+		err = cc.Client.ControlPlane.AWS.CloudFormation.WaitUntilStackUpdateComplete(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "waited for the update of the tenant cluster's control plane finalizer cloud formation stack")
+	}
+
+	return nil
+}
+
 func (r *Resource) newPrivateRoutes(ctx context.Context, cr v1alpha1.AWSConfig) ([]template.ParamsMainRouteTablesRoute, error) {
 	cc, err := controllercontext.FromContext(ctx)
 	if err != nil {
@@ -152,6 +262,7 @@ func (r *Resource) newPrivateRoutes(ctx context.Context, cr v1alpha1.AWSConfig)
 				// The peer connection id is fetched from the cloud formation stack
 				// outputs in the stackoutput resource.
 				PeerConnectionID: cc.Status.TenantCluster.TCCP.VPC.PeeringConnectionID,
+				TransitGatewayID: r.routedTransitGatewayID(cc),
 			}
 
 			routes = append(routes, route)
@@ -173,23 +284,42 @@ func (r *Resource) newPublicRoutes(ctx context.Context, cr v1alpha1.AWSConfig) (
 
 	var routes []template.ParamsMainRouteTablesRoute
 
+	// Secondary CIDR blocks of the tenant cluster's VPC are routed as well, so
+	// that the subnets of added availability zones can access Vault's ELB.
+	cidrBlocks := append([]string{key.StatusNetworkCIDR(cr)}, key.StatusNetworkSecondaryCIDRs(cr)...)
+
 	for _, id := range cc.Status.ControlPlane.RouteTable.Mappings {
-		route := template.ParamsMainRouteTablesRoute{
-			RouteTableID: id,
-			// Requester CIDR block, we create the peering connection from the
-			// tenant's CIDR for being able to access Vault's ELB.
-			CidrBlock: key.StatusNetworkCIDR(cr),
-			// The peer connection id is fetched from the cloud formation stack
-			// outputs in the stackoutput resource.
-			PeerConnectionID: cc.Status.TenantCluster.TCCP.VPC.PeeringConnectionID,
-		}
+		for _, cidrBlock := range cidrBlocks {
+			route := template.ParamsMainRouteTablesRoute{
+				RouteTableID: id,
+				// Requester CIDR block, we create the peering connection from the
+				// tenant's CIDR for being able to access Vault's ELB.
+				CidrBlock: cidrBlock,
+				// The peer connection id is fetched from the cloud formation stack
+				// outputs in the stackoutput resource.
+				PeerConnectionID: cc.Status.TenantCluster.TCCP.VPC.PeeringConnectionID,
+				TransitGatewayID: r.routedTransitGatewayID(cc),
+			}
 
-		routes = append(routes, route)
+			routes = append(routes, route)
+		}
 	}
 
 	return routes, nil
 }
 
+// routedTransitGatewayID returns the ID of the Transit Gateway the control
+// plane routes to the tenant cluster use. It is empty for tenant clusters
+// which are not yet attached to the Transit Gateway, so that their routes keep
+// using the VPC peering connection.
+func (r *Resource) routedTransitGatewayID(cc *controllercontext.Context) string {
+	if cc.Status.TenantCluster.TCCP.VPC.TransitGatewayAttachmentID == "" {
+		return ""
+	}
+
+	return r.transitGatewayID
+}
+
 func (r *Resource) newRecordSetsParams(ctx context.Context, cr v1alpha1.AWSConfig) (*template.ParamsMainRecordSets, error) {
 	cc, err := controllercontext.FromContext(ctx)
 	if err != nil {
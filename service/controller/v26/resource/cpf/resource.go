@@ -21,6 +21,10 @@ type Config struct {
 	EncrypterBackend string
 	InstallationName string
 	Route53Enabled   bool
+	// TransitGatewayID is the ID of the Transit Gateway the control plane routes
+	// to the tenant cluster point to. When empty, the routes point to the VPC
+	// peering connection of the tenant cluster.
+	TransitGatewayID string
 }
 
 // Resource implements the CPF resource, which stands for Control Plane
@@ -32,6 +36,7 @@ type Resource struct {
 	encrypterBackend string
 	installationName string
 	route53Enabled   bool
+	transitGatewayID string
 }
 
 func New(config Config) (*Resource, error) {
@@ -49,6 +54,7 @@ func New(config Config) (*Resource, error) {
 		encrypterBackend: config.EncrypterBackend,
 		installationName: config.InstallationName,
 		route53Enabled:   config.Route53Enabled,
+		transitGatewayID: config.TransitGatewayID,
 	}
 
 	return r, nil
@@ -0,0 +1,107 @@
+package cpf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/resource/cpf/template"
+)
+
+const (
+	privateRoutePrefix = "PrivateRoute"
+	publicRoutePrefix  = "PublicRoute"
+)
+
+const (
+	routeResourceType = "AWS::EC2::Route"
+)
+
+type cloudFormationTemplate struct {
+	Resources map[string]cloudFormationResource `yaml:"Resources"`
+}
+
+type cloudFormationResource struct {
+	Type       string                           `yaml:"Type"`
+	Properties cloudFormationResourceProperties `yaml:"Properties"`
+}
+
+type cloudFormationResourceProperties struct {
+	DestinationCidrBlock string `yaml:"DestinationCidrBlock"`
+	RouteTableID         string `yaml:"RouteTableId"`
+}
+
+// nameRoutes sets the logical IDs of the given routes. Routes already defined
+// in the current template of the stack keep their logical IDs, because Cloud
+// Formation would otherwise try to create them a second time when updating the
+// stack. All other routes get the lowest free index for the given prefix. The
+// number of routes not yet defined in the current template is returned.
+func nameRoutes(routes []template.ParamsMainRouteTablesRoute, current []template.ParamsMainRouteTablesRoute, prefix string) int {
+	used := map[string]bool{}
+	for _, c := range current {
+		used[c.Name] = true
+	}
+
+	var added int
+	var next int
+	for i, r := range routes {
+		name := currentRouteName(current, r, prefix)
+
+		if name == "" {
+			for used[fmt.Sprintf("%s%d", prefix, next)] {
+				next++
+			}
+
+			name = fmt.Sprintf("%s%d", prefix, next)
+			used[name] = true
+			added++
+		}
+
+		routes[i].Name = name
+	}
+
+	return added
+}
+
+// routesFromTemplate returns the routes defined in the given template body of
+// the control plane finalizer stack.
+func routesFromTemplate(templateBody string) ([]template.ParamsMainRouteTablesRoute, error) {
+	var t cloudFormationTemplate
+	err := yaml.Unmarshal([]byte(templateBody), &t)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var routes []template.ParamsMainRouteTablesRoute
+	for name, r := range t.Resources {
+		if r.Type != routeResourceType {
+			continue
+		}
+
+		route := template.ParamsMainRouteTablesRoute{
+			Name:         name,
+			RouteTableID: r.Properties.RouteTableID,
+			CidrBlock:    r.Properties.DestinationCidrBlock,
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+func currentRouteName(current []template.ParamsMainRouteTablesRoute, route template.ParamsMainRouteTablesRoute, prefix string) string {
+	for _, c := range current {
+		if !strings.HasPrefix(c.Name, prefix) {
+			continue
+		}
+
+		if c.RouteTableID == route.RouteTableID && c.CidrBlock == route.CidrBlock {
+			return c.Name
+		}
+	}
+
+	return ""
+}
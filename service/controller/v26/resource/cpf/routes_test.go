@@ -0,0 +1,137 @@
+package cpf
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/resource/cpf/template"
+)
+
+func Test_nameRoutes(t *testing.T) {
+	testCases := []struct {
+		name          string
+		routes        []template.ParamsMainRouteTablesRoute
+		current       []template.ParamsMainRouteTablesRoute
+		expectedNames []string
+		expectedAdded int
+	}{
+		{
+			name: "case 0: routes of new stack get sequential names",
+			routes: []template.ParamsMainRouteTablesRoute{
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/24"},
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.1.0/24"},
+			},
+			current:       nil,
+			expectedNames: []string{"PrivateRoute0", "PrivateRoute1"},
+			expectedAdded: 2,
+		},
+		{
+			name: "case 1: existing routes keep their names in any order",
+			routes: []template.ParamsMainRouteTablesRoute{
+				{RouteTableID: "rtb-2", CidrBlock: "10.1.0.0/24"},
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/24"},
+			},
+			current: []template.ParamsMainRouteTablesRoute{
+				{Name: "PrivateRoute0", RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/24"},
+				{Name: "PrivateRoute1", RouteTableID: "rtb-2", CidrBlock: "10.1.0.0/24"},
+			},
+			expectedNames: []string{"PrivateRoute1", "PrivateRoute0"},
+			expectedAdded: 0,
+		},
+		{
+			name: "case 2: added routes get names not used in the current template",
+			routes: []template.ParamsMainRouteTablesRoute{
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.2.0/24"},
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/24"},
+				{RouteTableID: "rtb-2", CidrBlock: "10.1.2.0/24"},
+			},
+			current: []template.ParamsMainRouteTablesRoute{
+				{Name: "PrivateRoute0", RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/24"},
+				{Name: "PrivateRoute1", RouteTableID: "rtb-1", CidrBlock: "10.1.1.0/24"},
+				{Name: "PublicRoute0", RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/16"},
+			},
+			expectedNames: []string{"PrivateRoute2", "PrivateRoute0", "PrivateRoute3"},
+			expectedAdded: 2,
+		},
+		{
+			name: "case 3: routes of other prefixes are not matched",
+			routes: []template.ParamsMainRouteTablesRoute{
+				{RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/16"},
+			},
+			current: []template.ParamsMainRouteTablesRoute{
+				{Name: "PublicRoute0", RouteTableID: "rtb-1", CidrBlock: "10.1.0.0/16"},
+			},
+			expectedNames: []string{"PrivateRoute0"},
+			expectedAdded: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			added := nameRoutes(tc.routes, tc.current, privateRoutePrefix)
+
+			var names []string
+			for _, r := range tc.routes {
+				names = append(names, r.Name)
+			}
+
+			if !reflect.DeepEqual(names, tc.expectedNames) {
+				t.Fatalf("expected %#v got %#v", tc.expectedNames, names)
+			}
+			if added != tc.expectedAdded {
+				t.Fatalf("expected %d got %d", tc.expectedAdded, added)
+			}
+		})
+	}
+}
+
+func Test_routesFromTemplate(t *testing.T) {
+	var expected []template.ParamsMainRouteTablesRoute
+	for i := 0; i < 3; i++ {
+		route := template.ParamsMainRouteTablesRoute{
+			Name:         privateRoutePrefix + strconv.Itoa(i),
+			RouteTableID: "rtb-1",
+			CidrBlock:    "10.1." + strconv.Itoa(i) + ".0/24",
+		}
+
+		expected = append(expected, route)
+	}
+	expected = append(expected, template.ParamsMainRouteTablesRoute{
+		Name:         publicRoutePrefix + "0",
+		RouteTableID: "rtb-1",
+		CidrBlock:    "10.1.0.0/16",
+	})
+
+	params := &template.ParamsMain{
+		RecordSets: &template.ParamsMainRecordSets{
+			BaseDomain:                 "example.com",
+			ClusterID:                  "al9qy",
+			GuestHostedZoneNameServers: "ns1,ns2",
+			Route53Enabled:             true,
+		},
+		RouteTables: &template.ParamsMainRouteTables{
+			PrivateRoutes: expected[:3],
+			PublicRoutes:  expected[3:],
+		},
+	}
+
+	templateBody, err := template.Render(params)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	routes, err := routesFromTemplate(templateBody)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Name < routes[j].Name
+	})
+
+	if !reflect.DeepEqual(routes, expected) {
+		t.Fatalf("expected %#v got %#v", expected, routes)
+	}
+}
@@ -6,7 +6,12 @@ type ParamsMainRouteTables struct {
 }
 
 type ParamsMainRouteTablesRoute struct {
+	// Name is the logical ID of the route within the Cloud Formation stack.
+	Name             string
 	RouteTableID     string
 	CidrBlock        string
 	PeerConnectionID string
+	// TransitGatewayID is the ID of the Transit Gateway the route points to. It
+	// takes precedence over PeerConnectionID.
+	TransitGatewayID string
 }
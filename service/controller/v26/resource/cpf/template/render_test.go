@@ -17,11 +17,13 @@ func Test_Controller_Resource_CPF_Template_Render(t *testing.T) {
 		routeTables := &ParamsMainRouteTables{
 			PrivateRoutes: []ParamsMainRouteTablesRoute{
 				ParamsMainRouteTablesRoute{
+					Name:             "PrivateRoute0",
 					PeerConnectionID: "PeerConnectionID",
 				},
 			},
 			PublicRoutes: []ParamsMainRouteTablesRoute{
 				ParamsMainRouteTablesRoute{
+					Name:             "PublicRoute0",
 					PeerConnectionID: "PeerConnectionID",
 				},
 			},
@@ -62,3 +64,50 @@ func Test_Controller_Resource_CPF_Template_Render(t *testing.T) {
 		}
 	}
 }
+
+func Test_Controller_Resource_CPF_Template_Render_TransitGateway(t *testing.T) {
+	var err error
+
+	var params *ParamsMain
+	{
+		recordSets := &ParamsMainRecordSets{
+			BaseDomain:     "BaseDomain",
+			Route53Enabled: true,
+		}
+		routeTables := &ParamsMainRouteTables{
+			PrivateRoutes: []ParamsMainRouteTablesRoute{
+				ParamsMainRouteTablesRoute{
+					Name:             "PrivateRoute0",
+					TransitGatewayID: "TransitGatewayID",
+				},
+			},
+		}
+
+		params = &ParamsMain{
+			RecordSets:  recordSets,
+			RouteTables: routeTables,
+		}
+	}
+
+	var templateBody string
+	{
+		templateBody, err = Render(params)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	{
+		expected := "TransitGatewayId: TransitGatewayID"
+		if !strings.Contains(templateBody, expected) {
+			t.Fatal("expected", "match", "got", "none")
+		}
+	}
+
+	{
+		unexpected := "VpcPeeringConnectionId"
+		if strings.Contains(templateBody, unexpected) {
+			t.Fatal("expected", "none", "got", "match")
+		}
+	}
+}
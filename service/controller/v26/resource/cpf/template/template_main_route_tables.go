@@ -2,22 +2,30 @@ package template
 
 const TemplateMainRouteTables = `
 {{ define "route_tables" }}
-  {{ range $i, $r := .RouteTables.PrivateRoutes }}
-  PrivateRoute{{$i}}:
+  {{ range $r := .RouteTables.PrivateRoutes }}
+  {{ $r.Name }}:
     Type: AWS::EC2::Route
     Properties:
       RouteTableId: {{$r.RouteTableID}}
       DestinationCidrBlock: {{$r.CidrBlock}}
+      {{- if $r.TransitGatewayID }}
+      TransitGatewayId: {{$r.TransitGatewayID}}
+      {{- else }}
       VpcPeeringConnectionId: {{$r.PeerConnectionID}}
+      {{- end }}
   {{end}}
 
-  {{ range $i, $r := .RouteTables.PublicRoutes }}
-  PublicRoute{{$i}}:
+  {{ range $r := .RouteTables.PublicRoutes }}
+  {{ $r.Name }}:
     Type: AWS::EC2::Route
     Properties:
       RouteTableId: {{$r.RouteTableID}}
       DestinationCidrBlock: {{$r.CidrBlock}}
+      {{- if $r.TransitGatewayID }}
+      TransitGatewayId: {{$r.TransitGatewayID}}
+      {{- else }}
       VpcPeeringConnectionId: {{$r.PeerConnectionID}}
+      {{- end }}
   {{ end }}
 {{ end }}
 `
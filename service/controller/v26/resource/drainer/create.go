@@ -10,6 +10,7 @@ import (
 	corev1alpha1 "github.com/giantswarm/apiextensions/pkg/apis/core/v1alpha1"
 	providerv1alpha1 "github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -30,21 +31,22 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	workerASGName := cc.Status.TenantCluster.TCCP.ASG.Name
-	if workerASGName == "" {
-		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG name is not available yet")
+	if len(cc.Status.TenantCluster.TCCP.ASGs) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG names are not available yet")
 		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 		return nil
 	}
 
+	// instanceASGs maps the IDs of the instances being in state
+	// Terminating:Wait to the names of the node pool ASGs they belong to.
 	var instances []*autoscaling.Instance
+	instanceASGs := map[string]string{}
 	{
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding the guest cluster nodes being in state %#q", autoscaling.LifecycleStateTerminatingWait))
 
-		i := &autoscaling.DescribeAutoScalingGroupsInput{
-			AutoScalingGroupNames: []*string{
-				aws.String(workerASGName),
-			},
+		i := &autoscaling.DescribeAutoScalingGroupsInput{}
+		for _, a := range cc.Status.TenantCluster.TCCP.ASGs {
+			i.AutoScalingGroupNames = append(i.AutoScalingGroupNames, aws.String(a.Name))
 		}
 
 		o, err := cc.Client.TenantCluster.AWS.AutoScaling.DescribeAutoScalingGroups(i)
@@ -56,6 +58,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			for _, i := range g.Instances {
 				if *i.LifecycleState == autoscaling.LifecycleStateTerminatingWait {
 					instances = append(instances, i)
+					instanceASGs[*i.InstanceId] = *g.AutoScalingGroupName
 				}
 			}
 		}
@@ -98,7 +101,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			if errors.IsNotFound(err) {
 				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not find drainer config for guest cluster node %#q", *instance.InstanceId))
 
-				err := r.createDrainerConfig(ctx, customObject, *instance.InstanceId, privateDNS)
+				err := r.createDrainerConfig(ctx, customObject, *instance.InstanceId, instanceASGs[*instance.InstanceId], privateDNS)
 				if err != nil {
 					return microerror.Mask(err)
 				}
@@ -116,14 +119,15 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	return nil
 }
 
-func (r *Resource) createDrainerConfig(ctx context.Context, customObject providerv1alpha1.AWSConfig, instanceID, privateDNS string) error {
+func (r *Resource) createDrainerConfig(ctx context.Context, customObject providerv1alpha1.AWSConfig, instanceID, asgName, privateDNS string) error {
 	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("creating drainer config for guest cluster nodes %#q", instanceID))
 
 	n := customObject.GetNamespace()
 	c := &corev1alpha1.DrainerConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Annotations: map[string]string{
-				key.InstanceIDAnnotation: instanceID,
+				key.AutoScalingGroupAnnotation: asgName,
+				key.InstanceIDAnnotation:       instanceID,
 			},
 			Labels: map[string]string{
 				key.ClusterIDLabel: key.ClusterID(customObject),
@@ -154,6 +158,9 @@ func (r *Resource) createDrainerConfig(ctx context.Context, customObject provide
 	}
 
 	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("created drainer config for guest cluster node %#q", instanceID))
+
+	r.eventRecorder.Emit(ctx, &customObject, corev1.EventTypeNormal, key.EventReasonDrainStarted, fmt.Sprintf("started draining node %#q of instance %#q in ASG %#q", privateDNS, instanceID, asgName))
+
 	return nil
 }
 
@@ -28,9 +28,8 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	workerASGName := cc.Status.TenantCluster.TCCP.ASG.Name
-	if workerASGName == "" {
-		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG name is not available yet")
+	if len(cc.Status.TenantCluster.TCCP.ASGs) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "worker ASG names are not available yet")
 		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 		return nil
 	}
@@ -84,6 +83,17 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 				return microerror.Mask(err)
 			}
 
+			// Drainer configs created before node pools existed do not carry the
+			// ASG annotation. Their instances belong to the unnamed node pool.
+			workerASGName := drainerConfig.GetAnnotations()[key.AutoScalingGroupAnnotation]
+			if workerASGName == "" {
+				a, ok := cc.Status.TenantCluster.TCCP.NodePoolASG("")
+				if !ok {
+					return microerror.Maskf(missingAnnotationError, key.AutoScalingGroupAnnotation)
+				}
+				workerASGName = a.Name
+			}
+
 			err = r.completeLifecycleHook(ctx, instanceID, workerASGName)
 			if err != nil {
 				return microerror.Mask(err)
@@ -93,6 +103,13 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			if err != nil {
 				return microerror.Mask(err)
 			}
+
+			nodeName := drainerConfig.Spec.Guest.Node.Name
+			if drainerConfig.Status.HasTimeoutCondition() {
+				r.eventRecorder.Emit(ctx, &customObject, v1.EventTypeWarning, key.EventReasonDrainTimedOut, fmt.Sprintf("draining node %#q of instance %#q timed out", nodeName, instanceID))
+			} else {
+				r.eventRecorder.Emit(ctx, &customObject, v1.EventTypeNormal, key.EventReasonDrainFinished, fmt.Sprintf("finished draining node %#q of instance %#q", nodeName, instanceID))
+			}
 		}
 
 		r.logger.LogCtx(ctx, "level", "debug", "message", "ensured finised draining for drained nodes")
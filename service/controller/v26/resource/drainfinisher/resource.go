@@ -4,6 +4,8 @@ import (
 	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder"
 )
 
 const (
@@ -11,16 +13,21 @@ const (
 )
 
 type ResourceConfig struct {
-	G8sClient versioned.Interface
-	Logger    micrologger.Logger
+	EventRecorder recorder.Interface
+	G8sClient     versioned.Interface
+	Logger        micrologger.Logger
 }
 
 type Resource struct {
-	g8sClient versioned.Interface
-	logger    micrologger.Logger
+	eventRecorder recorder.Interface
+	g8sClient     versioned.Interface
+	logger        micrologger.Logger
 }
 
 func NewResource(config ResourceConfig) (*Resource, error) {
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
 	if config.G8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
 	}
@@ -29,8 +36,9 @@ func NewResource(config ResourceConfig) (*Resource, error) {
 	}
 
 	newResource := &Resource{
-		g8sClient: config.G8sClient,
-		logger:    config.Logger,
+		eventRecorder: config.EventRecorder,
+		g8sClient:     config.G8sClient,
+		logger:        config.Logger,
 	}
 
 	return newResource, nil
@@ -5,7 +5,10 @@ import (
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/finalizerskeptcontext"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/ebs"
@@ -13,7 +16,15 @@ import (
 )
 
 // EnsureDeleted detaches and deletes the EBS volumes. We don't return
-// errors so deletion logic in following resources is executed.
+// errors so deletion logic in following resources is executed. The EBS volumes
+// of persistent volumes are handled according to the deletion policy of the
+// tenant cluster. With the Snapshot policy they are deleted once a snapshot
+// of them completed. The snapshots are not waited for. Instead the finalizer
+// is kept until they completed, so that their completion is checked in the
+// following reconciliations. With the Retain policy they are kept and tagged
+// as orphaned. Persistent volumes which could not be snapshotted are retained.
+// The pending snapshots as well as the kept persistent volumes and snapshots
+// are reported in events.
 func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	cr, err := key.ToCustomObject(obj)
 	if err != nil {
@@ -79,18 +90,153 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 			}
 		}
 
-		// Now delete the volumes.
+		// Now keep the persistent volumes according to the deletion policy and
+		// delete the remaining volumes.
+		kept := r.keepPersistentVolumes(ctx, cr, ebsService, volumes)
+
+		var deleted int
 		for _, vol := range volumes {
+			if kept[vol.VolumeID] {
+				continue
+			}
+
 			err := ebsService.DeleteVolume(ctx, vol.VolumeID)
 			if err != nil {
 				r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("failed to delete EBS volume %s", vol.VolumeID), "stack", fmt.Sprintf("%#v", err))
+				continue
 			}
+
+			deleted++
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted %d EBS volumes", len(volumes)))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted %d EBS volumes", deleted))
 	} else {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "not deleting EBS volumes because there aren't any")
 	}
 
 	return nil
 }
+
+// keepPersistentVolumes snapshots or orphans the EBS volumes of persistent
+// volumes according to the deletion policy of the tenant cluster and returns
+// the IDs of the volumes which must not be deleted. Unknown deletion policies
+// are treated like the Retain policy, so that no data gets lost by accident.
+// Volumes with pending snapshots are kept as well, until their snapshots
+// completed in one of the following reconciliations. Failed snapshots are
+// created again.
+func (r *Resource) keepPersistentVolumes(ctx context.Context, cr v1alpha1.AWSConfig, ebsService ebs.Interface, volumes []ebs.Volume) map[string]bool {
+	policy := key.PersistentVolumeDeletionPolicy(cr)
+	if policy == key.PersistentVolumeDeletionPolicyDelete {
+		return nil
+	}
+	if policy != key.PersistentVolumeDeletionPolicySnapshot && policy != key.PersistentVolumeDeletionPolicyRetain {
+		r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("unknown persistent volume deletion policy %#q, retaining persistent volumes", policy))
+		policy = key.PersistentVolumeDeletionPolicyRetain
+	}
+
+	kept := map[string]bool{}
+
+	var existing []ebs.Snapshot
+	if policy == key.PersistentVolumeDeletionPolicySnapshot {
+		var err error
+		existing, err = ebsService.ListPersistentVolumeSnapshots(cr)
+		if err != nil {
+			// Without knowing the existing snapshots all persistent volumes
+			// are kept untouched, so that they are handled in the next
+			// reconciliation.
+			r.logger.LogCtx(ctx, "level", "warning", "message", "failed to list snapshots of persistent volumes, keeping persistent volumes", "stack", fmt.Sprintf("%#v", err))
+			r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+			finalizerskeptcontext.SetKept(ctx)
+
+			for _, vol := range volumes {
+				if ebs.IsPersistentVolume(vol) {
+					kept[vol.VolumeID] = true
+				}
+			}
+
+			return kept
+		}
+	}
+
+	var created []string
+	var pending []string
+	var retained []string
+	var snapshots []string
+
+	for _, vol := range volumes {
+		if !ebs.IsPersistentVolume(vol) {
+			continue
+		}
+
+		if policy == key.PersistentVolumeDeletionPolicySnapshot {
+			s, ok := volumeSnapshot(existing, vol.VolumeID)
+			if ok && s.State == ec2.SnapshotStateCompleted {
+				snapshots = append(snapshots, s.SnapshotID)
+				continue
+			}
+			if ok && s.State == ec2.SnapshotStatePending {
+				kept[vol.VolumeID] = true
+				pending = append(pending, s.SnapshotID)
+				continue
+			}
+
+			snapshotID, err := ebsService.SnapshotPersistentVolume(ctx, cr, vol)
+			if err != nil {
+				r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("failed to snapshot EBS volume %s, retaining it", vol.VolumeID), "stack", fmt.Sprintf("%#v", err))
+			} else {
+				kept[vol.VolumeID] = true
+				created = append(created, snapshotID)
+				pending = append(pending, snapshotID)
+				continue
+			}
+		}
+
+		// The volume is kept in any case, even if orphaning it fails. It is
+		// then still tagged as owned by the deleted tenant cluster, which is
+		// preferable to losing its data.
+		kept[vol.VolumeID] = true
+		retained = append(retained, vol.VolumeID)
+
+		err := ebsService.OrphanVolume(ctx, cr, vol.VolumeID)
+		if err != nil {
+			r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("failed to orphan EBS volume %s", vol.VolumeID), "stack", fmt.Sprintf("%#v", err))
+		}
+	}
+
+	if len(created) > 0 {
+		m := fmt.Sprintf("created EBS snapshots %#q of persistent volumes according to deletion policy %#q, deleting the persistent volumes once the snapshots completed", created, policy)
+		r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonPersistentVolumesKept, m)
+	}
+	if len(pending) > 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("EBS snapshots %#q of persistent volumes are pending", pending))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+		finalizerskeptcontext.SetKept(ctx)
+	}
+	if len(retained) > 0 || len(snapshots) > 0 {
+		m := fmt.Sprintf("kept persistent volumes according to deletion policy %#q: retained EBS volumes %#q, completed EBS snapshots %#q", policy, retained, snapshots)
+		r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonPersistentVolumesKept, m)
+	}
+
+	return kept
+}
+
+// volumeSnapshot returns the most recent snapshot of the given EBS volume
+// which did not fail.
+func volumeSnapshot(snapshots []ebs.Snapshot, volumeID string) (ebs.Snapshot, bool) {
+	var snapshot ebs.Snapshot
+	var ok bool
+
+	for _, s := range snapshots {
+		if s.VolumeID != volumeID || s.State == ec2.SnapshotStateError {
+			continue
+		}
+		if ok && !s.StartTime.After(snapshot.StartTime) {
+			continue
+		}
+
+		snapshot = s
+		ok = true
+	}
+
+	return snapshot, ok
+}
@@ -0,0 +1,169 @@
+package ebsvolume
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/controller/context/finalizerskeptcontext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+	"github.com/giantswarm/aws-operator/service/controller/v26/ebs"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// ebsServiceMock answers the listing of persistent volume snapshots with the
+// configured snapshots and records the created snapshots and orphaned
+// volumes.
+type ebsServiceMock struct {
+	ebs.Interface
+
+	snapshots []ebs.Snapshot
+
+	created  []string
+	orphaned []string
+}
+
+func (e *ebsServiceMock) ListPersistentVolumeSnapshots(customObject v1alpha1.AWSConfig) ([]ebs.Snapshot, error) {
+	return e.snapshots, nil
+}
+
+func (e *ebsServiceMock) OrphanVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volumeID string) error {
+	e.orphaned = append(e.orphaned, volumeID)
+	return nil
+}
+
+func (e *ebsServiceMock) SnapshotPersistentVolume(ctx context.Context, customObject v1alpha1.AWSConfig, volume ebs.Volume) (string, error) {
+	id := fmt.Sprintf("snap-%d", len(e.created))
+	e.created = append(e.created, id)
+
+	return id, nil
+}
+
+func Test_Resource_keepPersistentVolumes(t *testing.T) {
+	volumes := []ebs.Volume{
+		{
+			VolumeID: "vol-docker",
+		},
+		{
+			VolumeID: "vol-pv",
+			Tags: map[string]string{
+				"kubernetes.io/created-for/pv/name": "pvc-1",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name                   string
+		policy                 string
+		snapshots              []ebs.Snapshot
+		expectedKept           bool
+		expectedCreated        int
+		expectedOrphaned       int
+		expectedFinalizersKept bool
+	}{
+		{
+			name:                   "case 0: persistent volume is deleted with the Delete policy",
+			policy:                 key.PersistentVolumeDeletionPolicyDelete,
+			expectedKept:           false,
+			expectedFinalizersKept: false,
+		},
+		{
+			name:                   "case 1: persistent volume is orphaned with the Retain policy",
+			policy:                 key.PersistentVolumeDeletionPolicyRetain,
+			expectedKept:           true,
+			expectedOrphaned:       1,
+			expectedFinalizersKept: false,
+		},
+		{
+			name:                   "case 2: snapshot is created without waiting for it",
+			policy:                 key.PersistentVolumeDeletionPolicySnapshot,
+			expectedKept:           true,
+			expectedCreated:        1,
+			expectedFinalizersKept: true,
+		},
+		{
+			name:   "case 3: persistent volume is kept while its snapshot is pending",
+			policy: key.PersistentVolumeDeletionPolicySnapshot,
+			snapshots: []ebs.Snapshot{
+				{
+					SnapshotID: "snap-pending",
+					State:      ec2.SnapshotStatePending,
+					VolumeID:   "vol-pv",
+				},
+			},
+			expectedKept:           true,
+			expectedFinalizersKept: true,
+		},
+		{
+			name:   "case 4: persistent volume is deleted once its snapshot completed",
+			policy: key.PersistentVolumeDeletionPolicySnapshot,
+			snapshots: []ebs.Snapshot{
+				{
+					SnapshotID: "snap-completed",
+					State:      ec2.SnapshotStateCompleted,
+					VolumeID:   "vol-pv",
+				},
+			},
+			expectedKept:           false,
+			expectedFinalizersKept: false,
+		},
+		{
+			name:   "case 5: failed snapshot is created again",
+			policy: key.PersistentVolumeDeletionPolicySnapshot,
+			snapshots: []ebs.Snapshot{
+				{
+					SnapshotID: "snap-failed",
+					State:      ec2.SnapshotStateError,
+					VolumeID:   "vol-pv",
+				},
+			},
+			expectedKept:           true,
+			expectedCreated:        1,
+			expectedFinalizersKept: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						key.AnnotationPersistentVolumeDeletionPolicy: tc.policy,
+					},
+				},
+			}
+
+			ebsService := &ebsServiceMock{snapshots: tc.snapshots}
+
+			r := &Resource{
+				eventRecorder: recordertest.New(),
+				logger:        microloggertest.New(),
+			}
+
+			ctx := finalizerskeptcontext.NewContext(context.Background(), make(chan struct{}))
+
+			kept := r.keepPersistentVolumes(ctx, cr, ebsService, volumes)
+
+			if kept["vol-docker"] {
+				t.Fatalf("expected %#q not to be kept", "vol-docker")
+			}
+			if kept["vol-pv"] != tc.expectedKept {
+				t.Fatalf("expected %#q to be kept %t got %t", "vol-pv", tc.expectedKept, kept["vol-pv"])
+			}
+			if len(ebsService.created) != tc.expectedCreated {
+				t.Fatalf("expected %d created snapshots got %d", tc.expectedCreated, len(ebsService.created))
+			}
+			if len(ebsService.orphaned) != tc.expectedOrphaned {
+				t.Fatalf("expected %d orphaned volumes got %d", tc.expectedOrphaned, len(ebsService.orphaned))
+			}
+			if finalizerskeptcontext.IsKept(ctx) != tc.expectedFinalizersKept {
+				t.Fatalf("expected finalizers kept to be %t", tc.expectedFinalizersKept)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package ebsvolume
 import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder"
 )
 
 const (
@@ -12,23 +14,29 @@ const (
 
 // Config represents the configuration used to create a new ebsvolume resource.
 type Config struct {
-	Logger micrologger.Logger
+	EventRecorder recorder.Interface
+	Logger        micrologger.Logger
 }
 
 // Resource implements the ebsvolume resource.
 type Resource struct {
-	logger micrologger.Logger
+	eventRecorder recorder.Interface
+	logger        micrologger.Logger
 }
 
 // New creates a new configured ebsvolume resource.
 func New(config Config) (*Resource, error) {
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
 	newResource := &Resource{
 		// Dependencies.
-		logger: config.Logger,
+		eventRecorder: config.EventRecorder,
+		logger:        config.Logger,
 	}
 
 	return newResource, nil
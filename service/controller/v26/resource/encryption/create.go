@@ -51,5 +51,14 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		cc.Status.TenantCluster.Encryption.Key = encryptionKey
 	}
 
+	{
+		cc.Status.TenantCluster.Encryption.VolumeKey = r.volumeKey(cr, cc.Status.TenantCluster.Encryption.Key)
+
+		err = r.ensureVolumeKeyGrant(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	return nil
 }
@@ -14,6 +14,11 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	err = r.ensureDeletedVolumeKeyGrant(ctx, customObject)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
 	err = r.encrypter.EnsureDeletedEncryptionKey(ctx, customObject)
 	if err != nil {
 		return microerror.Mask(err)
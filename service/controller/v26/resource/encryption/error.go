@@ -2,6 +2,7 @@ package encryption
 
 import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/giantswarm/microerror"
 )
 
@@ -35,3 +36,17 @@ func IsNotFound(err error) bool {
 
 	return false
 }
+
+// IsNoSuchEntity asserts the IAM error of not existing entities, e.g. roles.
+func IsNoSuchEntity(err error) bool {
+	c := microerror.Cause(err)
+
+	aerr, ok := c.(awserr.Error)
+	if ok {
+		if aerr.Code() == iam.ErrCodeNoSuchEntityException {
+			return true
+		}
+	}
+
+	return false
+}
@@ -14,11 +14,15 @@ const (
 type Config struct {
 	Encrypter encrypter.Interface
 	Logger    micrologger.Logger
+
+	EncrypterBackend string
 }
 
 type Resource struct {
 	encrypter encrypter.Interface
 	logger    micrologger.Logger
+
+	encrypterBackend string
 }
 
 func New(config Config) (*Resource, error) {
@@ -29,9 +33,15 @@ func New(config Config) (*Resource, error) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
+	if config.EncrypterBackend == "" {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EncrypterBackend must not be empty", config)
+	}
+
 	r := &Resource{
 		encrypter: config.Encrypter,
 		logger:    config.Logger,
+
+		encrypterBackend: config.EncrypterBackend,
 	}
 
 	return r, nil
@@ -0,0 +1,208 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// volumeKeyGrantOperations are the KMS operations EC2 auto scaling needs in
+// order to launch instances with encrypted EBS volumes.
+var volumeKeyGrantOperations = []string{
+	kms.GrantOperationCreateGrant,
+	kms.GrantOperationDecrypt,
+	kms.GrantOperationDescribeKey,
+	kms.GrantOperationEncrypt,
+	kms.GrantOperationGenerateDataKeyWithoutPlaintext,
+	kms.GrantOperationReEncryptFrom,
+	kms.GrantOperationReEncryptTo,
+}
+
+// volumeKey returns the ARN of the KMS key the worker volumes are encrypted
+// with. A customer managed key configured in the CR takes precedence over the
+// tenant cluster's KMS key. With the vault encrypter backend there is no KMS
+// key of the tenant cluster, in which case the AWS managed EBS key is used,
+// which is represented by the empty string.
+func (r *Resource) volumeKey(cr v1alpha1.AWSConfig, encryptionKey string) string {
+	if key.VolumeEncryptionKeyARN(cr) != "" {
+		return key.VolumeEncryptionKeyARN(cr)
+	}
+
+	if r.encrypterBackend == encrypter.KMSBackend {
+		return encryptionKey
+	}
+
+	return ""
+}
+
+// ensureVolumeKeyGrant ensures the service-linked role of EC2 auto scaling is
+// allowed to use the KMS key the worker volumes are encrypted with. The AWS
+// managed EBS key does not require any grant.
+func (r *Resource) ensureVolumeKeyGrant(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	volumeKey := cc.Status.TenantCluster.Encryption.VolumeKey
+	if volumeKey == "" {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "worker volumes are encrypted with the AWS managed EBS key")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "not granting EC2 auto scaling access to a volume encryption key")
+		return nil
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the service-linked role of EC2 auto scaling")
+
+		i := &iam.GetRoleInput{
+			RoleName: aws.String(key.AutoScalingServiceLinkedRoleName),
+		}
+
+		_, err := cc.Client.TenantCluster.AWS.IAM.GetRole(i)
+		if IsNoSuchEntity(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the service-linked role of EC2 auto scaling")
+			r.logger.LogCtx(ctx, "level", "debug", "message", "creating the service-linked role of EC2 auto scaling")
+
+			i := &iam.CreateServiceLinkedRoleInput{
+				AWSServiceName: aws.String("autoscaling.amazonaws.com"),
+			}
+
+			_, err = cc.Client.TenantCluster.AWS.IAM.CreateServiceLinkedRole(i)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "created the service-linked role of EC2 auto scaling")
+		} else if err != nil {
+			return microerror.Mask(err)
+		} else {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "found the service-linked role of EC2 auto scaling")
+		}
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+
+		grantID, err := r.findVolumeKeyGrant(ctx, cr, volumeKey)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if grantID != "" {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not find the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("creating the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+
+		i := &kms.CreateGrantInput{
+			GranteePrincipal: aws.String(key.AutoScalingServiceLinkedRoleARN(cr, cc.Status.TenantCluster.AWSAccountID)),
+			KeyId:            aws.String(volumeKey),
+			Name:             aws.String(key.VolumeEncryptionKeyGrantName(cr)),
+			Operations:       aws.StringSlice(volumeKeyGrantOperations),
+		}
+
+		_, err := cc.Client.TenantCluster.AWS.KMS.CreateGrant(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("created the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+	}
+
+	return nil
+}
+
+// ensureDeletedVolumeKeyGrant revokes the KMS grant of EC2 auto scaling on a
+// customer managed volume encryption key. Grants on the tenant cluster's KMS
+// key go away together with the key.
+func (r *Resource) ensureDeletedVolumeKeyGrant(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	volumeKey := key.VolumeEncryptionKeyARN(cr)
+	if volumeKey == "" {
+		return nil
+	}
+
+	var grantID string
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+
+		grantID, err = r.findVolumeKeyGrant(ctx, cr, volumeKey)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if grantID == "" {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not find the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("revoking the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+
+		i := &kms.RevokeGrantInput{
+			GrantId: aws.String(grantID),
+			KeyId:   aws.String(volumeKey),
+		}
+
+		_, err := cc.Client.TenantCluster.AWS.KMS.RevokeGrant(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("revoked the KMS grant for EC2 auto scaling on volume encryption key %#q", volumeKey))
+	}
+
+	return nil
+}
+
+// findVolumeKeyGrant returns the ID of the tenant cluster's KMS grant for EC2
+// auto scaling on the given key, or the empty string if there is none.
+func (r *Resource) findVolumeKeyGrant(ctx context.Context, cr v1alpha1.AWSConfig, volumeKey string) (string, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	var grantID string
+
+	i := &kms.ListGrantsInput{
+		KeyId: aws.String(volumeKey),
+	}
+
+	err = cc.Client.TenantCluster.AWS.KMS.ListGrantsPages(i, func(o *kms.ListGrantsResponse, lastPage bool) bool {
+		for _, g := range o.Grants {
+			if aws.StringValue(g.Name) == key.VolumeEncryptionKeyGrantName(cr) {
+				grantID = aws.StringValue(g.GrantId)
+				return false
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	return grantID, nil
+}
@@ -2,6 +2,7 @@ package endpoints
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/controller/context/resourcecanceledcontext"
@@ -17,25 +18,39 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) (interf
 		return nil, microerror.Mask(err)
 	}
 
-	instanceName := key.MasterInstanceName(customObject)
-	masterInstance, err := r.findMasterInstance(ctx, instanceName)
-	if IsNotFound(err) {
-		// During updates the master instance is shut down and thus cannot be found.
-		// In such cases we cancel the reconciliation for the endpoint resource.
-		// This should be ok since all endpoints should be created and up to date
-		// already. In case we miss an update it will be done on the next resync
-		// period once the master instance is up again.
+	var addresses []v1.EndpointAddress
+	for _, instanceName := range key.MasterInstanceNames(customObject) {
+		masterInstance, err := r.findMasterInstance(ctx, instanceName)
+		if IsNotFound(err) {
+			// During updates the master instances are shut down and thus cannot be
+			// found. Multi master clusters may still have other master instances
+			// running, which we continue to publish.
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("master instance %#q not found", instanceName))
+			continue
+		} else if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		addresses = append(addresses, v1.EndpointAddress{
+			IP: *masterInstance.PrivateIpAddress,
+		})
+	}
+
+	if len(addresses) == 0 {
+		// During updates the master instances are shut down and thus cannot be
+		// found. In such cases we cancel the reconciliation for the endpoint
+		// resource. This should be ok since all endpoints should be created and
+		// up to date already. In case we miss an update it will be done on the
+		// next resync period once the master instances are up again.
 		//
 		// TODO we might want to alert at some point when the master instance was
 		// not seen for too long. Like we should be able to find it again after
 		// three resync periods max or something.
-		r.logger.LogCtx(ctx, "level", "debug", "message", "master instance not found")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "no master instance found")
 		resourcecanceledcontext.SetCanceled(ctx)
 		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource reconciliation for custom object")
 
 		return nil, nil
-	} else if err != nil {
-		return nil, microerror.Mask(err)
 	}
 
 	endpoints := &v1.Endpoints{
@@ -50,11 +65,7 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) (interf
 		},
 		Subsets: []v1.EndpointSubset{
 			{
-				Addresses: []v1.EndpointAddress{
-					{
-						IP: *masterInstance.PrivateIpAddress,
-					},
-				},
+				Addresses: addresses,
 				Ports: []v1.EndpointPort{
 					{
 						Port: httpsPort,
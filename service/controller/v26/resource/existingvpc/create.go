@@ -0,0 +1,177 @@
+package existingvpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// EnsureCreated validates the existing VPC and subnets the tenant cluster is
+// deployed into before the tenant cluster's control plane stack is created.
+// The route tables of the private subnets are tracked in the controller
+// context, so that the tccp resource can add the VPC peering routes to them.
+// The VPC CIDR and the subnets of each availability zone are written to the CR
+// status, which is otherwise managed by the ipam resource.
+func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
+	cr, err := key.ToCustomObject(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	if !key.IsExistingVPC(cr) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "not validating existing VPC because the tenant cluster does not use one")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+		return nil
+	}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var input validateInput
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding existing VPC %#q", key.ExistingVPCID(cr)))
+
+		input.ControlPlaneVPCCIDR = cc.Status.ControlPlane.VPC.CIDR
+		input.SpecSubnets = key.ExistingVPCSubnets(cr)
+
+		{
+			i := &ec2.DescribeVpcsInput{
+				VpcIds: []*string{
+					aws.String(key.ExistingVPCID(cr)),
+				},
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.EC2.DescribeVpcs(i)
+			if IsNotFound(err) {
+				return r.invalid(ctx, cr, microerror.Maskf(invalidExistingVPCError, "VPC %#q not found", key.ExistingVPCID(cr)))
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			if len(o.Vpcs) != 1 {
+				return r.invalid(ctx, cr, microerror.Maskf(invalidExistingVPCError, "VPC %#q not found", key.ExistingVPCID(cr)))
+			}
+
+			input.VPC = o.Vpcs[0]
+		}
+
+		if len(input.SpecSubnets) > 0 {
+			var ids []*string
+			for _, s := range input.SpecSubnets {
+				ids = append(ids, aws.String(s.PrivateSubnetID), aws.String(s.PublicSubnetID))
+			}
+
+			i := &ec2.DescribeSubnetsInput{
+				SubnetIds: ids,
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.EC2.DescribeSubnets(i)
+			if IsNotFound(err) {
+				return r.invalid(ctx, cr, microerror.Maskf(invalidExistingVPCError, "subnets of VPC %#q not found", key.ExistingVPCID(cr)))
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			input.Subnets = o.Subnets
+		}
+
+		{
+			i := &ec2.DescribeRouteTablesInput{
+				Filters: []*ec2.Filter{
+					{
+						Name: aws.String("vpc-id"),
+						Values: []*string{
+							aws.String(key.ExistingVPCID(cr)),
+						},
+					},
+				},
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.EC2.DescribeRouteTables(i)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			input.RouteTables = o.RouteTables
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found existing VPC %#q", key.ExistingVPCID(cr)))
+	}
+
+	var output validateOutput
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("validating existing VPC %#q", key.ExistingVPCID(cr)))
+
+		output, err = validate(input)
+		if IsInvalidExistingVPC(err) {
+			return r.invalid(ctx, cr, err)
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		cc.Status.TenantCluster.TCCP.VPC.PrivateRouteTableIDs = output.PrivateRouteTableIDs
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("validated existing VPC %#q", key.ExistingVPCID(cr)))
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding out if CR status needs to be updated")
+
+		if key.StatusNetworkCIDR(cr) == output.CIDR && reflect.DeepEqual(key.StatusAvailabilityZones(cr), output.AvailabilityZones) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "found out CR status does not need to be updated")
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found out CR status needs to be updated")
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status")
+
+		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		newObj.Status.Cluster.Network.CIDR = output.CIDR
+		newObj.Status.AWS.AvailabilityZones = output.AvailabilityZones
+
+		_, err = r.g8sClient.ProviderV1alpha1().AWSConfigs(newObj.GetNamespace()).UpdateStatus(newObj)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status")
+
+		r.eventRecorder.Emit(ctx, newObj, corev1.EventTypeNormal, key.EventReasonExistingVPCValidated, fmt.Sprintf("validated existing VPC %#q with CIDR %#q", key.ExistingVPCID(cr), output.CIDR))
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+
+	return nil
+}
+
+// invalid emits a warning event about the invalid existing VPC on the CR so
+// that the problem is visible to the cluster admin and returns the given error.
+func (r *Resource) invalid(ctx context.Context, cr v1alpha1.AWSConfig, err error) error {
+	r.logger.LogCtx(ctx, "level", "warning", "message", "existing VPC is invalid", "stack", fmt.Sprintf("%#v", err))
+
+	r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonExistingVPCInvalid, err.Error())
+
+	return microerror.Mask(err)
+}
@@ -0,0 +1,9 @@
+package existingvpc
+
+import "context"
+
+// EnsureDeleted is a NOP for the existingvpc resource, because the existing VPC
+// and its subnets are not owned by the tenant cluster.
+func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
+	return nil
+}
@@ -0,0 +1,35 @@
+package existingvpc
+
+import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/giantswarm/microerror"
+)
+
+var invalidConfigError = &microerror.Error{
+	Kind: "invalidConfigError",
+}
+
+// IsInvalidConfig asserts invalidConfigError.
+func IsInvalidConfig(err error) bool {
+	return microerror.Cause(err) == invalidConfigError
+}
+
+var invalidExistingVPCError = &microerror.Error{
+	Kind: "invalidExistingVPCError",
+}
+
+// IsInvalidExistingVPC asserts invalidExistingVPCError.
+func IsInvalidExistingVPC(err error) bool {
+	return microerror.Cause(err) == invalidExistingVPCError
+}
+
+// IsNotFound asserts AWS errors returned when the requested VPC or subnets do
+// not exist.
+func IsNotFound(err error) bool {
+	aerr, ok := microerror.Cause(err).(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	return aerr.Code() == "InvalidVpcID.NotFound" || aerr.Code() == "InvalidSubnetID.NotFound"
+}
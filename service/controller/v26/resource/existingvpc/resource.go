@@ -0,0 +1,53 @@
+package existingvpc
+
+import (
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+)
+
+const (
+	Name = "existingvpcv26"
+)
+
+type Config struct {
+	EventRecorder recorder.Interface
+	G8sClient     versioned.Interface
+	Logger        micrologger.Logger
+}
+
+// Resource validates the existing VPC and subnets a tenant cluster is deployed
+// into and manages the network information of the CR status accordingly. It
+// is the counterpart of the ipam resource for tenant clusters which do not get
+// their VPC created by the operator.
+type Resource struct {
+	eventRecorder recorder.Interface
+	g8sClient     versioned.Interface
+	logger        micrologger.Logger
+}
+
+func New(config Config) (*Resource, error) {
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
+	if config.G8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	r := &Resource{
+		eventRecorder: config.EventRecorder,
+		g8sClient:     config.G8sClient,
+		logger:        config.Logger,
+	}
+
+	return r, nil
+}
+
+func (r *Resource) Name() string {
+	return Name
+}
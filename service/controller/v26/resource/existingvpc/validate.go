@@ -0,0 +1,221 @@
+package existingvpc
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/ipam"
+	"github.com/giantswarm/microerror"
+)
+
+const (
+	defaultRouteDestination = "0.0.0.0/0"
+	internetGatewayIDPrefix = "igw-"
+)
+
+type validateInput struct {
+	ControlPlaneVPCCIDR string
+	RouteTables         []*ec2.RouteTable
+	SpecSubnets         []v1alpha1.AWSConfigSpecAWSVPCSubnet
+	Subnets             []*ec2.Subnet
+	VPC                 *ec2.Vpc
+}
+
+type validateOutput struct {
+	AvailabilityZones    []v1alpha1.AWSConfigStatusAWSAvailabilityZone
+	CIDR                 string
+	PrivateRouteTableIDs []string
+}
+
+// validate checks that the existing VPC and subnets can host a tenant cluster.
+// Every availability zone must be referenced at most once and its subnets must
+// be located in the given availability zone within the VPC. The VPC must not
+// overlap with the control plane VPC, so that both can be peered. Private
+// subnets need a default route which does not point to an internet gateway and
+// public subnets need a default route pointing to an internet gateway. The
+// returned output describes the network of the tenant cluster the same way the
+// ipam resource does for VPCs managed by the operator.
+func validate(input validateInput) (validateOutput, error) {
+	if len(input.SpecSubnets) == 0 {
+		return validateOutput{}, microerror.Maskf(invalidExistingVPCError, "at least one availability zone with existing subnets required")
+	}
+
+	vpcID := aws.StringValue(input.VPC.VpcId)
+
+	_, vpcCIDR, err := net.ParseCIDR(aws.StringValue(input.VPC.CidrBlock))
+	if err != nil {
+		return validateOutput{}, microerror.Mask(err)
+	}
+
+	if input.ControlPlaneVPCCIDR != "" {
+		_, cpCIDR, err := net.ParseCIDR(input.ControlPlaneVPCCIDR)
+		if err != nil {
+			return validateOutput{}, microerror.Mask(err)
+		}
+
+		if overlaps(*vpcCIDR, *cpCIDR) {
+			return validateOutput{}, microerror.Maskf(invalidExistingVPCError, "VPC %#q with CIDR %#q overlaps with control plane VPC CIDR %#q", vpcID, vpcCIDR.String(), cpCIDR.String())
+		}
+	}
+
+	subnets := map[string]*ec2.Subnet{}
+	for _, s := range input.Subnets {
+		subnets[aws.StringValue(s.SubnetId)] = s
+	}
+
+	var availabilityZones []v1alpha1.AWSConfigStatusAWSAvailabilityZone
+	var privateRouteTableIDs []string
+	{
+		seen := map[string]bool{}
+
+		for _, s := range input.SpecSubnets {
+			if seen[s.AvailabilityZone] {
+				return validateOutput{}, microerror.Maskf(invalidExistingVPCError, "availability zone %#q must only be referenced once", s.AvailabilityZone)
+			}
+			seen[s.AvailabilityZone] = true
+
+			privateCIDR, err := validateSubnet(subnets, s.PrivateSubnetID, s.AvailabilityZone, vpcID, *vpcCIDR)
+			if err != nil {
+				return validateOutput{}, microerror.Mask(err)
+			}
+			publicCIDR, err := validateSubnet(subnets, s.PublicSubnetID, s.AvailabilityZone, vpcID, *vpcCIDR)
+			if err != nil {
+				return validateOutput{}, microerror.Mask(err)
+			}
+
+			privateRouteTable, err := subnetRouteTable(input.RouteTables, s.PrivateSubnetID)
+			if err != nil {
+				return validateOutput{}, microerror.Mask(err)
+			}
+			if !hasDefaultRoute(privateRouteTable, false) {
+				return validateOutput{}, microerror.Maskf(invalidExistingVPCError, "route table %#q of private subnet %#q must have a default route which does not point to an internet gateway", aws.StringValue(privateRouteTable.RouteTableId), s.PrivateSubnetID)
+			}
+
+			publicRouteTable, err := subnetRouteTable(input.RouteTables, s.PublicSubnetID)
+			if err != nil {
+				return validateOutput{}, microerror.Mask(err)
+			}
+			if !hasDefaultRoute(publicRouteTable, true) {
+				return validateOutput{}, microerror.Maskf(invalidExistingVPCError, "route table %#q of public subnet %#q must have a default route pointing to an internet gateway", aws.StringValue(publicRouteTable.RouteTableId), s.PublicSubnetID)
+			}
+
+			az := v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+				Name: s.AvailabilityZone,
+				Subnet: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnet{
+					Private: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPrivate{
+						CIDR: privateCIDR,
+					},
+					Public: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPublic{
+						CIDR: publicCIDR,
+					},
+				},
+			}
+			availabilityZones = append(availabilityZones, az)
+
+			id := aws.StringValue(privateRouteTable.RouteTableId)
+			if !containsString(privateRouteTableIDs, id) {
+				privateRouteTableIDs = append(privateRouteTableIDs, id)
+			}
+		}
+	}
+
+	sort.Slice(availabilityZones, func(i, j int) bool {
+		return availabilityZones[i].Name < availabilityZones[j].Name
+	})
+	sort.Strings(privateRouteTableIDs)
+
+	o := validateOutput{
+		AvailabilityZones:    availabilityZones,
+		CIDR:                 vpcCIDR.String(),
+		PrivateRouteTableIDs: privateRouteTableIDs,
+	}
+
+	return o, nil
+}
+
+// validateSubnet checks that the subnet with the given ID exists in the given
+// availability zone within the given VPC and returns its CIDR.
+func validateSubnet(subnets map[string]*ec2.Subnet, id, availabilityZone, vpcID string, vpcCIDR net.IPNet) (string, error) {
+	s, ok := subnets[id]
+	if !ok {
+		return "", microerror.Maskf(invalidExistingVPCError, "subnet %#q not found", id)
+	}
+
+	if aws.StringValue(s.VpcId) != vpcID {
+		return "", microerror.Maskf(invalidExistingVPCError, "subnet %#q must be located in VPC %#q", id, vpcID)
+	}
+	if aws.StringValue(s.AvailabilityZone) != availabilityZone {
+		return "", microerror.Maskf(invalidExistingVPCError, "subnet %#q must be located in availability zone %#q", id, availabilityZone)
+	}
+
+	_, cidr, err := net.ParseCIDR(aws.StringValue(s.CidrBlock))
+	if err != nil {
+		return "", microerror.Mask(err)
+	}
+
+	if !ipam.Contains(vpcCIDR, *cidr) {
+		return "", microerror.Maskf(invalidExistingVPCError, "subnet %#q with CIDR %#q must be part of VPC CIDR %#q", id, cidr.String(), vpcCIDR.String())
+	}
+
+	return cidr.String(), nil
+}
+
+// subnetRouteTable returns the route table explicitly associated with the
+// subnet with the given ID. Subnets without explicit association use the main
+// route table of their VPC.
+func subnetRouteTable(routeTables []*ec2.RouteTable, subnetID string) (*ec2.RouteTable, error) {
+	var main *ec2.RouteTable
+
+	for _, rt := range routeTables {
+		for _, a := range rt.Associations {
+			if aws.StringValue(a.SubnetId) == subnetID {
+				return rt, nil
+			}
+			if aws.BoolValue(a.Main) {
+				main = rt
+			}
+		}
+	}
+
+	if main == nil {
+		return nil, microerror.Maskf(invalidExistingVPCError, "route table of subnet %#q not found", subnetID)
+	}
+
+	return main, nil
+}
+
+// hasDefaultRoute returns true when the route table has an active default
+// route. When internetGateway is true, the default route must point to an
+// internet gateway, otherwise it must not.
+func hasDefaultRoute(routeTable *ec2.RouteTable, internetGateway bool) bool {
+	for _, r := range routeTable.Routes {
+		if aws.StringValue(r.DestinationCidrBlock) != defaultRouteDestination {
+			continue
+		}
+		if aws.StringValue(r.State) != ec2.RouteStateActive {
+			continue
+		}
+
+		return strings.HasPrefix(aws.StringValue(r.GatewayId), internetGatewayIDPrefix) == internetGateway
+	}
+
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func overlaps(a, b net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
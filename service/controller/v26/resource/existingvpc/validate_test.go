@@ -0,0 +1,262 @@
+package existingvpc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
+func Test_validate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		input          validateInput
+		expectedOutput validateOutput
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:  "case 0: valid existing VPC with two availability zones",
+			input: newTestValidateInput(),
+			expectedOutput: validateOutput{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					newTestStatusAZ("eu-central-1a", "10.10.0.0/24", "10.10.1.0/24"),
+					newTestStatusAZ("eu-central-1b", "10.10.2.0/24", "10.10.3.0/24"),
+				},
+				CIDR:                 "10.10.0.0/16",
+				PrivateRouteTableIDs: []string{"rtb-private-a", "rtb-private-b"},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 1: private subnets sharing a route table",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.RouteTables[1].Associations = append(i.RouteTables[1].Associations, i.RouteTables[2].Associations...)
+				i.RouteTables = append(i.RouteTables[:2], i.RouteTables[3:]...)
+				return i
+			}(),
+			expectedOutput: validateOutput{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					newTestStatusAZ("eu-central-1a", "10.10.0.0/24", "10.10.1.0/24"),
+					newTestStatusAZ("eu-central-1b", "10.10.2.0/24", "10.10.3.0/24"),
+				},
+				CIDR:                 "10.10.0.0/16",
+				PrivateRouteTableIDs: []string{"rtb-private-a"},
+			},
+			errorMatcher: nil,
+		},
+		{
+			name: "case 2: no availability zones",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.SpecSubnets = nil
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 3: availability zone referenced twice",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.SpecSubnets[1].AvailabilityZone = "eu-central-1b"
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 4: VPC overlapping with control plane VPC",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.ControlPlaneVPCCIDR = "10.10.128.0/17"
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 5: subnet not found",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.Subnets = i.Subnets[1:]
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 6: subnet in another VPC",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.Subnets[0].VpcId = aws.String("vpc-other")
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 7: subnet in another availability zone",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.Subnets[0].AvailabilityZone = aws.String("eu-central-1c")
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 8: subnet CIDR outside of VPC CIDR",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.Subnets[0].CidrBlock = aws.String("10.20.0.0/24")
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 9: private subnet routing to internet gateway",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.RouteTables[1].Routes = i.RouteTables[0].Routes
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 10: public subnet without internet gateway route",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.RouteTables[0].Routes = i.RouteTables[1].Routes
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 11: private subnet without default route",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.RouteTables[2].Routes = nil
+				return i
+			}(),
+			errorMatcher: IsInvalidExistingVPC,
+		},
+		{
+			name: "case 12: public subnets using the main route table",
+			input: func() validateInput {
+				i := newTestValidateInput()
+				i.RouteTables[0].Associations = []*ec2.RouteTableAssociation{
+					{Main: aws.Bool(true)},
+				}
+				return i
+			}(),
+			expectedOutput: validateOutput{
+				AvailabilityZones: []v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+					newTestStatusAZ("eu-central-1a", "10.10.0.0/24", "10.10.1.0/24"),
+					newTestStatusAZ("eu-central-1b", "10.10.2.0/24", "10.10.3.0/24"),
+				},
+				CIDR:                 "10.10.0.0/16",
+				PrivateRouteTableIDs: []string{"rtb-private-a", "rtb-private-b"},
+			},
+			errorMatcher: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := validate(tc.input)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(output, tc.expectedOutput) {
+				t.Fatalf("output == %#v, want %#v", output, tc.expectedOutput)
+			}
+		})
+	}
+}
+
+// newTestValidateInput returns a valid existing VPC with a private and a public
+// subnet in two availability zones. The public subnets share a route table
+// pointing to an internet gateway and each private subnet has its own route
+// table pointing to a NAT gateway.
+func newTestValidateInput() validateInput {
+	return validateInput{
+		ControlPlaneVPCCIDR: "10.0.0.0/16",
+		RouteTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-public"),
+				Associations: []*ec2.RouteTableAssociation{
+					{SubnetId: aws.String("subnet-public-a")},
+					{SubnetId: aws.String("subnet-public-b")},
+				},
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("10.10.0.0/16"), GatewayId: aws.String("local"), State: aws.String("active")},
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), GatewayId: aws.String("igw-1"), State: aws.String("active")},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-private-a"),
+				Associations: []*ec2.RouteTableAssociation{
+					{SubnetId: aws.String("subnet-private-a")},
+				},
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("10.10.0.0/16"), GatewayId: aws.String("local"), State: aws.String("active")},
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-a"), State: aws.String("active")},
+				},
+			},
+			{
+				RouteTableId: aws.String("rtb-private-b"),
+				Associations: []*ec2.RouteTableAssociation{
+					{SubnetId: aws.String("subnet-private-b")},
+				},
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("10.10.0.0/16"), GatewayId: aws.String("local"), State: aws.String("active")},
+					{DestinationCidrBlock: aws.String("0.0.0.0/0"), NatGatewayId: aws.String("nat-b"), State: aws.String("active")},
+				},
+			},
+		},
+		SpecSubnets: []v1alpha1.AWSConfigSpecAWSVPCSubnet{
+			{AvailabilityZone: "eu-central-1b", PrivateSubnetID: "subnet-private-b", PublicSubnetID: "subnet-public-b"},
+			{AvailabilityZone: "eu-central-1a", PrivateSubnetID: "subnet-private-a", PublicSubnetID: "subnet-public-a"},
+		},
+		Subnets: []*ec2.Subnet{
+			newTestSubnet("subnet-private-a", "eu-central-1a", "10.10.0.0/24"),
+			newTestSubnet("subnet-public-a", "eu-central-1a", "10.10.1.0/24"),
+			newTestSubnet("subnet-private-b", "eu-central-1b", "10.10.2.0/24"),
+			newTestSubnet("subnet-public-b", "eu-central-1b", "10.10.3.0/24"),
+		},
+		VPC: &ec2.Vpc{
+			CidrBlock: aws.String("10.10.0.0/16"),
+			VpcId:     aws.String("vpc-1"),
+		},
+	}
+}
+
+func newTestSubnet(id, availabilityZone, cidr string) *ec2.Subnet {
+	return &ec2.Subnet{
+		AvailabilityZone: aws.String(availabilityZone),
+		CidrBlock:        aws.String(cidr),
+		SubnetId:         aws.String(id),
+		VpcId:            aws.String("vpc-1"),
+	}
+}
+
+func newTestStatusAZ(name, privateCIDR, publicCIDR string) v1alpha1.AWSConfigStatusAWSAvailabilityZone {
+	return v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+		Name: name,
+		Subnet: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnet{
+			Private: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPrivate{
+				CIDR: privateCIDR,
+			},
+			Public: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPublic{
+				CIDR: publicCIDR,
+			},
+		},
+	}
+}
@@ -0,0 +1,288 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/ipam"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// addAZs allocates subnets for availability zones added to the tenant cluster
+// after its network segment got allocated. The subnets of the new availability
+// zones are allocated from unused space of the tenant cluster's network
+// segments. In case there is not enough space left, a secondary network
+// segment is allocated for the new availability zones. The new availability
+// zones are appended to the CR status so that the subnets of the existing
+// availability zones keep their positions and are not recreated. Removing
+// availability zones is not supported.
+func (r *Resource) addAZs(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	r.logger.LogCtx(ctx, "level", "debug", "message", "finding out if availability zones need to be added")
+
+	current := key.StatusAvailabilityZones(cr)
+	desired := key.SpecAvailabilityZoneCount(cr)
+
+	if desired < len(current) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("not removing availability zones because it is not supported, CR status contains %d availability zones", len(current)))
+		return nil
+	}
+	if desired == len(current) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found out availability zones do not need to be added")
+		return nil
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found out %d availability zones need to be added", desired-len(current)))
+
+	azs, err := r.selectAdditionalAZs(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	name, networkRange, err := r.selectNetworkRange(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	statusAZs, secondaryCIDR, err := r.allocateAdditionalSubnets(ctx, cr, azs, networkRange)
+	if IsNetworkRangeExhausted(err) {
+		return r.networkRangeExhausted(ctx, cr, name, err)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status")
+
+		cr.Status.Cluster.Conditions = withoutCondition(cr.Status.Cluster.Conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted)
+		cr.Status.AWS.AvailabilityZones = append(cr.Status.AWS.AvailabilityZones, statusAZs...)
+		if secondaryCIDR != "" {
+			cr.Status.Cluster.Network.SecondaryCIDRs = append(cr.Status.Cluster.Network.SecondaryCIDRs, secondaryCIDR)
+		}
+
+		_, err = r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).UpdateStatus(&cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status")
+
+		r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonAvailabilityZonesAdded, fmt.Sprintf("added availability zones %#v", azs))
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+		reconciliationcanceledcontext.SetCanceled(ctx)
+	}
+
+	return nil
+}
+
+// selectAdditionalAZs returns the availability zones which have to be added to
+// the tenant cluster. Explicitly configured availability zones must contain
+// all availability zones the tenant cluster already spans.
+func (r *Resource) selectAdditionalAZs(ctx context.Context, cr v1alpha1.AWSConfig) ([]string, error) {
+	var currentAZs []string
+	for _, az := range key.StatusAvailabilityZones(cr) {
+		currentAZs = append(currentAZs, az.Name)
+	}
+
+	if len(key.SpecAvailabilityZoneNames(cr)) > 0 {
+		azs, err := r.selectAZs(ctx, cr)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, az := range currentAZs {
+			if !containsString(azs, az) {
+				err := microerror.Maskf(invalidAvailabilityZoneError, "availability zone %#q of the tenant cluster must not be removed", az)
+				return nil, r.invalidAZs(ctx, cr, err)
+			}
+		}
+
+		var additionalAZs []string
+		for _, az := range azs {
+			if !containsString(currentAZs, az) {
+				additionalAZs = append(additionalAZs, az)
+			}
+		}
+
+		return additionalAZs, nil
+	}
+
+	var candidates []string
+	for _, az := range r.availabilityZones {
+		if !containsString(currentAZs, az) {
+			candidates = append(candidates, az)
+		}
+	}
+
+	azs, err := randomAZs(candidates, key.SpecAvailabilityZones(cr)-len(currentAZs))
+	if IsInvalidParameter(err) {
+		err := microerror.Maskf(invalidAvailabilityZoneError, "%d availability zones requested but the installation only has %d availability zones", key.SpecAvailabilityZones(cr), len(r.availabilityZones))
+		return nil, r.invalidAZs(ctx, cr, err)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return azs, nil
+}
+
+// allocateAdditionalSubnets allocates private and public subnets for the given
+// availability zones. It returns the secondary network segment in case one had
+// to be allocated for the subnets from the given network range.
+func (r *Resource) allocateAdditionalSubnets(ctx context.Context, cr v1alpha1.AWSConfig, azs []string, networkRange net.IPNet) ([]v1alpha1.AWSConfigStatusAWSAvailabilityZone, string, error) {
+	var mask net.IPMask
+	var used []net.IPNet
+	for _, az := range key.StatusAvailabilityZones(cr) {
+		for _, cidr := range []string{az.Subnet.Private.CIDR, az.Subnet.Public.CIDR} {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, "", microerror.Mask(err)
+			}
+
+			used = append(used, *n)
+		}
+	}
+	if len(used) > 0 {
+		mask = used[0].Mask
+	}
+
+	var networks []net.IPNet
+	for _, cidr := range append([]string{key.StatusNetworkCIDR(cr)}, key.StatusNetworkSecondaryCIDRs(cr)...) {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, "", microerror.Mask(err)
+		}
+
+		networks = append(networks, *n)
+	}
+
+	if mask != nil {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding unused space for additional subnets in tenant cluster subnets")
+
+		subnets, err := freeSubnets(networks, used, mask, len(azs)*2)
+		if err != nil {
+			return nil, "", microerror.Mask(err)
+		}
+
+		if len(subnets) == len(azs)*2 {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "found unused space for additional subnets in tenant cluster subnets")
+
+			return newStatusAZs(subnets, azs), "", nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find enough unused space for additional subnets in tenant cluster subnets")
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", "allocating secondary cluster subnet CIDR")
+
+	secondary, err := r.allocateSecondarySubnet(ctx, cr, networks, networkRange)
+	if err != nil {
+		return nil, "", microerror.Mask(err)
+	}
+
+	statusAZs, err := splitSubnetToStatusAZs(secondary, azs)
+	if err != nil {
+		return nil, "", microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("allocated secondary cluster subnet CIDR %#q", secondary.String()))
+
+	return statusAZs, secondary.String(), nil
+}
+
+// allocateSecondarySubnet reserves an additional network segment for the
+// tenant cluster within the given network range. It is of the same size as the
+// tenant cluster's primary network segment, which is the first of the given
+// networks. A network segment reserved for the tenant cluster in a previous
+// reconciliation but not yet written to the CR status is used right away.
+func (r *Resource) allocateSecondarySubnet(ctx context.Context, cr v1alpha1.AWSConfig, networks []net.IPNet, networkRange net.IPNet) (net.IPNet, error) {
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding reserved secondary subnet of tenant cluster")
+
+		reserved, err := r.ipamAllocation.List(ctx, key.ClusterID(cr))
+		if err != nil {
+			return net.IPNet{}, microerror.Mask(err)
+		}
+
+		for _, s := range reserved {
+			if !containsNetwork(networks, s) {
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found reserved secondary subnet %#q of tenant cluster", s.String()))
+				return s, nil
+			}
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find reserved secondary subnet of tenant cluster")
+	}
+
+	subnet, err := r.reserveFreeSubnet(ctx, key.ClusterID(cr), networkRange, networks[0].Mask)
+	if err != nil {
+		return net.IPNet{}, microerror.Mask(err)
+	}
+
+	return subnet, nil
+}
+
+// freeSubnets returns up to n subnets of the given mask which are part of the
+// given networks and do not overlap with the given used subnets.
+func freeSubnets(networks []net.IPNet, used []net.IPNet, mask net.IPMask, n int) ([]net.IPNet, error) {
+	var subnets []net.IPNet
+
+	for _, network := range networks {
+		for len(subnets) < n {
+			// CanonicalizeSubnets modifies the given slice, so we hand over a
+			// copy.
+			reserved := append(append([]net.IPNet{}, used...), subnets...)
+			reserved = ipam.CanonicalizeSubnets(network, reserved)
+
+			subnet, err := ipam.Free(network, mask, reserved)
+			if ipam.IsSpaceExhausted(err) {
+				break
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			subnets = append(subnets, subnet)
+		}
+	}
+
+	return subnets, nil
+}
+
+// newStatusAZs pairs the given subnets with the given availability zones such
+// that each availability zone gets a private and a public subnet, in the same
+// way splitSubnetToStatusAZs does.
+func newStatusAZs(subnets []net.IPNet, azs []string) []v1alpha1.AWSConfigStatusAWSAvailabilityZone {
+	var statusAZs []v1alpha1.AWSConfigStatusAWSAvailabilityZone
+	for i, az := range azs {
+		statusAZ := v1alpha1.AWSConfigStatusAWSAvailabilityZone{
+			Name: az,
+			Subnet: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnet{
+				Private: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPrivate{
+					CIDR: subnets[i*2].String(),
+				},
+				Public: v1alpha1.AWSConfigStatusAWSAvailabilityZoneSubnetPublic{
+					CIDR: subnets[i*2+1].String(),
+				},
+			},
+		}
+
+		statusAZs = append(statusAZs, statusAZ)
+	}
+
+	return statusAZs
+}
+
+func containsNetwork(list []net.IPNet, n net.IPNet) bool {
+	for _, l := range list {
+		if l.String() == n.String() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,102 @@
+package ipam
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func Test_freeSubnets(t *testing.T) {
+	testCases := []struct {
+		name            string
+		networks        []net.IPNet
+		used            []net.IPNet
+		mask            net.IPMask
+		n               int
+		expectedSubnets []net.IPNet
+	}{
+		{
+			name: "case 0: unused space of three availability zones fits one more",
+			networks: []net.IPNet{
+				mustParseCIDR("10.1.0.0/24"),
+			},
+			used: []net.IPNet{
+				mustParseCIDR("10.1.0.0/27"),
+				mustParseCIDR("10.1.0.32/27"),
+				mustParseCIDR("10.1.0.64/27"),
+				mustParseCIDR("10.1.0.96/27"),
+				mustParseCIDR("10.1.0.128/27"),
+				mustParseCIDR("10.1.0.160/27"),
+			},
+			mask: net.CIDRMask(27, 32),
+			n:    2,
+			expectedSubnets: []net.IPNet{
+				mustParseCIDR("10.1.0.192/27"),
+				mustParseCIDR("10.1.0.224/27"),
+			},
+		},
+		{
+			name: "case 1: unused space of three availability zones does not fit two more",
+			networks: []net.IPNet{
+				mustParseCIDR("10.1.0.0/24"),
+			},
+			used: []net.IPNet{
+				mustParseCIDR("10.1.0.0/27"),
+				mustParseCIDR("10.1.0.32/27"),
+				mustParseCIDR("10.1.0.64/27"),
+				mustParseCIDR("10.1.0.96/27"),
+				mustParseCIDR("10.1.0.128/27"),
+				mustParseCIDR("10.1.0.160/27"),
+			},
+			mask: net.CIDRMask(27, 32),
+			n:    4,
+			expectedSubnets: []net.IPNet{
+				mustParseCIDR("10.1.0.192/27"),
+				mustParseCIDR("10.1.0.224/27"),
+			},
+		},
+		{
+			name: "case 2: single availability zone leaves no unused space",
+			networks: []net.IPNet{
+				mustParseCIDR("10.1.0.0/24"),
+			},
+			used: []net.IPNet{
+				mustParseCIDR("10.1.0.0/25"),
+				mustParseCIDR("10.1.0.128/25"),
+			},
+			mask:            net.CIDRMask(25, 32),
+			n:               2,
+			expectedSubnets: nil,
+		},
+		{
+			name: "case 3: unused space of secondary network segment is used",
+			networks: []net.IPNet{
+				mustParseCIDR("10.1.0.0/24"),
+				mustParseCIDR("10.1.5.0/24"),
+			},
+			used: []net.IPNet{
+				mustParseCIDR("10.1.0.0/25"),
+				mustParseCIDR("10.1.0.128/25"),
+			},
+			mask: net.CIDRMask(25, 32),
+			n:    2,
+			expectedSubnets: []net.IPNet{
+				mustParseCIDR("10.1.5.0/25"),
+				mustParseCIDR("10.1.5.128/25"),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subnets, err := freeSubnets(tc.networks, tc.used, tc.mask, tc.n)
+			if err != nil {
+				t.Fatalf("error == %#v, want nil", err)
+			}
+
+			if !reflect.DeepEqual(subnets, tc.expectedSubnets) {
+				t.Fatalf("subnets == %v, want %v", subnets, tc.expectedSubnets)
+			}
+		})
+	}
+}
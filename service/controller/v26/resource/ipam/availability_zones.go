@@ -0,0 +1,155 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// selectAZs returns the availability zones the tenant cluster is placed into.
+// The availability zones explicitly configured in the CR take precedence.
+// Only in case there are none configured the availability zones are selected
+// randomly.
+func (r *Resource) selectAZs(ctx context.Context, cr v1alpha1.AWSConfig) ([]string, error) {
+	if len(key.SpecAvailabilityZoneNames(cr)) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "selecting random availability zones")
+
+		azs, err := r.selectRandomAZs(key.SpecAvailabilityZones(cr))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("selected random availability zones %#v", azs))
+
+		return azs, nil
+	}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("validating configured availability zones %#v", key.SpecAvailabilityZoneNames(cr)))
+
+	var controlPlaneAZs []*ec2.AvailabilityZone
+	{
+		o, err := cc.Client.ControlPlane.AWS.EC2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		controlPlaneAZs = o.AvailabilityZones
+	}
+
+	var tenantClusterAZs []*ec2.AvailabilityZone
+	{
+		o, err := cc.Client.TenantCluster.AWS.EC2.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		tenantClusterAZs = o.AvailabilityZones
+	}
+
+	azs, err := validateAZs(key.SpecAvailabilityZoneNames(cr), key.SpecAvailabilityZones(cr), r.availabilityZones, controlPlaneAZs, tenantClusterAZs)
+	if IsInvalidAvailabilityZone(err) {
+		return nil, r.invalidAZs(ctx, cr, err)
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("validated configured availability zones %#v", azs))
+
+	return azs, nil
+}
+
+// validateAZs resolves the requested availability zones to availability zone
+// names of the tenant cluster's AWS account and validates them. Requested
+// availability zones may be given as names or as IDs. Availability zone names
+// are mapped to different physical locations in different AWS accounts, while
+// IDs are the same across AWS accounts. This makes it possible to co-locate a
+// tenant cluster with resources running in another AWS account. The resolved
+// availability zones must be available in the tenant cluster's AWS account and
+// must be configured for the installation. Since the installation's
+// availability zones are names of the control plane's AWS account, they are
+// compared by ID with the availability zones of the tenant cluster's AWS
+// account.
+func validateAZs(requested []string, n int, installationAZs []string, controlPlaneAZs []*ec2.AvailabilityZone, tenantClusterAZs []*ec2.AvailabilityZone) ([]string, error) {
+	if n != 0 && n != len(requested) {
+		return nil, microerror.Maskf(invalidAvailabilityZoneError, "number of availability zones %d does not match availability zones %#v", n, requested)
+	}
+
+	installationIDs := map[string]bool{}
+	for _, az := range controlPlaneAZs {
+		if containsString(installationAZs, aws.StringValue(az.ZoneName)) {
+			installationIDs[aws.StringValue(az.ZoneId)] = true
+		}
+	}
+
+	names := map[string]*ec2.AvailabilityZone{}
+	ids := map[string]*ec2.AvailabilityZone{}
+	for _, az := range tenantClusterAZs {
+		names[aws.StringValue(az.ZoneName)] = az
+		ids[aws.StringValue(az.ZoneId)] = az
+	}
+
+	seen := map[string]bool{}
+	var azs []string
+	for _, r := range requested {
+		az, ok := names[r]
+		if !ok {
+			az, ok = ids[r]
+		}
+		if !ok {
+			return nil, microerror.Maskf(invalidAvailabilityZoneError, "availability zone %#q does not exist in the tenant cluster's AWS account", r)
+		}
+
+		id := aws.StringValue(az.ZoneId)
+		name := aws.StringValue(az.ZoneName)
+
+		if aws.StringValue(az.State) != ec2.AvailabilityZoneStateAvailable {
+			return nil, microerror.Maskf(invalidAvailabilityZoneError, "availability zone %#q is %#q", name, aws.StringValue(az.State))
+		}
+		if !installationIDs[id] {
+			return nil, microerror.Maskf(invalidAvailabilityZoneError, "availability zone %#q with ID %#q is not one of the installation's availability zones %#v", name, id, installationAZs)
+		}
+		if seen[id] {
+			return nil, microerror.Maskf(invalidAvailabilityZoneError, "availability zone %#q is configured more than once", name)
+		}
+		seen[id] = true
+
+		azs = append(azs, name)
+	}
+
+	sort.Strings(azs)
+
+	return azs, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// invalidAZs emits a warning event about the invalid availability zones on the
+// CR so that the problem is visible to the cluster admin and returns the given
+// error.
+func (r *Resource) invalidAZs(ctx context.Context, cr v1alpha1.AWSConfig, err error) error {
+	r.logger.LogCtx(ctx, "level", "warning", "message", "configured availability zones are invalid", "stack", fmt.Sprintf("%#v", err))
+
+	r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonAvailabilityZoneInvalid, err.Error())
+
+	return microerror.Mask(err)
+}
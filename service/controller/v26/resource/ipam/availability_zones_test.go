@@ -0,0 +1,146 @@
+package ipam
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func Test_validateAZs(t *testing.T) {
+	// The installation's availability zones are names of the control plane's
+	// AWS account, which are mapped to other IDs than in the tenant cluster's
+	// AWS account.
+	installationAZs := []string{"eu-central-1a", "eu-central-1b"}
+	controlPlaneAZs := []*ec2.AvailabilityZone{
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az3"),
+			ZoneName: aws.String("eu-central-1a"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az1"),
+			ZoneName: aws.String("eu-central-1b"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az2"),
+			ZoneName: aws.String("eu-central-1c"),
+		},
+	}
+	tenantClusterAZs := []*ec2.AvailabilityZone{
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az2"),
+			ZoneName: aws.String("eu-central-1a"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az3"),
+			ZoneName: aws.String("eu-central-1b"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az1"),
+			ZoneName: aws.String("eu-central-1c"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateAvailable),
+			ZoneId:   aws.String("euc1-az4"),
+			ZoneName: aws.String("eu-central-1d"),
+		},
+		{
+			State:    aws.String(ec2.AvailabilityZoneStateImpaired),
+			ZoneId:   aws.String("euc1-az5"),
+			ZoneName: aws.String("eu-central-1e"),
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		requested    []string
+		n            int
+		expectedAZs  []string
+		errorMatcher func(error) bool
+	}{
+		{
+			name:         "case 0: availability zone names are sorted",
+			requested:    []string{"eu-central-1c", "eu-central-1b"},
+			n:            0,
+			expectedAZs:  []string{"eu-central-1b", "eu-central-1c"},
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 1: availability zone IDs are mapped to names",
+			requested:    []string{"euc1-az3"},
+			n:            1,
+			expectedAZs:  []string{"eu-central-1b"},
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 2: error when the number of availability zones does not match",
+			requested:    []string{"eu-central-1b"},
+			n:            2,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+		{
+			name:         "case 3: error when the availability zone does not exist",
+			requested:    []string{"eu-west-1a"},
+			n:            0,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+		{
+			name:         "case 4: error when the availability zone is not available",
+			requested:    []string{"eu-central-1e"},
+			n:            0,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+		{
+			name:         "case 5: error when the availability zone is not configured for the installation",
+			requested:    []string{"euc1-az4"},
+			n:            0,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+		{
+			name:         "case 6: error when the same availability zone is given by name and ID",
+			requested:    []string{"eu-central-1b", "euc1-az3"},
+			n:            0,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+		{
+			name:         "case 7: error when only the name of the availability zone matches the installation",
+			requested:    []string{"eu-central-1a"},
+			n:            0,
+			expectedAZs:  nil,
+			errorMatcher: IsInvalidAvailabilityZone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			azs, err := validateAZs(tc.requested, tc.n, installationAZs, controlPlaneAZs, tenantClusterAZs)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(azs, tc.expectedAZs) {
+				t.Fatalf("azs == %#v, want %#v", azs, tc.expectedAZs)
+			}
+		})
+	}
+}
@@ -16,10 +16,13 @@ import (
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
 	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
+	"github.com/giantswarm/aws-operator/service/ipamsubnet"
 )
 
 func init() {
@@ -29,18 +32,29 @@ func init() {
 
 // EnsureCreated allocates guest cluster network segment. It gathers existing
 // subnets from existing AWSConfig/Status objects and existing VPCs from AWS.
+// Tenant clusters deployed into existing VPCs do not get any subnet allocated.
+// Their network status is managed by the existingvpc resource. Once the network
+// segment is allocated, subnets for availability zones added to the CR are
+// allocated as well.
 func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	var err error
 
 	var cr v1alpha1.AWSConfig
 	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", "fetching latest version of custom resource")
-
 		oldObj, err := key.ToCustomObject(obj)
 		if err != nil {
 			return microerror.Mask(err)
 		}
 
+		if key.IsExistingVPC(oldObj) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "not allocating subnet because the tenant cluster uses an existing VPC")
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "fetching latest version of custom resource")
+
 		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(oldObj.GetNamespace()).Get(oldObj.GetName(), metav1.GetOptions{})
 		if err != nil {
 			return microerror.Mask(err)
@@ -58,17 +72,29 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		{
 			r.logger.LogCtx(ctx, "level", "debug", "message", "allocating cluster subnet CIDR")
 
-			subnetCIDR, err = r.allocateSubnet(ctx)
+			azs, err := r.selectAZs(ctx, cr)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			name, networkRange, err := r.selectNetworkRange(ctx, cr)
 			if err != nil {
 				return microerror.Mask(err)
 			}
 
-			randomAZs, err := r.selectRandomAZs(key.SpecAvailabilityZones(cr))
+			mask, err := r.selectSubnetMask(ctx, cr, networkRange)
 			if err != nil {
 				return microerror.Mask(err)
 			}
 
-			statusAZs, err = splitSubnetToStatusAZs(subnetCIDR, randomAZs)
+			subnetCIDR, err = r.allocateSubnet(ctx, key.ClusterID(cr), networkRange, mask)
+			if IsNetworkRangeExhausted(err) {
+				return r.networkRangeExhausted(ctx, cr, name, err)
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			statusAZs, err = splitSubnetToStatusAZs(subnetCIDR, azs)
 			if err != nil {
 				return microerror.Mask(err)
 			}
@@ -82,6 +108,7 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		{
 			r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status")
 
+			cr.Status.Cluster.Conditions = withoutCondition(cr.Status.Cluster.Conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted)
 			cr.Status.Cluster.Network.CIDR = subnetCIDR.String()
 			cr.Status.AWS.AvailabilityZones = statusAZs
 
@@ -92,18 +119,57 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 			r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status")
 
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonSubnetAllocated, fmt.Sprintf("allocated subnet %#q", subnetCIDR.String()))
+
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
 			reconciliationcanceledcontext.SetCanceled(ctx)
 		}
 
 	} else {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "found out subnet doesn't need to be allocated for cluster")
+
+		err = r.addAZs(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
 	}
 
 	return nil
 }
 
-func (r *Resource) allocateSubnet(ctx context.Context) (net.IPNet, error) {
+// allocateSubnet finds a free subnet of the given mask within the given network
+// range for the given tenant cluster and reserves it by creating an
+// IPAMAllocation CR. In case another tenant cluster
+// reserved the same subnet concurrently, the next free subnet is tried. A
+// subnet reserved for the tenant cluster in a previous reconciliation is used
+// right away.
+func (r *Resource) allocateSubnet(ctx context.Context, clusterID string, networkRange net.IPNet, mask net.IPMask) (net.IPNet, error) {
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding reserved subnet of tenant cluster")
+
+		subnet, err := r.ipamAllocation.Lookup(ctx, clusterID)
+		if ipamallocation.IsNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find reserved subnet of tenant cluster")
+		} else if err != nil {
+			return net.IPNet{}, microerror.Mask(err)
+		} else {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found reserved subnet %#q of tenant cluster", subnet.String()))
+			return subnet, nil
+		}
+	}
+
+	subnet, err := r.reserveFreeSubnet(ctx, clusterID, networkRange, mask)
+	if err != nil {
+		return net.IPNet{}, microerror.Mask(err)
+	}
+
+	return subnet, nil
+}
+
+// reserveFreeSubnet finds a subnet of the given mask within the given network
+// range neither allocated for any tenant cluster nor used by any VPC and
+// reserves it for the given tenant cluster.
+func (r *Resource) reserveFreeSubnet(ctx context.Context, clusterID string, networkRange net.IPNet, mask net.IPMask) (net.IPNet, error) {
 	var err error
 	var mutex sync.Mutex
 	var reservedSubnets []net.IPNet
@@ -142,36 +208,82 @@ func (r *Resource) allocateSubnet(ctx context.Context) (net.IPNet, error) {
 		return nil
 	})
 
+	g.Go(func() error {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding allocated subnets from IPAMAllocations")
+
+		subnets, err := r.ipamAllocation.Allocations(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		mutex.Lock()
+		reservedSubnets = append(reservedSubnets, subnets...)
+		mutex.Unlock()
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found allocated subnets from IPAMAllocations")
+
+		return nil
+	})
+
 	err = g.Wait()
 	if err != nil {
 		return net.IPNet{}, microerror.Mask(err)
 	}
 
-	reservedSubnets = ipam.CanonicalizeSubnets(r.networkRange, reservedSubnets)
+	reservedSubnets = ipam.CanonicalizeSubnets(networkRange, reservedSubnets)
 
-	var subnet net.IPNet
-	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", "finding free subnet")
+	for {
+		var subnet net.IPNet
+		{
+			r.logger.LogCtx(ctx, "level", "debug", "message", "finding free subnet")
 
-		subnet, err = ipam.Free(r.networkRange, r.allocatedSubnetMask, reservedSubnets)
-		if err != nil {
-			return net.IPNet{}, microerror.Maskf(err, "networkRange: %s, allocatedSubnetMask: %s, reservedSubnets: %#v", r.networkRange.String(), r.allocatedSubnetMask.String(), reservedSubnets)
+			subnet, err = ipam.Free(networkRange, mask, reservedSubnets)
+			if ipam.IsSpaceExhausted(err) {
+				return net.IPNet{}, microerror.Maskf(networkRangeExhaustedError, "networkRange: %s, allocatedSubnetMask: %s, reservedSubnets: %#v", networkRange.String(), mask.String(), reservedSubnets)
+			} else if err != nil {
+				return net.IPNet{}, microerror.Maskf(err, "networkRange: %s, allocatedSubnetMask: %s, reservedSubnets: %#v", networkRange.String(), mask.String(), reservedSubnets)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found free subnet %#q", subnet.String()))
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found free subnet %#q", subnet.String()))
-	}
+		{
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("reserving subnet %#q", subnet.String()))
+
+			err = r.ipamAllocation.Reserve(ctx, clusterID, subnet)
+			if ipamallocation.IsAlreadyAllocated(err) {
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not reserve subnet %#q because it got allocated for another tenant cluster", subnet.String()))
+				reservedSubnets = append(reservedSubnets, subnet)
+				continue
+			} else if err != nil {
+				return net.IPNet{}, microerror.Mask(err)
+			}
 
-	return subnet, nil
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("reserved subnet %#q", subnet.String()))
+		}
+
+		return subnet, nil
+	}
 }
 
 func (r *Resource) selectRandomAZs(n int) ([]string, error) {
-	if n > len(r.availabilityZones) {
-		return nil, microerror.Maskf(invalidParameterError, "requested nubmer of AZs %d is bigger than number of available AZs %d", n, len(r.availabilityZones))
+	azs, err := randomAZs(r.availabilityZones, n)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return azs, nil
+}
+
+// randomAZs returns n randomly selected availability zones of the given
+// availability zones sorted by name.
+func randomAZs(availabilityZones []string, n int) ([]string, error) {
+	if n > len(availabilityZones) {
+		return nil, microerror.Maskf(invalidParameterError, "requested nubmer of AZs %d is bigger than number of available AZs %d", n, len(availabilityZones))
 	}
 
 	// availabilityZones must be copied so that original slice doesn't get shuffled.
-	shuffledAZs := make([]string, len(r.availabilityZones))
-	copy(shuffledAZs, r.availabilityZones)
+	shuffledAZs := make([]string, len(availabilityZones))
+	copy(shuffledAZs, availabilityZones)
 	rand.Shuffle(len(shuffledAZs), func(i, j int) {
 		shuffledAZs[i], shuffledAZs[j] = shuffledAZs[j], shuffledAZs[i]
 	})
@@ -182,24 +294,14 @@ func (r *Resource) selectRandomAZs(n int) ([]string, error) {
 }
 
 func getAWSConfigSubnets(g8sClient versioned.Interface) ([]net.IPNet, error) {
-	awsConfigList, err := g8sClient.ProviderV1alpha1().AWSConfigs(metav1.NamespaceAll).List(metav1.ListOptions{})
+	clusterSubnets, err := ipamsubnet.AWSConfigSubnets(g8sClient)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
 	var results []net.IPNet
-	for _, ac := range awsConfigList.Items {
-		cidr := key.StatusNetworkCIDR(ac)
-		if cidr == "" {
-			continue
-		}
-
-		_, n, err := net.ParseCIDR(cidr)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-
-		results = append(results, *n)
+	for _, c := range clusterSubnets {
+		results = append(results, c.Subnet)
 	}
 
 	return results, nil
@@ -211,19 +313,14 @@ func getVPCSubnets(ctx context.Context) ([]net.IPNet, error) {
 		return nil, microerror.Mask(err)
 	}
 
-	out, err := cc.Client.TenantCluster.AWS.EC2.DescribeSubnets(nil)
+	vpcSubnets, err := ipamsubnet.VPCSubnets(cc.Client.TenantCluster.AWS.EC2)
 	if err != nil {
 		return nil, microerror.Mask(err)
 	}
 
 	var results []net.IPNet
-	for _, subnet := range out.Subnets {
-		_, n, err := net.ParseCIDR(*subnet.CidrBlock)
-		if err != nil {
-			return nil, microerror.Mask(err)
-		}
-
-		results = append(results, *n)
+	for _, v := range vpcSubnets {
+		results = append(results, v.Subnet)
 	}
 
 	return results, nil
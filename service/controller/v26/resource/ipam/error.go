@@ -19,3 +19,30 @@ var invalidParameterError = &microerror.Error{
 func IsInvalidParameter(err error) bool {
 	return microerror.Cause(err) == invalidParameterError
 }
+
+var invalidAvailabilityZoneError = &microerror.Error{
+	Kind: "invalid availability zone",
+}
+
+// IsInvalidAvailabilityZone asserts invalidAvailabilityZoneError.
+func IsInvalidAvailabilityZone(err error) bool {
+	return microerror.Cause(err) == invalidAvailabilityZoneError
+}
+
+var invalidNetworkRangeError = &microerror.Error{
+	Kind: "invalid network range",
+}
+
+// IsInvalidNetworkRange asserts invalidNetworkRangeError.
+func IsInvalidNetworkRange(err error) bool {
+	return microerror.Cause(err) == invalidNetworkRangeError
+}
+
+var networkRangeExhaustedError = &microerror.Error{
+	Kind: "network range exhausted",
+}
+
+// IsNetworkRangeExhausted asserts networkRangeExhaustedError.
+func IsNetworkRangeExhausted(err error) bool {
+	return microerror.Cause(err) == networkRangeExhaustedError
+}
@@ -0,0 +1,163 @@
+package ipam
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"net"
+	"time"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+const (
+	// defaultNetworkRangeName is the name of the network range used for tenant
+	// clusters not requesting a named network range.
+	defaultNetworkRangeName = "default"
+)
+
+const (
+	// minSubnetMaskBits and maxSubnetMaskBits are the boundaries AWS puts on
+	// the network masks of VPC CIDR blocks and subnets.
+	minSubnetMaskBits = 16
+	maxSubnetMaskBits = 28
+)
+
+// selectNetworkRange returns the name and the network segment of the network
+// range the tenant cluster's network segment is allocated from.
+func (r *Resource) selectNetworkRange(ctx context.Context, cr v1alpha1.AWSConfig) (string, net.IPNet, error) {
+	name := key.SpecNetworkRange(cr)
+	if name == "" {
+		return defaultNetworkRangeName, r.networkRange, nil
+	}
+
+	networkRange, ok := r.networkRanges[name]
+	if !ok {
+		err := microerror.Maskf(invalidNetworkRangeError, "network range %#q is not configured for the installation", name)
+		return "", net.IPNet{}, r.invalidNetworkRange(ctx, cr, err)
+	}
+
+	return name, networkRange, nil
+}
+
+// selectSubnetMask returns the network mask of the tenant cluster's network
+// segment allocated from the given network range.
+func (r *Resource) selectSubnetMask(ctx context.Context, cr v1alpha1.AWSConfig, networkRange net.IPNet) (net.IPMask, error) {
+	mask := r.allocatedSubnetMask
+	if key.SpecSubnetMaskBits(cr) != 0 {
+		mask = net.CIDRMask(key.SpecSubnetMaskBits(cr), 32)
+	}
+
+	err := validateSubnetMask(networkRange, mask, key.SpecAvailabilityZoneCount(cr))
+	if err != nil {
+		return nil, r.invalidNetworkRange(ctx, cr, err)
+	}
+
+	return mask, nil
+}
+
+// validateSubnetMask checks if a network segment of the given mask fits into
+// the given network range and can be split into a private and a public subnet
+// for each of the given number of availability zones.
+func validateSubnetMask(networkRange net.IPNet, mask net.IPMask, azCount int) error {
+	ones, _ := mask.Size()
+	rangeOnes, _ := networkRange.Mask.Size()
+
+	if ones < minSubnetMaskBits {
+		return microerror.Maskf(invalidNetworkRangeError, "subnet mask bits %d must not be smaller than %d", ones, minSubnetMaskBits)
+	}
+	if ones < rangeOnes {
+		return microerror.Maskf(invalidNetworkRangeError, "subnet mask bits %d must not be smaller than the mask bits %d of network range %s", ones, rangeOnes, networkRange.String())
+	}
+
+	// Each availability zone gets a private and a public subnet, both of which
+	// must not exceed the AWS limits.
+	subnetOnes := ones
+	if azCount > 0 {
+		subnetOnes += bits.Len(uint(azCount*2 - 1))
+	}
+	if subnetOnes > maxSubnetMaskBits {
+		return microerror.Maskf(invalidNetworkRangeError, "subnet mask bits %d leave no room for the subnets of %d availability zones", ones, azCount)
+	}
+
+	return nil
+}
+
+func (r *Resource) invalidNetworkRange(ctx context.Context, cr v1alpha1.AWSConfig, err error) error {
+	r.logger.LogCtx(ctx, "level", "warning", "message", "requested network range is invalid", "stack", fmt.Sprintf("%#v", err))
+
+	r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonNetworkRangeInvalid, err.Error())
+
+	return microerror.Mask(err)
+}
+
+// networkRangeExhausted records the exhausted network range in the CR status
+// conditions and cancels the reconciliation. The allocation is retried with
+// the next reconciliation, e.g. after other tenant clusters got deleted. The
+// condition is removed as soon as the allocation succeeds.
+func (r *Resource) networkRangeExhausted(ctx context.Context, cr v1alpha1.AWSConfig, name string, err error) error {
+	r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("network range %#q is exhausted", name), "stack", fmt.Sprintf("%#v", err))
+
+	message := fmt.Sprintf("network range %#q has no room left for the tenant cluster's network segment", name)
+
+	if !hasCondition(cr.Status.Cluster.Conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted, message) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status")
+
+		cr.Status.Cluster.Conditions = withNetworkRangeExhaustedCondition(cr.Status.Cluster.Conditions, message, time.Now())
+
+		_, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).UpdateStatus(&cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status")
+	}
+
+	r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonNetworkRangeExhausted, message)
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+	reconciliationcanceledcontext.SetCanceled(ctx)
+
+	return nil
+}
+
+func hasCondition(conditions []v1alpha1.StatusClusterCondition, conditionType string, message string) bool {
+	for _, c := range conditions {
+		if c.Type == conditionType && c.Status == v1alpha1.StatusClusterStatusTrue && c.Message == message {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withNetworkRangeExhaustedCondition(conditions []v1alpha1.StatusClusterCondition, message string, t time.Time) []v1alpha1.StatusClusterCondition {
+	newConditions := []v1alpha1.StatusClusterCondition{
+		{
+			LastTransitionTime: v1alpha1.DeepCopyTime{Time: t},
+			Message:            message,
+			Status:             v1alpha1.StatusClusterStatusTrue,
+			Type:               v1alpha1.StatusClusterTypeNetworkRangeExhausted,
+		},
+	}
+
+	return append(newConditions, withoutCondition(conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted)...)
+}
+
+func withoutCondition(conditions []v1alpha1.StatusClusterCondition, conditionType string) []v1alpha1.StatusClusterCondition {
+	var newConditions []v1alpha1.StatusClusterCondition
+	for _, c := range conditions {
+		if c.Type == conditionType {
+			continue
+		}
+
+		newConditions = append(newConditions, c)
+	}
+
+	return newConditions
+}
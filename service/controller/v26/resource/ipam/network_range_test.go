@@ -0,0 +1,111 @@
+package ipam
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
+func Test_validateSubnetMask(t *testing.T) {
+	testCases := []struct {
+		name         string
+		networkRange string
+		maskBits     int
+		azCount      int
+		errorMatcher func(error) bool
+	}{
+		{
+			name:         "case 0: default size with three availability zones",
+			networkRange: "10.1.0.0/16",
+			maskBits:     24,
+			azCount:      3,
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 1: segment as big as the network range",
+			networkRange: "10.1.0.0/16",
+			maskBits:     16,
+			azCount:      1,
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 2: segment bigger than the network range",
+			networkRange: "10.1.0.0/20",
+			maskBits:     19,
+			azCount:      1,
+			errorMatcher: IsInvalidNetworkRange,
+		},
+		{
+			name:         "case 3: segment bigger than AWS allows",
+			networkRange: "10.0.0.0/8",
+			maskBits:     15,
+			azCount:      1,
+			errorMatcher: IsInvalidNetworkRange,
+		},
+		{
+			name:         "case 4: smallest segment for one availability zone",
+			networkRange: "10.1.0.0/16",
+			maskBits:     27,
+			azCount:      1,
+			errorMatcher: nil,
+		},
+		{
+			name:         "case 5: segment too small for three availability zones",
+			networkRange: "10.1.0.0/16",
+			maskBits:     27,
+			azCount:      3,
+			errorMatcher: IsInvalidNetworkRange,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, networkRange, err := net.ParseCIDR(tc.networkRange)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validateSubnetMask(*networkRange, net.CIDRMask(tc.maskBits, 32), tc.azCount)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+		})
+	}
+}
+
+func Test_withNetworkRangeExhaustedCondition(t *testing.T) {
+	conditions := []v1alpha1.StatusClusterCondition{
+		{
+			Status: v1alpha1.StatusClusterStatusTrue,
+			Type:   v1alpha1.StatusClusterTypeCreating,
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		conditions = withNetworkRangeExhaustedCondition(conditions, "message "+strconv.Itoa(i), time.Now())
+	}
+
+	if len(conditions) != 2 {
+		t.Fatalf("expected %d conditions got %d", 2, len(conditions))
+	}
+	if !hasCondition(conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted, "message 1") {
+		t.Fatalf("expected condition %#q with latest message", v1alpha1.StatusClusterTypeNetworkRangeExhausted)
+	}
+
+	conditions = withoutCondition(conditions, v1alpha1.StatusClusterTypeNetworkRangeExhausted)
+
+	if len(conditions) != 1 || conditions[0].Type != v1alpha1.StatusClusterTypeCreating {
+		t.Fatalf("expected only condition %#q got %#v", v1alpha1.StatusClusterTypeCreating, conditions)
+	}
+}
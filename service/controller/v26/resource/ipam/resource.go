@@ -7,6 +7,9 @@ import (
 	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder"
+	"github.com/giantswarm/aws-operator/service/ipamallocation"
 )
 
 const (
@@ -14,27 +17,43 @@ const (
 )
 
 type Config struct {
-	G8sClient versioned.Interface
-	Logger    micrologger.Logger
+	EventRecorder  recorder.Interface
+	G8sClient      versioned.Interface
+	IPAMAllocation ipamallocation.Interface
+	Logger         micrologger.Logger
 
 	AllocatedSubnetMaskBits int
 	AvailabilityZones       []string
 	NetworkRange            net.IPNet
+	// NetworkRanges are the named network ranges tenant clusters may request
+	// their network segment to be allocated from. Tenant clusters not
+	// requesting a named network range get their network segment allocated
+	// from NetworkRange.
+	NetworkRanges map[string]net.IPNet
 }
 
 type Resource struct {
-	g8sClient versioned.Interface
-	logger    micrologger.Logger
+	eventRecorder  recorder.Interface
+	g8sClient      versioned.Interface
+	ipamAllocation ipamallocation.Interface
+	logger         micrologger.Logger
 
 	allocatedSubnetMask net.IPMask
 	availabilityZones   []string
 	networkRange        net.IPNet
+	networkRanges       map[string]net.IPNet
 }
 
 func New(config Config) (*Resource, error) {
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
 	if config.G8sClient == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
 	}
+	if config.IPAMAllocation == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.IPAMAllocation must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
@@ -45,14 +64,22 @@ func New(config Config) (*Resource, error) {
 	if reflect.DeepEqual(config.NetworkRange, net.IPNet{}) {
 		return nil, microerror.Maskf(invalidConfigError, "%T.NetworkRange must not be empty", config)
 	}
+	for name, networkRange := range config.NetworkRanges {
+		if reflect.DeepEqual(networkRange, net.IPNet{}) {
+			return nil, microerror.Maskf(invalidConfigError, "%T.NetworkRanges[%#q] must not be empty", config, name)
+		}
+	}
 
 	newResource := &Resource{
-		g8sClient: config.G8sClient,
-		logger:    config.Logger,
+		eventRecorder:  config.EventRecorder,
+		g8sClient:      config.G8sClient,
+		ipamAllocation: config.IPAMAllocation,
+		logger:         config.Logger,
 
 		allocatedSubnetMask: net.CIDRMask(config.AllocatedSubnetMaskBits, 32),
 		availabilityZones:   config.AvailabilityZones,
 		networkRange:        config.NetworkRange,
+		networkRanges:       config.NetworkRanges,
 	}
 
 	return newResource, nil
@@ -3,17 +3,28 @@ package loadbalancer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/finalizerskeptcontext"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
-// EnsureDeleted ensures that any ELBs from Kubernetes LoadBalancer services
-// are deleted. This is needed because the use the VPC public subnet.
+// EnsureDeleted ensures that any ELBs from Kubernetes LoadBalancer services as
+// well as NLBs, ALBs and their target groups created within the tenant cluster
+// are deleted. This is needed because the use the VPC public subnet. The
+// reconciliation is canceled until the network interfaces of the deleted load
+// balancers are gone, so that the tccp resource does not try to delete the
+// tenant cluster's VPC too early. Only the network interfaces of the load
+// balancers deleted by the resource are waited for, and only until the
+// deadline of eniDeletionTimeout passed. The deleted load balancers are kept in
+// memory, so there is nothing to wait for after a restart of the operator.
 func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 	customObject, err := key.ToCustomObject(obj)
 	if err != nil {
@@ -42,10 +53,107 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 			}
 		}
 
+		r.addDeletion(key.ClusterID(customObject), lbState.LoadBalancerNames)
+
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted %d load balancers", len(lbState.LoadBalancerNames)))
 	} else {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "not deleting load balancers because there aren't any")
 	}
 
+	if lbState != nil && len(lbState.LoadBalancerARNs) > 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleting %d elbv2 load balancers", len(lbState.LoadBalancerARNs)))
+
+		cc, err := controllercontext.FromContext(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, lbARN := range lbState.LoadBalancerARNs {
+			_, err := cc.Client.TenantCluster.AWS.ELBv2.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{
+				LoadBalancerArn: aws.String(lbARN),
+			})
+			if IsNotFound(err) {
+				// The load balancer got deleted in the meantime.
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			r.addDeletion(key.ClusterID(customObject), []string{loadBalancerNameFromARN(lbARN)})
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted %d elbv2 load balancers", len(lbState.LoadBalancerARNs)))
+	} else {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "not deleting elbv2 load balancers because there aren't any")
+	}
+
+	if lbState != nil && len(lbState.TargetGroupARNs) > 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleting %d target groups", len(lbState.TargetGroupARNs)))
+
+		cc, err := controllercontext.FromContext(ctx)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		var inUse int
+		for _, tgARN := range lbState.TargetGroupARNs {
+			_, err := cc.Client.TenantCluster.AWS.ELBv2.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{
+				TargetGroupArn: aws.String(tgARN),
+			})
+			if IsResourceInUse(err) {
+				// The load balancer the target group is attached to is still
+				// being deleted. We try again during the next reconciliation.
+				inUse++
+			} else if IsNotFound(err) {
+				// The target group got deleted in the meantime.
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted %d target groups, %d target groups are still in use", len(lbState.TargetGroupARNs)-inUse, inUse))
+
+		if inUse > 0 {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+			finalizerskeptcontext.SetKept(ctx)
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+			reconciliationcanceledcontext.SetCanceled(ctx)
+
+			return nil
+		}
+	} else {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "not deleting target groups because there aren't any")
+	}
+
+	if d, ok := r.getDeletion(key.ClusterID(customObject)); ok {
+		if time.Now().After(d.Deadline) {
+			r.logger.LogCtx(ctx, "level", "warning", "message", fmt.Sprintf("stopped waiting for network interfaces of deleted load balancers %#q after %s", d.LoadBalancerNames, eniDeletionTimeout))
+			r.removeDeletion(key.ClusterID(customObject))
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding network interfaces of deleted load balancers")
+
+		eniIDs, err := r.clusterLoadBalancerENIs(ctx, customObject, d.LoadBalancerNames)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if len(eniIDs) > 0 {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d network interfaces of deleted load balancers", len(eniIDs)))
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "keeping finalizers")
+			finalizerskeptcontext.SetKept(ctx)
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+			reconciliationcanceledcontext.SetCanceled(ctx)
+
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find network interfaces of deleted load balancers")
+		r.removeDeletion(key.ClusterID(customObject))
+	}
+
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
 
@@ -12,6 +13,7 @@ import (
 )
 
 const (
+	cloudFormationStackTagKey    = "aws:cloudformation:stack-name"
 	cloudProviderClusterTagValue = "owned"
 	cloudProviderServiceTagKey   = "kubernetes.io/service-name"
 	loadBalancerTagChunkSize     = 20
@@ -19,6 +21,32 @@ const (
 
 func (r *Resource) clusterLoadBalancers(ctx context.Context, customObject v1alpha1.AWSConfig) (*LoadBalancerState, error) {
 	lbState := &LoadBalancerState{}
+
+	clusterLBNames, err := r.clusterClassicLoadBalancers(ctx, customObject)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	clusterLBARNs, err := r.clusterV2LoadBalancers(ctx, customObject)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	clusterTGARNs, err := r.clusterV2TargetGroups(ctx, customObject)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	lbState.LoadBalancerARNs = clusterLBARNs
+	lbState.LoadBalancerNames = clusterLBNames
+	lbState.TargetGroupARNs = clusterTGARNs
+
+	return lbState, nil
+}
+
+// clusterClassicLoadBalancers returns the names of the classic ELBs created by
+// Kubernetes services of the tenant cluster.
+func (r *Resource) clusterClassicLoadBalancers(ctx context.Context, customObject v1alpha1.AWSConfig) ([]string, error) {
 	clusterLBNames := []string{}
 
 	cc, err := controllercontext.FromContext(ctx)
@@ -51,15 +79,138 @@ func (r *Resource) clusterLoadBalancers(ctx context.Context, customObject v1alph
 		// We filter based on the AWS cloud provider tags to find load balancers
 		// associated with the cluster being processed.
 		for _, lb := range tagsOutput.TagDescriptions {
-			if containsClusterTag(lb.Tags, customObject) && containsServiceTag(lb.Tags) {
+			tags := map[string]string{}
+			for _, t := range lb.Tags {
+				tags[*t.Key] = *t.Value
+			}
+
+			if containsClusterTag(tags, customObject) && containsServiceTag(tags) {
 				clusterLBNames = append(clusterLBNames, *lb.LoadBalancerName)
 			}
 		}
 	}
 
-	lbState.LoadBalancerNames = clusterLBNames
+	return clusterLBNames, nil
+}
 
-	return lbState, nil
+// clusterV2LoadBalancers returns the ARNs of the NLBs and ALBs created within
+// the tenant cluster, e.g. by Kubernetes services or ingress controllers.
+func (r *Resource) clusterV2LoadBalancers(ctx context.Context, customObject v1alpha1.AWSConfig) ([]string, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// We get all load balancers because the API does not allow tag filters.
+	allLBARNs := []*string{}
+	{
+		var marker *string
+		for {
+			i := &elbv2.DescribeLoadBalancersInput{
+				Marker: marker,
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.ELBv2.DescribeLoadBalancers(i)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			for _, lb := range o.LoadBalancers {
+				allLBARNs = append(allLBARNs, lb.LoadBalancerArn)
+			}
+
+			if o.NextMarker == nil {
+				break
+			}
+			marker = o.NextMarker
+		}
+	}
+
+	clusterLBARNs, err := r.filterV2Resources(ctx, customObject, allLBARNs)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return clusterLBARNs, nil
+}
+
+// clusterV2TargetGroups returns the ARNs of the target groups created within
+// the tenant cluster, e.g. by Kubernetes services or ingress controllers.
+func (r *Resource) clusterV2TargetGroups(ctx context.Context, customObject v1alpha1.AWSConfig) ([]string, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	// We get all target groups because the API does not allow tag filters.
+	allTGARNs := []*string{}
+	{
+		var marker *string
+		for {
+			i := &elbv2.DescribeTargetGroupsInput{
+				Marker: marker,
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.ELBv2.DescribeTargetGroups(i)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			for _, tg := range o.TargetGroups {
+				allTGARNs = append(allTGARNs, tg.TargetGroupArn)
+			}
+
+			if o.NextMarker == nil {
+				break
+			}
+			marker = o.NextMarker
+		}
+	}
+
+	clusterTGARNs, err := r.filterV2Resources(ctx, customObject, allTGARNs)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return clusterTGARNs, nil
+}
+
+// filterV2Resources returns the ARNs of the given ELBv2 resources which carry
+// the cluster cloud provider tag. Resources managed by Cloud Formation, like
+// the load balancers of the tenant cluster's control plane stack, are left to
+// Cloud Formation.
+func (r *Resource) filterV2Resources(ctx context.Context, customObject v1alpha1.AWSConfig, allARNs []*string) ([]string, error) {
+	clusterARNs := []string{}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	chunks := splitLoadBalancers(allARNs, loadBalancerTagChunkSize)
+
+	for _, arns := range chunks {
+		tagsInput := &elbv2.DescribeTagsInput{
+			ResourceArns: arns,
+		}
+		tagsOutput, err := cc.Client.TenantCluster.AWS.ELBv2.DescribeTags(tagsInput)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, d := range tagsOutput.TagDescriptions {
+			tags := map[string]string{}
+			for _, t := range d.Tags {
+				tags[*t.Key] = *t.Value
+			}
+
+			if containsClusterTag(tags, customObject) && !containsCloudFormationTag(tags) {
+				clusterARNs = append(clusterARNs, *d.ResourceArn)
+			}
+		}
+	}
+
+	return clusterARNs, nil
 }
 
 func splitLoadBalancers(loadBalancerNames []*string, chunkSize int) [][]*string {
@@ -78,23 +229,16 @@ func splitLoadBalancers(loadBalancerNames []*string, chunkSize int) [][]*string
 	return chunks
 }
 
-func containsClusterTag(tags []*elb.Tag, customObject v1alpha1.AWSConfig) bool {
-	tagKey := key.ClusterCloudProviderTag(customObject)
-
-	for _, tag := range tags {
-		if *tag.Key == tagKey && *tag.Value == cloudProviderClusterTagValue {
-			return true
-		}
-	}
-	return false
+func containsClusterTag(tags map[string]string, customObject v1alpha1.AWSConfig) bool {
+	return tags[key.ClusterCloudProviderTag(customObject)] == cloudProviderClusterTagValue
 }
 
-func containsServiceTag(tags []*elb.Tag) bool {
-	for _, tag := range tags {
-		if *tag.Key == cloudProviderServiceTagKey {
-			return true
-		}
-	}
+func containsServiceTag(tags map[string]string) bool {
+	_, ok := tags[cloudProviderServiceTagKey]
+	return ok
+}
 
-	return false
+func containsCloudFormationTag(tags map[string]string) bool {
+	_, ok := tags[cloudFormationStackTagKey]
+	return ok
 }
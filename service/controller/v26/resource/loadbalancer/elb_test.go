@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/micrologger/microloggertest"
 
@@ -25,25 +26,31 @@ func Test_clusterLoadBalancers(t *testing.T) {
 	}
 
 	testCases := []struct {
-		description   string
-		obj           v1alpha1.AWSConfig
-		expectedState *LoadBalancerState
-		loadBalancers []LoadBalancerMock
+		description     string
+		obj             v1alpha1.AWSConfig
+		expectedState   *LoadBalancerState
+		loadBalancers   []LoadBalancerMock
+		v2LoadBalancers []LoadBalancerV2Mock
+		v2TargetGroups  []LoadBalancerV2Mock
 	}{
 		{
 			description: "basic match with no load balancers",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs:  []string{},
 				LoadBalancerNames: []string{},
+				TargetGroupARNs:   []string{},
 			},
 		},
 		{
 			description: "basic match with load balancer",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{},
 				LoadBalancerNames: []string{
 					"test-elb",
 				},
+				TargetGroupARNs: []string{},
 			},
 			loadBalancers: []LoadBalancerMock{
 				{
@@ -65,7 +72,9 @@ func Test_clusterLoadBalancers(t *testing.T) {
 			description: "no matching load balancer",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs:  []string{},
 				LoadBalancerNames: []string{},
+				TargetGroupARNs:   []string{},
 			},
 			loadBalancers: []LoadBalancerMock{
 				{
@@ -87,10 +96,12 @@ func Test_clusterLoadBalancers(t *testing.T) {
 			description: "multiple load balancers",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{},
 				LoadBalancerNames: []string{
 					"test-elb",
 					"test-elb-2",
 				},
+				TargetGroupARNs: []string{},
 			},
 			loadBalancers: []LoadBalancerMock{
 				{
@@ -125,10 +136,12 @@ func Test_clusterLoadBalancers(t *testing.T) {
 			description: "multiple load balancers some not matching",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{},
 				LoadBalancerNames: []string{
 					"test-elb",
 					"test-elb-2",
 				},
+				TargetGroupARNs: []string{},
 			},
 			loadBalancers: []LoadBalancerMock{
 				{
@@ -176,7 +189,9 @@ func Test_clusterLoadBalancers(t *testing.T) {
 			description: "missing service tag",
 			obj:         customObject,
 			expectedState: &LoadBalancerState{
+				LoadBalancerARNs:  []string{},
 				LoadBalancerNames: []string{},
+				TargetGroupARNs:   []string{},
 			},
 			loadBalancers: []LoadBalancerMock{
 				{
@@ -190,6 +205,175 @@ func Test_clusterLoadBalancers(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "network load balancer",
+			obj:         customObject,
+			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{
+					"arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-nlb/1234567890",
+				},
+				LoadBalancerNames: []string{},
+				TargetGroupARNs:   []string{},
+			},
+			v2LoadBalancers: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-nlb/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/service-name"),
+							Value: aws.String("hello-world"),
+						},
+					},
+				},
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-nlb-2/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/other-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/service-name"),
+							Value: aws.String("hello-world"),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "classic and network load balancers",
+			obj:         customObject,
+			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{
+					"arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-nlb/1234567890",
+				},
+				LoadBalancerNames: []string{
+					"test-elb",
+				},
+				TargetGroupARNs: []string{},
+			},
+			loadBalancers: []LoadBalancerMock{
+				{
+					loadBalancerName: "test-elb",
+					loadBalancerTags: []*elb.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/service-name"),
+							Value: aws.String("hello-world"),
+						},
+					},
+				},
+			},
+			v2LoadBalancers: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-nlb/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/service-name"),
+							Value: aws.String("hello-world-2"),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "application load balancer and target group of ingress controller",
+			obj:         customObject,
+			expectedState: &LoadBalancerState{
+				LoadBalancerARNs: []string{
+					"arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/app/test-alb/1234567890",
+				},
+				LoadBalancerNames: []string{},
+				TargetGroupARNs: []string{
+					"arn:aws:elasticloadbalancing:eu-central-1:123456789012:targetgroup/test-tg/1234567890",
+				},
+			},
+			v2LoadBalancers: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/app/test-alb/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("kubernetes.io/ingress-name"),
+							Value: aws.String("hello-world"),
+						},
+					},
+				},
+			},
+			v2TargetGroups: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:targetgroup/test-tg/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+					},
+				},
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:targetgroup/test-tg-2/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/other-cluster"),
+							Value: aws.String("owned"),
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "network load balancer and target group of control plane stack",
+			obj:         customObject,
+			expectedState: &LoadBalancerState{
+				LoadBalancerARNs:  []string{},
+				LoadBalancerNames: []string{},
+				TargetGroupARNs:   []string{},
+			},
+			v2LoadBalancers: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/test-cluster-api/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("aws:cloudformation:stack-name"),
+							Value: aws.String("cluster-test-cluster-guest-main"),
+						},
+					},
+				},
+			},
+			v2TargetGroups: []LoadBalancerV2Mock{
+				{
+					loadBalancerARN: "arn:aws:elasticloadbalancing:eu-central-1:123456789012:targetgroup/test-cluster-api/1234567890",
+					loadBalancerTags: []*elbv2.Tag{
+						{
+							Key:   aws.String("kubernetes.io/cluster/test-cluster"),
+							Value: aws.String("owned"),
+						},
+						{
+							Key:   aws.String("aws:cloudformation:stack-name"),
+							Value: aws.String("cluster-test-cluster-guest-main"),
+						},
+					},
+				},
+			},
+		},
 	}
 	var err error
 	var newResource *Resource
@@ -208,6 +392,10 @@ func Test_clusterLoadBalancers(t *testing.T) {
 				ELB: &ELBClientMock{
 					loadBalancers: tc.loadBalancers,
 				},
+				ELBv2: &ELBv2ClientMock{
+					loadBalancers: tc.v2LoadBalancers,
+					targetGroups:  tc.v2TargetGroups,
+				},
 			}
 			ctx := context.TODO()
 			cc := controllercontext.Context{
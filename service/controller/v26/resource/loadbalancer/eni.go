@@ -0,0 +1,149 @@
+package loadbalancer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+const (
+	// loadBalancerENIDescriptionPrefix is the prefix of the descriptions of the
+	// network interfaces AWS creates for load balancers. The descriptions look
+	// like the following.
+	//
+	//     ELB my-classic-elb
+	//     ELB app/my-alb/50dc6c495c0c9188
+	//     ELB net/my-nlb/50dc6c495c0c9188
+	//
+	loadBalancerENIDescriptionPrefix = "ELB "
+)
+
+// clusterLoadBalancerENIs returns the IDs of the network interfaces of the
+// given load balancers in the tenant cluster's VPC. Deleting a load balancer
+// returns before its network interfaces are released. As long as they exist
+// the tenant cluster's VPC cannot be deleted. Existing VPCs are not deleted
+// together with the tenant cluster and may be shared with other workloads, so
+// there is nothing to wait for in this case.
+func (r *Resource) clusterLoadBalancerENIs(ctx context.Context, customObject v1alpha1.AWSConfig, lbNames []string) ([]string, error) {
+	if key.IsExistingVPC(customObject) {
+		return nil, nil
+	}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var vpcID string
+	{
+		i := &ec2.DescribeVpcsInput{
+			Filters: []*ec2.Filter{
+				{
+					Name: aws.String("tag:Name"),
+					Values: []*string{
+						aws.String(key.ClusterID(customObject)),
+					},
+				},
+			},
+		}
+
+		o, err := cc.Client.TenantCluster.AWS.EC2.DescribeVpcs(i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if len(o.Vpcs) != 1 {
+			// The tenant cluster's VPC does not exist anymore or was never
+			// created, so there are no network interfaces to wait for.
+			return nil, nil
+		}
+
+		vpcID = *o.Vpcs[0].VpcId
+	}
+
+	var eniIDs []string
+	{
+		i := &ec2.DescribeNetworkInterfacesInput{
+			Filters: []*ec2.Filter{
+				{
+					Name: aws.String("vpc-id"),
+					Values: []*string{
+						aws.String(vpcID),
+					},
+				},
+				{
+					Name: aws.String("description"),
+					Values: []*string{
+						aws.String(loadBalancerENIDescriptionPrefix + "*"),
+					},
+				},
+			},
+		}
+
+		o, err := cc.Client.TenantCluster.AWS.EC2.DescribeNetworkInterfaces(i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, n := range o.NetworkInterfaces {
+			name := loadBalancerNameFromENIDescription(aws.StringValue(n.Description))
+			if !containsString(lbNames, name) {
+				continue
+			}
+
+			eniIDs = append(eniIDs, *n.NetworkInterfaceId)
+		}
+	}
+
+	return eniIDs, nil
+}
+
+// loadBalancerNameFromENIDescription returns the name of the load balancer
+// the network interface with the given description belongs to. An empty
+// string is returned in case the network interface does not belong to a load
+// balancer.
+func loadBalancerNameFromENIDescription(description string) string {
+	if !strings.HasPrefix(description, loadBalancerENIDescriptionPrefix) {
+		return ""
+	}
+
+	name := strings.TrimPrefix(description, loadBalancerENIDescriptionPrefix)
+
+	parts := strings.Split(name, "/")
+	if len(parts) == 3 {
+		return parts[1]
+	}
+
+	return name
+}
+
+// loadBalancerNameFromARN returns the name of the NLB or ALB with the given
+// ARN, which looks like the following.
+//
+//     arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/my-nlb/50dc6c495c0c9188
+//
+func loadBalancerNameFromARN(arn string) string {
+	parts := strings.Split(arn, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+
+	return parts[len(parts)-2]
+}
+
+func containsString(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
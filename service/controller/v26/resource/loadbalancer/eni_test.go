@@ -0,0 +1,85 @@
+package loadbalancer
+
+import (
+	"testing"
+)
+
+func Test_loadBalancerNameFromENIDescription(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		description  string
+		expectedName string
+	}{
+		{
+			name:         "case 0: classic load balancer",
+			description:  "ELB a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+			expectedName: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+		},
+		{
+			name:         "case 1: network load balancer",
+			description:  "ELB net/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4/50dc6c495c0c9188",
+			expectedName: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+		},
+		{
+			name:         "case 2: application load balancer",
+			description:  "ELB app/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4/50dc6c495c0c9188",
+			expectedName: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+		},
+		{
+			name:         "case 3: network interface not belonging to a load balancer",
+			description:  "Interface for NAT Gateway nat-0a1b2c3d4e5f6a7b8",
+			expectedName: "",
+		},
+		{
+			name:         "case 4: empty description",
+			description:  "",
+			expectedName: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := loadBalancerNameFromENIDescription(tc.description)
+
+			if name != tc.expectedName {
+				t.Fatalf("name == %#q, want %#q", name, tc.expectedName)
+			}
+		})
+	}
+}
+
+func Test_loadBalancerNameFromARN(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		arn          string
+		expectedName string
+	}{
+		{
+			name:         "case 0: network load balancer",
+			arn:          "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/net/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4/50dc6c495c0c9188",
+			expectedName: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+		},
+		{
+			name:         "case 1: application load balancer",
+			arn:          "arn:aws:elasticloadbalancing:eu-central-1:123456789012:loadbalancer/app/a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4/50dc6c495c0c9188",
+			expectedName: "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+		},
+		{
+			name:         "case 2: malformed ARN",
+			arn:          "a1b2c3d4e5f6a1b2c3d4e5f6a1b2c3d4",
+			expectedName: "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := loadBalancerNameFromARN(tc.arn)
+
+			if name != tc.expectedName {
+				t.Fatalf("name == %#q, want %#q", name, tc.expectedName)
+			}
+		})
+	}
+}
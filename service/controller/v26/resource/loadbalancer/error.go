@@ -1,6 +1,8 @@
 package loadbalancer
 
 import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/giantswarm/microerror"
 )
 
@@ -21,3 +23,37 @@ var wrongTypeError = &microerror.Error{
 func IsWrongType(err error) bool {
 	return microerror.Cause(err) == wrongTypeError
 }
+
+// IsNotFound asserts the AWS error codes of ELBv2 load balancers and target
+// groups not being found, e.g. because they got deleted concurrently.
+func IsNotFound(err error) bool {
+	c := microerror.Cause(err)
+
+	aerr, ok := c.(awserr.Error)
+	if ok {
+		if aerr.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+			return true
+		}
+		if aerr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsResourceInUse asserts the AWS error code ResourceInUse. Target groups
+// cannot be deleted as long as the load balancer they are attached to is still
+// being deleted.
+func IsResourceInUse(err error) bool {
+	c := microerror.Cause(err)
+
+	aerr, ok := c.(awserr.Error)
+	if ok {
+		if aerr.Code() == elbv2.ErrCodeResourceInUseException {
+			return true
+		}
+	}
+
+	return false
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elb"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 )
 
 type ELBClientMock struct {
@@ -51,3 +53,75 @@ func (e *ELBClientMock) DescribeTags(*elb.DescribeTagsInput) (*elb.DescribeTagsO
 
 	return output, nil
 }
+
+type ELBv2ClientMock struct {
+	elbv2iface.ELBV2API
+
+	loadBalancers []LoadBalancerV2Mock
+	targetGroups  []LoadBalancerV2Mock
+}
+
+// LoadBalancerV2Mock is used for load balancers as well as target groups,
+// since both are identified by their ARN and carry tags.
+type LoadBalancerV2Mock struct {
+	loadBalancerARN  string
+	loadBalancerTags []*elbv2.Tag
+}
+
+func (e *ELBv2ClientMock) DeleteLoadBalancer(*elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error) {
+	return nil, nil
+}
+
+func (e *ELBv2ClientMock) DeleteTargetGroup(*elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error) {
+	return nil, nil
+}
+
+func (e *ELBv2ClientMock) DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error) {
+	output := &elbv2.DescribeLoadBalancersOutput{}
+	lbs := []*elbv2.LoadBalancer{}
+
+	for _, lb := range e.loadBalancers {
+		lbs = append(lbs, &elbv2.LoadBalancer{
+			LoadBalancerArn: aws.String(lb.loadBalancerARN),
+		})
+	}
+	output.SetLoadBalancers(lbs)
+
+	return output, nil
+}
+
+func (e *ELBv2ClientMock) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	output := &elbv2.DescribeTagsOutput{}
+	tagDescs := []*elbv2.TagDescription{}
+
+	for _, arn := range input.ResourceArns {
+		for _, lb := range append(e.loadBalancers, e.targetGroups...) {
+			if lb.loadBalancerARN != *arn {
+				continue
+			}
+
+			tagDesc := &elbv2.TagDescription{
+				ResourceArn: aws.String(lb.loadBalancerARN),
+				Tags:        lb.loadBalancerTags,
+			}
+			tagDescs = append(tagDescs, tagDesc)
+		}
+	}
+	output.SetTagDescriptions(tagDescs)
+
+	return output, nil
+}
+
+func (e *ELBv2ClientMock) DescribeTargetGroups(*elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error) {
+	output := &elbv2.DescribeTargetGroupsOutput{}
+	tgs := []*elbv2.TargetGroup{}
+
+	for _, tg := range e.targetGroups {
+		tgs = append(tgs, &elbv2.TargetGroup{
+			TargetGroupArn: aws.String(tg.loadBalancerARN),
+		})
+	}
+	output.SetTargetGroups(tgs)
+
+	return output, nil
+}
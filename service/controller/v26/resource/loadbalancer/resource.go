@@ -1,6 +1,9 @@
 package loadbalancer
 
 import (
+	"sync"
+	"time"
+
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 )
@@ -10,6 +13,14 @@ const (
 	Name = "loadbalancerv26"
 )
 
+const (
+	// eniDeletionTimeout is the time the resource waits for the network
+	// interfaces of the deleted load balancers to be released. Network
+	// interfaces still existing afterwards are left to the deletion of the
+	// tenant cluster's VPC, so that the deletion cannot get stuck.
+	eniDeletionTimeout = 15 * time.Minute
+)
+
 // Config represents the configuration used to create a new loadbalancer resource.
 type Config struct {
 	// Dependencies.
@@ -20,6 +31,20 @@ type Config struct {
 type Resource struct {
 	// Dependencies.
 	logger micrologger.Logger
+
+	// deletions is a mapping of tenant cluster IDs and the load balancers the
+	// resource deleted for them, whose network interfaces are waited for.
+	deletions map[string]deletion
+	mutex     sync.Mutex
+}
+
+// deletion is a set of deleted load balancers of a tenant cluster.
+type deletion struct {
+	// Deadline is the time until which the network interfaces of the deleted
+	// load balancers are waited for.
+	Deadline time.Time
+	// LoadBalancerNames are the names of the deleted load balancers.
+	LoadBalancerNames []string
 }
 
 // New creates a new configured loadbalancer resource.
@@ -32,6 +57,9 @@ func New(config Config) (*Resource, error) {
 	newResource := &Resource{
 		// Dependencies.
 		logger: config.Logger,
+
+		deletions: map[string]deletion{},
+		mutex:     sync.Mutex{},
 	}
 
 	return newResource, nil
@@ -40,3 +68,39 @@ func New(config Config) (*Resource, error) {
 func (r *Resource) Name() string {
 	return Name
 }
+
+// addDeletion remembers the given load balancers of the given tenant cluster
+// as deleted, so that their network interfaces are waited for in the following
+// reconciliations. The deadline is set when the first load balancers of the
+// tenant cluster are deleted.
+func (r *Resource) addDeletion(clusterID string, names []string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	d, ok := r.deletions[clusterID]
+	if !ok {
+		d.Deadline = time.Now().Add(eniDeletionTimeout)
+	}
+	for _, n := range names {
+		if !containsString(d.LoadBalancerNames, n) {
+			d.LoadBalancerNames = append(d.LoadBalancerNames, n)
+		}
+	}
+
+	r.deletions[clusterID] = d
+}
+
+func (r *Resource) getDeletion(clusterID string) (deletion, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	d, ok := r.deletions[clusterID]
+	return d, ok
+}
+
+func (r *Resource) removeDeletion(clusterID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.deletions, clusterID)
+}
@@ -0,0 +1,46 @@
+package loadbalancer
+
+import (
+	"testing"
+
+	"github.com/giantswarm/micrologger/microloggertest"
+)
+
+func Test_Resource_addDeletion(t *testing.T) {
+	r, err := New(Config{Logger: microloggertest.New()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.addDeletion("al9qy", []string{"lb-1"})
+
+	first, ok := r.getDeletion("al9qy")
+	if !ok {
+		t.Fatalf("expected deletion of %#q", "al9qy")
+	}
+
+	// Load balancers deleted in following reconciliations are added without
+	// extending the deadline.
+	r.addDeletion("al9qy", []string{"lb-1", "lb-2"})
+
+	second, ok := r.getDeletion("al9qy")
+	if !ok {
+		t.Fatalf("expected deletion of %#q", "al9qy")
+	}
+	if !second.Deadline.Equal(first.Deadline) {
+		t.Fatalf("expected deadline %s got %s", first.Deadline, second.Deadline)
+	}
+	if len(second.LoadBalancerNames) != 2 {
+		t.Fatalf("expected %d load balancers got %#v", 2, second.LoadBalancerNames)
+	}
+
+	if _, ok := r.getDeletion("w7utg"); ok {
+		t.Fatalf("expected no deletion of %#q", "w7utg")
+	}
+
+	r.removeDeletion("al9qy")
+
+	if _, ok := r.getDeletion("al9qy"); ok {
+		t.Fatalf("expected no deletion of %#q", "al9qy")
+	}
+}
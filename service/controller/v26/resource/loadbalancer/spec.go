@@ -1,11 +1,23 @@
 package loadbalancer
 
 import (
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
 )
 
 type Clients struct {
-	ELB ELBClient
+	EC2   EC2Client
+	ELB   ELBClient
+	ELBv2 ELBv2Client
+}
+
+// EC2Client describes the methods required to be implemented by an EC2 AWS
+// client. It is used to find the network interfaces of load balancers which
+// are still being deleted.
+type EC2Client interface {
+	DescribeNetworkInterfaces(*ec2.DescribeNetworkInterfacesInput) (*ec2.DescribeNetworkInterfacesOutput, error)
+	DescribeVpcs(*ec2.DescribeVpcsInput) (*ec2.DescribeVpcsOutput, error)
 }
 
 // ELBClient describes the methods required to be implemented by an ELB AWS
@@ -16,6 +28,23 @@ type ELBClient interface {
 	DescribeTags(*elb.DescribeTagsInput) (*elb.DescribeTagsOutput, error)
 }
 
+// ELBv2Client describes the methods required to be implemented by an ELBv2 AWS
+// client. The ELBv2 API provides support for NLBs and ALBs.
+type ELBv2Client interface {
+	DeleteLoadBalancer(*elbv2.DeleteLoadBalancerInput) (*elbv2.DeleteLoadBalancerOutput, error)
+	DeleteTargetGroup(*elbv2.DeleteTargetGroupInput) (*elbv2.DeleteTargetGroupOutput, error)
+	DescribeLoadBalancers(*elbv2.DescribeLoadBalancersInput) (*elbv2.DescribeLoadBalancersOutput, error)
+	DescribeTags(*elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error)
+	DescribeTargetGroups(*elbv2.DescribeTargetGroupsInput) (*elbv2.DescribeTargetGroupsOutput, error)
+}
+
 type LoadBalancerState struct {
+	// LoadBalancerARNs are the ARNs of the NLBs and ALBs managed by the ELBv2
+	// API.
+	LoadBalancerARNs []string
+	// LoadBalancerNames are the names of the classic ELBs.
 	LoadBalancerNames []string
+	// TargetGroupARNs are the ARNs of the target groups of the NLBs and ALBs
+	// managed by the ELBv2 API.
+	TargetGroupARNs []string
 }
@@ -6,7 +6,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
@@ -99,6 +101,13 @@ func (r *Resource) ApplyCreateChange(ctx context.Context, obj, createChange inte
 			}
 		}
 
+		if bucketInput.EtcdBackupExpiration > 0 {
+			err = r.putEtcdBackupLifecycleConfiguration(ctx, customObject, bucketInput)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+		}
+
 		if bucketInput.IsLoggingEnabled {
 			i := &s3.PutBucketLoggingInput{
 				Bucket: aws.String(bucketInput.Name),
@@ -137,6 +146,8 @@ func (r *Resource) ApplyCreateChange(ctx context.Context, obj, createChange inte
 		}
 
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("created S3 bucket %#q", bucketInput.Name))
+
+		r.eventRecorder.Emit(ctx, &customObject, corev1.EventTypeNormal, key.EventReasonS3BucketCreated, fmt.Sprintf("created S3 bucket %#q", bucketInput.Name))
 	}
 
 	return nil
@@ -161,3 +172,38 @@ func (r *Resource) newCreateChange(ctx context.Context, obj, currentState, desir
 
 	return createState, nil
 }
+
+// putEtcdBackupLifecycleConfiguration configures the bucket to expire etcd
+// snapshots of the tenant cluster after the number of days defined in the
+// given bucket state.
+func (r *Resource) putEtcdBackupLifecycleConfiguration(ctx context.Context, customObject v1alpha1.AWSConfig, bucket BucketState) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	i := &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucket.Name),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: []*s3.LifecycleRule{
+				{
+					Expiration: &s3.LifecycleExpiration{
+						Days: aws.Int64(int64(bucket.EtcdBackupExpiration)),
+					},
+					Filter: &s3.LifecycleRuleFilter{
+						Prefix: aws.String(key.EtcdBackupPrefix(customObject)),
+					},
+					ID:     aws.String(LifecycleEtcdBackupID),
+					Status: aws.String("Enabled"),
+				},
+			},
+		},
+	}
+
+	_, err = cc.Client.TenantCluster.AWS.S3.PutBucketLifecycleConfiguration(i)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/micrologger/microloggertest"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
 )
 
 func Test_Resource_S3Bucket_newCreate(t *testing.T) {
@@ -83,6 +85,7 @@ func Test_Resource_S3Bucket_newCreate(t *testing.T) {
 	var newResource *Resource
 	{
 		c := Config{
+			EventRecorder:    recordertest.New(),
 			Logger:           microloggertest.New(),
 			InstallationName: "test-install",
 		}
@@ -68,9 +68,15 @@ func (r *Resource) GetCurrentState(ctx context.Context, obj interface{}) (interf
 					return microerror.Mask(err)
 				}
 
+				lfc, err := r.getLifecycleConfiguration(ctx, bucketName)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+
 				m.Lock()
 				inputBucket.IsLoggingBucket = isLoggingBucket(bucketName, lc)
 				inputBucket.IsLoggingEnabled = isLoggingEnabled(lc)
+				inputBucket.EtcdBackupExpiration = etcdBackupExpiration(lfc)
 				currentBucketState = append(currentBucketState, inputBucket)
 				m.Unlock()
 
@@ -127,6 +133,46 @@ func (r *Resource) getLoggingConfiguration(ctx context.Context, name string) (*s
 	return bucketLoggingOutput, nil
 }
 
+// getLifecycleConfiguration returns the lifecycle rules of the bucket with the
+// given name. Nil is returned for buckets without lifecycle rules.
+func (r *Resource) getLifecycleConfiguration(ctx context.Context, name string) (*s3.GetBucketLifecycleConfigurationOutput, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	i := &s3.GetBucketLifecycleConfigurationInput{
+		Bucket: aws.String(name),
+	}
+	o, err := cc.Client.TenantCluster.AWS.S3.GetBucketLifecycleConfiguration(i)
+	if IsNoSuchLifecycleConfiguration(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	return o, nil
+}
+
+// etcdBackupExpiration returns the number of days etcd snapshots are kept
+// according to the given lifecycle rules. Zero is returned when there is no
+// such rule.
+func etcdBackupExpiration(lfc *s3.GetBucketLifecycleConfigurationOutput) int {
+	if lfc == nil {
+		return 0
+	}
+
+	for _, r := range lfc.Rules {
+		if aws.StringValue(r.ID) != LifecycleEtcdBackupID || r.Expiration == nil {
+			continue
+		}
+
+		return int(aws.Int64Value(r.Expiration.Days))
+	}
+
+	return 0
+}
+
 func isLoggingEnabled(lc *s3.GetBucketLoggingOutput) bool {
 	if lc.LoggingEnabled != nil {
 		return true
@@ -29,9 +29,10 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) (interf
 			IsLoggingEnabled: true,
 		},
 		{
-			Name:             key.BucketName(customObject, cc.Status.TenantCluster.AWSAccountID),
-			IsLoggingBucket:  false,
-			IsLoggingEnabled: true,
+			Name:                 key.BucketName(customObject, cc.Status.TenantCluster.AWSAccountID),
+			IsLoggingBucket:      false,
+			IsLoggingEnabled:     true,
+			EtcdBackupExpiration: r.etcdBackupExpiration,
 		},
 	}
 
@@ -7,6 +7,8 @@ import (
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/micrologger/microloggertest"
 
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 )
 
@@ -38,6 +40,7 @@ func Test_Resource_S3Bucket_GetDesiredState(t *testing.T) {
 	var newResource *Resource
 	{
 		c := Config{
+			EventRecorder:    recordertest.New(),
 			Logger:           microloggertest.New(),
 			InstallationName: "test-install",
 		}
@@ -104,6 +104,22 @@ func IsBucketNotEmpty(err error) bool {
 	return false
 }
 
+// IsNoSuchLifecycleConfiguration asserts the NoSuchLifecycleConfiguration
+// error code from the AWS SDK, which is returned for buckets without any
+// lifecycle rules.
+func IsNoSuchLifecycleConfiguration(err error) bool {
+	aerr, ok := microerror.Cause(err).(awserr.Error)
+	if !ok {
+		return false
+	}
+
+	if aerr.Code() == "NoSuchLifecycleConfiguration" {
+		return true
+	}
+
+	return false
+}
+
 var wrongTypeError = &microerror.Error{
 	Kind: "wrongTypeError",
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/giantswarm/micrologger"
 
 	"github.com/giantswarm/aws-operator/pkg/awstags"
+	"github.com/giantswarm/aws-operator/pkg/recorder"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
@@ -15,16 +16,23 @@ const (
 	Name = "s3bucketv26"
 	// LifecycleLoggingBucketID is the Lifecycle ID for the logging bucket
 	LifecycleLoggingBucketID = "ExpirationLogs"
+	// LifecycleEtcdBackupID is the Lifecycle ID for the etcd snapshots in the
+	// tenant cluster's bucket.
+	LifecycleEtcdBackupID = "ExpirationEtcdBackups"
 )
 
 // Config represents the configuration used to create a new s3bucket resource.
 type Config struct {
 	// Dependencies.
-	Logger micrologger.Logger
+	EventRecorder recorder.Interface
+	Logger        micrologger.Logger
 
 	// Settings.
 	AccessLogsExpiration int
 	DeleteLoggingBucket  bool
+	// EtcdBackupExpiration is the number of days etcd snapshots are kept in the
+	// tenant cluster's bucket. Zero disables the retention policy.
+	EtcdBackupExpiration int
 	IncludeTags          bool
 	InstallationName     string
 }
@@ -32,11 +40,13 @@ type Config struct {
 // Resource implements the s3bucket resource.
 type Resource struct {
 	// Dependencies.
-	logger micrologger.Logger
+	eventRecorder recorder.Interface
+	logger        micrologger.Logger
 
 	// Settings.
 	accessLogsExpiration int
 	deleteLoggingBucket  bool
+	etcdBackupExpiration int
 	includeTags          bool
 	installationName     string
 }
@@ -44,6 +54,9 @@ type Resource struct {
 // New creates a new configured s3bucket resource.
 func New(config Config) (*Resource, error) {
 	// Dependencies.
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
@@ -52,17 +65,22 @@ func New(config Config) (*Resource, error) {
 	if config.AccessLogsExpiration < 0 {
 		return nil, microerror.Maskf(invalidConfigError, "%T.AccessLogsExpiration must not be lower than 0", config)
 	}
+	if config.EtcdBackupExpiration < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EtcdBackupExpiration must not be lower than 0", config)
+	}
 	if config.InstallationName == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.InstallationName must not be empty", config)
 	}
 
 	newResource := &Resource{
 		// Dependencies.
-		logger: config.Logger,
+		eventRecorder: config.EventRecorder,
+		logger:        config.Logger,
 
 		// Settings.
 		accessLogsExpiration: config.AccessLogsExpiration,
 		deleteLoggingBucket:  config.DeleteLoggingBucket,
+		etcdBackupExpiration: config.EtcdBackupExpiration,
 		includeTags:          config.IncludeTags,
 		installationName:     config.InstallationName,
 	}
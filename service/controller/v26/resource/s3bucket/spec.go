@@ -9,6 +9,9 @@ type BucketState struct {
 	Name             string
 	IsLoggingBucket  bool
 	IsLoggingEnabled bool
+	// EtcdBackupExpiration is the number of days etcd snapshots are kept in the
+	// bucket. It is zero for buckets without etcd snapshots.
+	EtcdBackupExpiration int
 }
 
 type Clients struct {
@@ -21,6 +24,7 @@ type S3Client interface {
 	DeleteBucket(*s3.DeleteBucketInput) (*s3.DeleteBucketOutput, error)
 	DeleteObject(*s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error)
 	DeleteObjects(*s3.DeleteObjectsInput) (*s3.DeleteObjectsOutput, error)
+	GetBucketLifecycleConfiguration(*s3.GetBucketLifecycleConfigurationInput) (*s3.GetBucketLifecycleConfigurationOutput, error)
 	GetBucketLogging(*s3.GetBucketLoggingInput) (*s3.GetBucketLoggingOutput, error)
 	HeadBucket(*s3.HeadBucketInput) (*s3.HeadBucketOutput, error)
 	ListObjectsV2(*s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error)
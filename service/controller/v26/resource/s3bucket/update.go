@@ -2,12 +2,37 @@ package s3bucket
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/operatorkit/controller"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
+// ApplyUpdateChange enforces the retention policy of etcd snapshots on existing
+// buckets. Other properties of S3 buckets are not updated.
 func (r *Resource) ApplyUpdateChange(ctx context.Context, obj, updateChange interface{}) error {
+	customObject, err := key.ToCustomObject(obj)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	updateBucketsState, err := toBucketState(updateChange)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, bucketInput := range updateBucketsState {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("updating etcd backup retention policy of S3 bucket %#q", bucketInput.Name))
+
+		err = r.putEtcdBackupLifecycleConfiguration(ctx, customObject, bucketInput)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("updated etcd backup retention policy of S3 bucket %#q", bucketInput.Name))
+	}
+
 	return nil
 }
 
@@ -29,7 +54,31 @@ func (r *Resource) NewUpdatePatch(ctx context.Context, obj, currentState, desire
 	return patch, nil
 }
 
-// newUpdateChange is a no-op because S3 buckets are not updated.
+// newUpdateChange returns the existing buckets whose etcd backup retention
+// policy differs from the desired one. Disabling the retention policy does not
+// remove it from existing buckets.
 func (r *Resource) newUpdateChange(ctx context.Context, obj, currentState, desiredState interface{}) (interface{}, error) {
-	return nil, nil
+	currentBuckets, err := toBucketState(currentState)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+	desiredBuckets, err := toBucketState(desiredState)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var updateState []BucketState
+	for _, desired := range desiredBuckets {
+		if desired.EtcdBackupExpiration == 0 {
+			continue
+		}
+
+		for _, current := range currentBuckets {
+			if current.Name == desired.Name && current.EtcdBackupExpiration != desired.EtcdBackupExpiration {
+				updateState = append(updateState, desired)
+			}
+		}
+	}
+
+	return updateState, nil
 }
@@ -0,0 +1,131 @@
+package s3bucket
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/micrologger/microloggertest"
+
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+)
+
+func Test_Resource_S3Bucket_newUpdate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		currentState         interface{}
+		desiredState         interface{}
+		expectedBucketsState []BucketState
+		description          string
+	}{
+		{
+			description:          "current and desired state empty, expected empty",
+			currentState:         []BucketState{},
+			desiredState:         []BucketState{},
+			expectedBucketsState: nil,
+		},
+		{
+			description:  "current state empty, desired state not empty, expected empty",
+			currentState: []BucketState{},
+			desiredState: []BucketState{
+				{
+					Name:                 "desired",
+					EtcdBackupExpiration: 30,
+				},
+			},
+			expectedBucketsState: nil,
+		},
+		{
+			description: "current and desired etcd backup expiration equal, expected empty",
+			currentState: []BucketState{
+				{
+					Name:                 "bucket",
+					EtcdBackupExpiration: 30,
+				},
+			},
+			desiredState: []BucketState{
+				{
+					Name:                 "bucket",
+					EtcdBackupExpiration: 30,
+				},
+			},
+			expectedBucketsState: nil,
+		},
+		{
+			description: "current etcd backup expiration missing, expected desired state",
+			currentState: []BucketState{
+				{
+					Name: "access-logs",
+				},
+				{
+					Name: "bucket",
+				},
+			},
+			desiredState: []BucketState{
+				{
+					Name: "access-logs",
+				},
+				{
+					Name:                 "bucket",
+					EtcdBackupExpiration: 30,
+				},
+			},
+			expectedBucketsState: []BucketState{
+				{
+					Name:                 "bucket",
+					EtcdBackupExpiration: 30,
+				},
+			},
+		},
+		{
+			description: "desired etcd backup expiration disabled, expected empty",
+			currentState: []BucketState{
+				{
+					Name:                 "bucket",
+					EtcdBackupExpiration: 30,
+				},
+			},
+			desiredState: []BucketState{
+				{
+					Name: "bucket",
+				},
+			},
+			expectedBucketsState: nil,
+		},
+	}
+
+	var err error
+
+	var newResource *Resource
+	{
+		c := Config{
+			EventRecorder:    recordertest.New(),
+			Logger:           microloggertest.New(),
+			InstallationName: "test-install",
+		}
+
+		newResource, err = New(c)
+		if err != nil {
+			t.Fatal("expected", nil, "got", err)
+		}
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			obj := &v1alpha1.AWSConfig{}
+
+			result, err := newResource.newUpdateChange(context.TODO(), obj, tc.currentState, tc.desiredState)
+			if err != nil {
+				t.Fatalf("expected '%v' got '%#v'", nil, err)
+			}
+			updateChanges, ok := result.([]BucketState)
+			if !ok {
+				t.Fatalf("expected '%T', got '%T'", updateChanges, result)
+			}
+			if !reflect.DeepEqual(updateChanges, tc.expectedBucketsState) {
+				t.Fatalf("expected %#v, got %#v", tc.expectedBucketsState, updateChanges)
+			}
+		})
+	}
+}
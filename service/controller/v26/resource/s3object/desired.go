@@ -77,23 +77,27 @@ func (r *Resource) GetDesiredState(ctx context.Context, obj interface{}) (interf
 			return nil
 		})
 
-		g.Go(func() error {
-			b, err := r.cloudConfig.NewWorkerTemplate(ctx, customObject, clusterCerts)
-			if err != nil {
-				return microerror.Mask(err)
-			}
-
-			m.Lock()
-			k := key.BucketObjectName(customObject, key.KindWorker)
-			output[k] = BucketObjectState{
-				Bucket: key.BucketName(customObject, cc.Status.TenantCluster.AWSAccountID),
-				Body:   b,
-				Key:    k,
-			}
-			m.Unlock()
-
-			return nil
-		})
+		for _, p := range key.WorkerNodePools(customObject) {
+			p := p
+
+			g.Go(func() error {
+				b, err := r.cloudConfig.NewWorkerTemplate(ctx, customObject, clusterCerts, p)
+				if err != nil {
+					return microerror.Mask(err)
+				}
+
+				m.Lock()
+				k := key.BucketObjectName(customObject, key.NodePoolRole(p.Name))
+				output[k] = BucketObjectState{
+					Bucket: key.BucketName(customObject, cc.Status.TenantCluster.AWSAccountID),
+					Body:   b,
+					Key:    k,
+				}
+				m.Unlock()
+
+				return nil
+			})
+		}
 
 		err = g.Wait()
 		if err != nil {
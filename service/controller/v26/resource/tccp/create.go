@@ -2,17 +2,20 @@ package tccp
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/giantswarm/aws-operator/pkg/awstags"
-	"github.com/giantswarm/aws-operator/service/controller/v22/ebs"
 	"github.com/giantswarm/aws-operator/service/controller/v26/adapter"
+	cf "github.com/giantswarm/aws-operator/service/controller/v26/cloudformation"
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/ebs"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 	"github.com/giantswarm/aws-operator/service/controller/v26/templates"
@@ -28,6 +31,20 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
+	// Stacks in UPDATE_ROLLBACK_FAILED are reported as transitioning because
+	// their outputs are not accessible. They never leave this state on their own
+	// though, so we try to recover them before waiting for the transition.
+	if cc.Status.TenantCluster.TCCP.StackStatus == cloudformation.StackStatusUpdateRollbackFailed {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "the tenant cluster's control plane cloud formation stack failed to roll back")
+
+		err = r.recoverStack(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		return nil
+	}
+
 	// When the TCCP cloud formation stack is transitioning, it means it is
 	// updating in most cases. We do not want to interfere with the current
 	// process and stop here. We will then check on the next reconciliation loop
@@ -57,6 +74,13 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster's control plane network cidr")
 	}
 
+	{
+		err = r.ensureRecoveryStatusCleared(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	{
 		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the tenant cluster's control plane cloud formation stack")
 
@@ -70,9 +94,12 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 			err = r.createStack(ctx, cr)
 			if err != nil {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackCreationFailed, err.Error())
 				return microerror.Mask(err)
 			}
 
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackCreationRequested, fmt.Sprintf("requested the creation of stack %#q", key.MainGuestStackName(cr)))
+
 			return nil
 
 		} else if err != nil {
@@ -80,8 +107,17 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 		} else if len(o.Stacks) != 1 {
 			return microerror.Maskf(executionFailedError, "expected one stack, got %d", len(o.Stacks))
+		}
+
+		if cf.IsFailedStackStatus(*o.Stacks[0].StackStatus) {
+			m := fmt.Sprintf("stack %#q has status %#q", key.MainGuestStackName(cr), *o.Stacks[0].StackStatus)
+			if o.Stacks[0].StackStatusReason != nil {
+				m += fmt.Sprintf(": %s", *o.Stacks[0].StackStatusReason)
+			}
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackFailed, m)
+		}
 
-		} else if *o.Stacks[0].StackStatus == cloudformation.StackStatusCreateFailed {
+		if *o.Stacks[0].StackStatus == cloudformation.StackStatusCreateFailed {
 			return microerror.Maskf(executionFailedError, "expected successful status, got %#q", o.Stacks[0].StackStatus)
 		}
 
@@ -94,16 +130,37 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 			return microerror.Mask(err)
 		}
 
-		if update {
+		if update && key.IsPlanMode(cr) {
+			err = r.planStack(ctx, cr)
+			if err != nil {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackUpdateFailed, err.Error())
+				return microerror.Mask(err)
+			}
+
+			return nil
+
+		} else if update {
 			err = r.updateStack(ctx, cr)
 			if err != nil {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackUpdateFailed, err.Error())
 				return microerror.Mask(err)
 			}
 
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackUpdateRequested, fmt.Sprintf("requested the update of stack %#q to version bundle version %s", key.MainGuestStackName(cr), key.VersionBundleVersion(cr)))
+
 			return nil
 		}
 	}
 
+	// Once a planned change set got executed or the update became obsolete the
+	// plan in the CR status is outdated and removed again.
+	if cr.Status.AWS.Plan.ChangeSetName != "" {
+		_, err := r.ensurePlanStatus(ctx, cr, v1alpha1.AWSConfigStatusAWSPlan{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+	}
+
 	{
 		scale, err := r.detection.ShouldScale(ctx, cr)
 		if err != nil {
@@ -113,9 +170,12 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		if scale {
 			err = r.scaleStack(ctx, cr)
 			if err != nil {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackScalingFailed, err.Error())
 				return microerror.Mask(err)
 			}
 
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackScalingRequested, fmt.Sprintf("requested the scaling of stack %#q", key.MainGuestStackName(cr)))
+
 			return nil
 		}
 	}
@@ -275,9 +335,13 @@ func (r *Resource) newTemplateBody(ctx context.Context, cr v1alpha1.AWSConfig, t
 	if err != nil {
 		return "", microerror.Mask(err)
 	}
-	im, err := key.ImageID(cr)
-	if err != nil {
-		return "", microerror.Mask(err)
+
+	im := tp.ImageID
+	if im == "" {
+		im, err = r.imageResolver.ImageID(ctx, cr)
+		if err != nil {
+			return "", microerror.Mask(err)
+		}
 	}
 
 	var templateBody string
@@ -287,9 +351,11 @@ func (r *Resource) newTemplateBody(ctx context.Context, cr v1alpha1.AWSConfig, t
 			ControlPlaneAccountID:           cc.Status.ControlPlane.AWSAccountID,
 			ControlPlaneNATGatewayAddresses: cc.Status.ControlPlane.NATGateway.Addresses,
 			ControlPlanePeerRoleARN:         cc.Status.ControlPlane.PeerRole.ARN,
+			ControlPlaneTransitGatewayID:    r.transitGatewayID,
 			ControlPlaneVPCCidr:             cc.Status.ControlPlane.VPC.CIDR,
 			CustomObject:                    cr,
 			EncrypterBackend:                r.encrypterBackend,
+			ExistingVPCPrivateRouteTableIDs: cc.Status.TenantCluster.TCCP.VPC.PrivateRouteTableIDs,
 			InstallationName:                r.installationName,
 			PublicRouteTables:               r.publicRouteTables,
 			Route53Enabled:                  r.route53Enabled,
@@ -297,28 +363,28 @@ func (r *Resource) newTemplateBody(ctx context.Context, cr v1alpha1.AWSConfig, t
 				Name: key.MainGuestStackName(cr),
 
 				DockerVolumeResourceName:   tp.DockerVolumeResourceName,
+				MasterAvailabilityZones:    newMasterAvailabilityZones(cr, cc.Status.TenantCluster),
+				MasterDockerVolume:         key.MasterDockerVolume(cr),
+				MasterEtcdRestoreSnapshot:  key.EtcdRestoreSnapshot(cr),
+				MasterEtcdVolume:           key.MasterEtcdVolume(cr),
 				MasterImageID:              im,
 				MasterInstanceResourceName: tp.MasterInstanceResourceName,
 				MasterInstanceType:         key.MasterInstanceType(cr),
 				MasterCloudConfigVersion:   key.CloudConfigVersion,
 				MasterInstanceMonitoring:   r.instanceMonitoring,
+				MasterLogVolume:            key.MasterLogVolume(cr),
 
 				WorkerCloudConfigVersion: key.CloudConfigVersion,
-				WorkerDesired:            cc.Status.TenantCluster.TCCP.ASG.DesiredCapacity,
-				WorkerDockerVolumeSizeGB: key.WorkerDockerVolumeSizeGB(cr),
-				// TODO: https://github.com/giantswarm/giantswarm/issues/4105#issuecomment-421772917
-				// TODO: for now we use same value as for DockerVolumeSizeFromNode, when we have kubelet size in spec we should use that.
-				WorkerKubeletVolumeSizeGB: key.WorkerDockerVolumeSizeGB(cr),
-				WorkerImageID:             im,
-				WorkerInstanceMonitoring:  r.instanceMonitoring,
-				WorkerInstanceType:        key.WorkerInstanceType(cr),
-				WorkerMax:                 cc.Status.TenantCluster.TCCP.ASG.MaxSize,
-				WorkerMin:                 cc.Status.TenantCluster.TCCP.ASG.MinSize,
+				WorkerImageID:            im,
+				WorkerInstanceMonitoring: r.instanceMonitoring,
+				WorkerNodePools:          newStackStateNodePools(cr, cc.Status.TenantCluster.TCCP),
+				WorkerVolumeType:         key.WorkerVolumeType(cr),
 
 				VersionBundleVersion: key.VersionBundleVersion(cr),
 			},
-			TenantClusterAccountID: cc.Status.TenantCluster.AWSAccountID,
-			TenantClusterKMSKeyARN: cc.Status.TenantCluster.Encryption.Key,
+			TenantClusterAccountID:       cc.Status.TenantCluster.AWSAccountID,
+			TenantClusterKMSKeyARN:       cc.Status.TenantCluster.Encryption.Key,
+			TenantClusterVolumeKMSKeyARN: cc.Status.TenantCluster.Encryption.VolumeKey,
 		}
 
 		a, err := adapter.NewGuest(c)
@@ -341,9 +407,13 @@ func (r *Resource) scaleStack(ctx context.Context, cr v1alpha1.AWSConfig) error
 		return microerror.Mask(err)
 	}
 
+	// Scaling must not replace any instances, so the EC2 AMI of the deployed
+	// master and worker nodes is kept. Image changes are rolled out with stack
+	// updates.
 	tp := templateParams{
 		MasterInstanceResourceName: cc.Status.TenantCluster.MasterInstance.ResourceName,
 		DockerVolumeResourceName:   cc.Status.TenantCluster.MasterInstance.DockerVolumeResourceName,
+		ImageID:                    cc.Status.TenantCluster.MasterInstance.Image,
 	}
 
 	templateBody, err := r.newTemplateBody(ctx, cr, tp)
@@ -375,7 +445,17 @@ func (r *Resource) updateStack(ctx context.Context, cr v1alpha1.AWSConfig) error
 		return microerror.Mask(err)
 	}
 
-	err = r.terminateMasterInstance(ctx, cr)
+	completed, err := r.snapshotVolumes(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+	if !completed {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "snapshots of master volumes are not completed yet")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		return nil
+	}
+
+	err = r.terminateMasterInstances(ctx, cr)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -387,3 +467,85 @@ func (r *Resource) updateStack(ctx context.Context, cr v1alpha1.AWSConfig) error
 
 	return nil
 }
+
+// newMasterAvailabilityZones returns the availability zones the masters are
+// placed in, ordered by master index. Masters are distributed across the
+// tenant cluster's availability zones in a round robin fashion when they are
+// created. Once created, masters must stay in their availability zones, also
+// when availability zones are added to the tenant cluster. The placement is
+// therefore taken from the TCCP stack outputs. Stacks created before the
+// placement was tracked in the outputs distribute the masters across the
+// availability zones which already have subnets. These are the leading
+// availability zones of the CR status, since added availability zones are
+// appended.
+func newMasterAvailabilityZones(cr v1alpha1.AWSConfig, tc controllercontext.ContextStatusTenantCluster) []string {
+	var zones []string
+	for _, az := range key.StatusAvailabilityZones(cr) {
+		zones = append(zones, az.Name)
+	}
+
+	n := len(zones)
+	if len(tc.TCCP.Subnets) > 0 {
+		var existing int
+		for _, az := range zones {
+			if !hasSubnetInAZ(tc.TCCP.Subnets, az) {
+				break
+			}
+			existing++
+		}
+		if existing > 0 {
+			n = existing
+		}
+	}
+
+	var azs []string
+	for idx := 0; idx < key.MasterReplicas(cr); idx++ {
+		if idx < len(tc.MasterInstance.AvailabilityZones) {
+			azs = append(azs, tc.MasterInstance.AvailabilityZones[idx])
+			continue
+		}
+		if n == 0 {
+			break
+		}
+
+		azs = append(azs, zones[idx%n])
+	}
+
+	return azs
+}
+
+func hasSubnetInAZ(subnets []*ec2.Subnet, az string) bool {
+	for _, s := range subnets {
+		if aws.StringValue(s.AvailabilityZone) == az {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newStackStateNodePools(cr v1alpha1.AWSConfig, tccp controllercontext.ContextStatusTenantClusterTCCP) []adapter.StackStateNodePool {
+	var nodePools []adapter.StackStateNodePool
+
+	for _, p := range key.WorkerNodePools(cr) {
+		asg, _ := tccp.NodePoolASG(p.Name)
+
+		n := adapter.StackStateNodePool{
+			Name: p.Name,
+
+			Desired:              asg.DesiredCapacity,
+			DockerVolumeSizeGB:   key.NodePoolDockerVolumeSizeGB(p),
+			InstanceType:         p.InstanceType,
+			InstanceTypes:        key.NodePoolInstanceTypes(p),
+			KubeletVolumeSizeGB:  key.NodePoolKubeletVolumeSizeGB(p),
+			Max:                  p.Scaling.Max,
+			Min:                  p.Scaling.Min,
+			OnDemandBaseCapacity: p.InstanceDistribution.OnDemandBaseCapacity,
+			SpotPercentage:       p.InstanceDistribution.SpotPercentage,
+		}
+
+		nodePools = append(nodePools, n)
+	}
+
+	return nodePools
+}
@@ -23,7 +23,7 @@ func (r *Resource) EnsureDeleted(ctx context.Context, obj interface{}) error {
 		return microerror.Mask(err)
 	}
 
-	err = r.terminateMasterInstance(ctx, cr)
+	err = r.terminateMasterInstances(ctx, cr)
 	if err != nil {
 		return microerror.Mask(err)
 	}
@@ -35,6 +35,33 @@ func IsAlreadyTerminated(err error) bool {
 	return false
 }
 
+var changeSetNotFoundError = &microerror.Error{
+	Kind: "changeSetNotFoundError",
+}
+
+// IsChangeSetNotFound asserts changeSetNotFoundError. Here we also check for
+// the AWS error code ChangeSetNotFound. The AWS errors might look like the
+// following example.
+//
+//     ChangeSetNotFound: ChangeSet [plan-5f2e1c0d8a9b3e47] does not exist
+//
+func IsChangeSetNotFound(err error) bool {
+	c := microerror.Cause(err)
+
+	aerr, ok := c.(awserr.Error)
+	if ok {
+		if aerr.Code() == cloudformation.ErrCodeChangeSetNotFoundException {
+			return true
+		}
+	}
+
+	if c == changeSetNotFoundError {
+		return true
+	}
+
+	return false
+}
+
 var deleteInProgressError = &microerror.Error{
 	Kind: "deleteInProgressError",
 }
@@ -0,0 +1,127 @@
+package tccp
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// cloudFormationMock records the Cloud Formation API calls of the resource and
+// answers them with the configured change set and stack resources. A nil
+// change set causes DescribeChangeSet to fail with ChangeSetNotFound. A non
+// nil rollback error causes ContinueUpdateRollback to fail.
+type cloudFormationMock struct {
+	changeSet     *cloudformation.DescribeChangeSetOutput
+	resources     []*cloudformation.StackResourceSummary
+	rollbackError error
+
+	calls            []string
+	skippedResources []string
+}
+
+// newCloudFormationClient returns a Cloud Formation client which does not send
+// any requests but lets the given mock answer them.
+func newCloudFormationClient(m *cloudFormationMock) *cloudformation.CloudFormation {
+	c := &aws.Config{
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		Region:      aws.String("eu-central-1"),
+	}
+	client := cloudformation.New(session.Must(session.NewSession(c)))
+
+	client.Handlers.Send.Clear()
+	client.Handlers.Retry.Clear()
+	client.Handlers.AfterRetry.Clear()
+	client.Handlers.Unmarshal.Clear()
+	client.Handlers.UnmarshalMeta.Clear()
+	client.Handlers.UnmarshalError.Clear()
+	client.Handlers.ValidateResponse.Clear()
+	client.Handlers.Send.PushBack(m.send)
+
+	return client
+}
+
+func (m *cloudFormationMock) send(r *request.Request) {
+	m.calls = append(m.calls, r.Operation.Name)
+
+	switch r.Operation.Name {
+	case "ContinueUpdateRollback":
+		if m.rollbackError != nil {
+			r.Error = m.rollbackError
+			return
+		}
+		for _, s := range r.Params.(*cloudformation.ContinueUpdateRollbackInput).ResourcesToSkip {
+			m.skippedResources = append(m.skippedResources, aws.StringValue(s))
+		}
+	case "ListStackResources":
+		r.Data.(*cloudformation.ListStackResourcesOutput).StackResourceSummaries = m.resources
+	case "DescribeChangeSet":
+		if m.changeSet == nil {
+			r.Error = awserr.New(cloudformation.ErrCodeChangeSetNotFoundException, "ChangeSet does not exist", nil)
+			return
+		}
+		*r.Data.(*cloudformation.DescribeChangeSetOutput) = *m.changeSet
+	}
+}
+
+func (m *cloudFormationMock) called(operation string) bool {
+	for _, c := range m.calls {
+		if c == operation {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ec2ClientMock answers the EC2 API calls of the resource with the configured
+// instances, volumes and snapshots. Without any configuration it finds neither
+// volumes nor instances, so that detaching the master volumes and terminating
+// the master instances are no-ops.
+type ec2ClientMock struct {
+	ec2iface.EC2API
+
+	instances []*ec2.Instance
+	snapshots []*ec2.Snapshot
+	volumes   []*ec2.Volume
+
+	createdSnapshots []string
+}
+
+func (e *ec2ClientMock) CreateSnapshot(i *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
+	id := fmt.Sprintf("snap-%d", len(e.createdSnapshots))
+	e.createdSnapshots = append(e.createdSnapshots, id)
+
+	return &ec2.Snapshot{SnapshotId: aws.String(id), VolumeId: i.VolumeId}, nil
+}
+
+func (e *ec2ClientMock) DeleteSnapshot(*ec2.DeleteSnapshotInput) (*ec2.DeleteSnapshotOutput, error) {
+	return &ec2.DeleteSnapshotOutput{}, nil
+}
+
+func (e *ec2ClientMock) DescribeInstances(*ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	o := &ec2.DescribeInstancesOutput{}
+	if len(e.instances) > 0 {
+		o.Reservations = []*ec2.Reservation{
+			{
+				Instances: e.instances,
+			},
+		}
+	}
+
+	return o, nil
+}
+
+func (e *ec2ClientMock) DescribeSnapshots(*ec2.DescribeSnapshotsInput) (*ec2.DescribeSnapshotsOutput, error) {
+	return &ec2.DescribeSnapshotsOutput{Snapshots: e.snapshots}, nil
+}
+
+func (e *ec2ClientMock) DescribeVolumes(*ec2.DescribeVolumesInput) (*ec2.DescribeVolumesOutput, error) {
+	return &ec2.DescribeVolumesOutput{Volumes: e.volumes}, nil
+}
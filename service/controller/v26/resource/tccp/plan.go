@@ -0,0 +1,406 @@
+package tccp
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+const (
+	// changeSetNamePrefix is the prefix of all change sets created in plan
+	// mode. It is used to identify stale change sets which can be deleted.
+	changeSetNamePrefix = "plan-"
+)
+
+const (
+	// stableDockerVolumeResourceName and stableMasterInstanceResourceName
+	// replace the time hashed resource names of the rendered template when
+	// computing change set names. See newChangeSetName.
+	stableDockerVolumeResourceName   = "DockerVolume"
+	stableMasterInstanceResourceName = "MasterInstance"
+)
+
+// planStack is the plan mode equivalent of updateStack. Instead of updating
+// the tenant cluster's control plane stack right away, a Cloud Formation change
+// set is created from the rendered template. Its changes are written to the CR
+// status so they can be reviewed. The change set is only executed once the
+// plan approval annotation of the CR is set to the change set name. The
+// master instances are only terminated right before the change set gets
+// executed, so nothing is touched as long as the plan is not approved.
+func (r *Resource) planStack(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	tp := templateParams{
+		MasterInstanceResourceName: key.MasterInstanceResourceName(cr),
+		DockerVolumeResourceName:   key.DockerVolumeResourceName(cr),
+	}
+
+	templateBody, err := r.newTemplateBody(ctx, cr, tp)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.applyPlan(ctx, cr, newChangeSetName(cr, templateBody, tp), templateBody)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	return nil
+}
+
+// applyPlan ensures the change set with the given name exists for the given
+// template body and reflects it in the CR status. The change set is executed
+// once it got approved.
+func (r *Resource) applyPlan(ctx context.Context, cr v1alpha1.AWSConfig, changeSetName string, templateBody string) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var changeSet *cloudformation.DescribeChangeSetOutput
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("finding the tenant cluster's control plane change set %#q", changeSetName))
+
+		changeSet, err = r.describeChangeSet(ctx, cr, changeSetName)
+		if IsChangeSetNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not find the tenant cluster's control plane change set %#q", changeSetName))
+
+			err = r.deleteStaleChangeSets(ctx, cr, changeSetName)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			err = r.createChangeSet(ctx, cr, changeSetName, templateBody)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+			return nil
+
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the tenant cluster's control plane change set %#q", changeSetName))
+	}
+
+	switch *changeSet.Status {
+	case cloudformation.ChangeSetStatusCreatePending, cloudformation.ChangeSetStatusCreateInProgress:
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("the tenant cluster's control plane change set %#q is being computed", changeSetName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		return nil
+	}
+
+	{
+		updated, err := r.ensurePlanStatus(ctx, cr, newPlan(changeSet))
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		if updated {
+			if *changeSet.Status == cloudformation.ChangeSetStatusCreateComplete {
+				m := fmt.Sprintf("planned change set %#q with %d changes, set annotation %#q to %#q to execute it", changeSetName, len(changeSet.Changes), key.AnnotationPlanApproved, changeSetName)
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackPlanned, m)
+			} else {
+				m := fmt.Sprintf("planning change set %#q failed with status %#q: %s", changeSetName, *changeSet.Status, aws.StringValue(changeSet.StatusReason))
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackUpdateFailed, m)
+			}
+
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling reconciliation")
+			reconciliationcanceledcontext.SetCanceled(ctx)
+			return nil
+		}
+	}
+
+	if *changeSet.Status != cloudformation.ChangeSetStatusCreateComplete {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("the tenant cluster's control plane change set %#q cannot be executed due to status %#q", changeSetName, *changeSet.Status))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		return nil
+	}
+
+	if key.PlanApprovedChangeSetName(cr) != changeSetName {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("the tenant cluster's control plane change set %#q is not approved", changeSetName))
+		r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+		return nil
+	}
+
+	err = r.detachVolumes(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	err = r.terminateMasterInstances(ctx, cr)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("executing the tenant cluster's control plane change set %#q", changeSetName))
+
+		i := &cloudformation.ExecuteChangeSetInput{
+			ChangeSetName: aws.String(changeSetName),
+			StackName:     aws.String(key.MainGuestStackName(cr)),
+		}
+
+		_, err = cc.Client.TenantCluster.AWS.CloudFormation.ExecuteChangeSet(i)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("executed the tenant cluster's control plane change set %#q", changeSetName))
+
+		r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackPlanExecuted, fmt.Sprintf("executed change set %#q", changeSetName))
+	}
+
+	return nil
+}
+
+func (r *Resource) createChangeSet(ctx context.Context, cr v1alpha1.AWSConfig, changeSetName string, templateBody string) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("requesting the creation of the tenant cluster's control plane change set %#q", changeSetName))
+
+	i := &cloudformation.CreateChangeSetInput{
+		Capabilities: []*string{
+			// CAPABILITY_NAMED_IAM is required for updating worker policy IAM
+			// roles.
+			aws.String(namedIAMCapability),
+		},
+		ChangeSetName: aws.String(changeSetName),
+		ChangeSetType: aws.String(cloudformation.ChangeSetTypeUpdate),
+		Description:   aws.String(fmt.Sprintf("Update to version bundle version %s.", key.VersionBundleVersion(cr))),
+		Parameters: []*cloudformation.Parameter{
+			{
+				ParameterKey:   aws.String(versionBundleVersionParameterKey),
+				ParameterValue: aws.String(key.VersionBundleVersion(cr)),
+			},
+		},
+		StackName:    aws.String(key.MainGuestStackName(cr)),
+		TemplateBody: aws.String(templateBody),
+	}
+
+	_, err = cc.Client.TenantCluster.AWS.CloudFormation.CreateChangeSet(i)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("requested the creation of the tenant cluster's control plane change set %#q", changeSetName))
+
+	return nil
+}
+
+// deleteStaleChangeSets deletes change sets which were planned for templates
+// that are outdated by now, e.g. because the CR changed again before the plan
+// got approved.
+func (r *Resource) deleteStaleChangeSets(ctx context.Context, cr v1alpha1.AWSConfig, changeSetName string) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var staleNames []string
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding stale change sets of the tenant cluster's control plane")
+
+		var nextToken *string
+		for {
+			i := &cloudformation.ListChangeSetsInput{
+				NextToken: nextToken,
+				StackName: aws.String(key.MainGuestStackName(cr)),
+			}
+
+			o, err := cc.Client.TenantCluster.AWS.CloudFormation.ListChangeSets(i)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			for _, s := range o.Summaries {
+				n := aws.StringValue(s.ChangeSetName)
+				if strings.HasPrefix(n, changeSetNamePrefix) && n != changeSetName {
+					staleNames = append(staleNames, n)
+				}
+			}
+
+			if o.NextToken == nil {
+				break
+			}
+			nextToken = o.NextToken
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d stale change sets of the tenant cluster's control plane", len(staleNames)))
+	}
+
+	for _, n := range staleNames {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleting stale change set %#q", n))
+
+		i := &cloudformation.DeleteChangeSetInput{
+			ChangeSetName: aws.String(n),
+			StackName:     aws.String(key.MainGuestStackName(cr)),
+		}
+
+		_, err = cc.Client.TenantCluster.AWS.CloudFormation.DeleteChangeSet(i)
+		if IsChangeSetNotFound(err) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not delete stale change set %#q", n))
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("stale change set %#q does not exist anymore", n))
+			continue
+
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("deleted stale change set %#q", n))
+	}
+
+	return nil
+}
+
+// describeChangeSet returns the given change set including all of its changes,
+// which might be spread across several pages.
+func (r *Resource) describeChangeSet(ctx context.Context, cr v1alpha1.AWSConfig, changeSetName string) (*cloudformation.DescribeChangeSetOutput, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var changeSet *cloudformation.DescribeChangeSetOutput
+	var nextToken *string
+	for {
+		i := &cloudformation.DescribeChangeSetInput{
+			ChangeSetName: aws.String(changeSetName),
+			NextToken:     nextToken,
+			StackName:     aws.String(key.MainGuestStackName(cr)),
+		}
+
+		o, err := cc.Client.TenantCluster.AWS.CloudFormation.DescribeChangeSet(i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		if changeSet == nil {
+			changeSet = o
+		} else {
+			changeSet.Changes = append(changeSet.Changes, o.Changes...)
+		}
+
+		if o.NextToken == nil {
+			break
+		}
+		nextToken = o.NextToken
+	}
+
+	return changeSet, nil
+}
+
+// ensurePlanStatus writes the given plan into the CR status in case it
+// differs from the plan currently persisted. The returned boolean is true when
+// the CR status was updated.
+func (r *Resource) ensurePlanStatus(ctx context.Context, cr v1alpha1.AWSConfig, plan v1alpha1.AWSConfigStatusAWSPlan) (bool, error) {
+	var customObject v1alpha1.AWSConfig
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding latest version of custom resource")
+
+		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+		customObject = *newObj
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found latest version of custom resource")
+	}
+
+	if reflect.DeepEqual(customObject.Status.AWS.Plan, plan) {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "CR status plan does not have to be updated")
+		return false, nil
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status plan")
+
+		customObject.Status.AWS.Plan = plan
+
+		_, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.GetNamespace()).UpdateStatus(&customObject)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status plan")
+	}
+
+	for _, c := range plan.Changes {
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("planned change %s", planChangeSummary(c)))
+	}
+
+	return true, nil
+}
+
+// newChangeSetName computes a change set name which is stable as long as the
+// rendered template and the version bundle version do not change. This way a
+// planned change set is found again on the next reconciliation loop and an
+// approval always refers to exactly one rendered template. The master instance
+// and docker volume resource names contain a hash of the current time and
+// differ with every rendering. They are replaced with stable names before
+// hashing.
+func newChangeSetName(cr v1alpha1.AWSConfig, templateBody string, tp templateParams) string {
+	replacer := strings.NewReplacer(
+		tp.DockerVolumeResourceName, stableDockerVolumeResourceName,
+		tp.MasterInstanceResourceName, stableMasterInstanceResourceName,
+	)
+
+	h := sha256.New()
+	h.Write([]byte(key.VersionBundleVersion(cr)))
+	h.Write([]byte(replacer.Replace(templateBody)))
+
+	return fmt.Sprintf("%s%x", changeSetNamePrefix, h.Sum(nil)[:8])
+}
+
+func newPlan(changeSet *cloudformation.DescribeChangeSetOutput) v1alpha1.AWSConfigStatusAWSPlan {
+	plan := v1alpha1.AWSConfigStatusAWSPlan{
+		ChangeSetName: aws.StringValue(changeSet.ChangeSetName),
+		Status:        aws.StringValue(changeSet.Status),
+		StatusReason:  aws.StringValue(changeSet.StatusReason),
+	}
+
+	for _, c := range changeSet.Changes {
+		if c.ResourceChange == nil {
+			continue
+		}
+
+		plan.Changes = append(plan.Changes, v1alpha1.AWSConfigStatusAWSPlanChange{
+			Action:       aws.StringValue(c.ResourceChange.Action),
+			LogicalID:    aws.StringValue(c.ResourceChange.LogicalResourceId),
+			Replacement:  aws.StringValue(c.ResourceChange.Replacement),
+			ResourceType: aws.StringValue(c.ResourceChange.ResourceType),
+		})
+	}
+
+	return plan
+}
+
+// planChangeSummary returns a human readable representation of the given
+// change, e.g.
+//
+//     Modify AWS::EC2::Instance MasterInstance (replacement: True)
+//
+func planChangeSummary(c v1alpha1.AWSConfigStatusAWSPlanChange) string {
+	s := fmt.Sprintf("%s %s %s", c.Action, c.ResourceType, c.LogicalID)
+	if c.Replacement != "" {
+		s += fmt.Sprintf(" (replacement: %s)", c.Replacement)
+	}
+
+	return s
+}
@@ -0,0 +1,237 @@
+package tccp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/fake"
+	"github.com/giantswarm/micrologger/microloggertest"
+	"github.com/giantswarm/operatorkit/controller/context/reconciliationcanceledcontext"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsclient "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+func Test_newChangeSetName(t *testing.T) {
+	cr := v1alpha1.AWSConfig{
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "al9qy",
+			},
+			VersionBundle: v1alpha1.AWSConfigSpecVersionBundle{
+				Version: "5.0.0",
+			},
+		},
+	}
+
+	// The template body mimics the rendered template, which contains the time
+	// hashed resource names several times.
+	render := func(tp templateParams, instanceType string) string {
+		return strings.Join([]string{
+			tp.MasterInstanceResourceName + ":",
+			"  InstanceType: " + instanceType,
+			key.MasterResourceName(tp.MasterInstanceResourceName, 1) + ":",
+			tp.DockerVolumeResourceName + ":",
+			"  VolumeId: !Ref " + tp.DockerVolumeResourceName,
+		}, "\n")
+	}
+
+	tp1 := templateParams{
+		DockerVolumeResourceName:   "DockerVolumeAL9QYA1B2C",
+		MasterInstanceResourceName: "MasterInstanceAL9QYA1B2C",
+	}
+	tp2 := templateParams{
+		DockerVolumeResourceName:   "DockerVolumeAL9QYD3E4F",
+		MasterInstanceResourceName: "MasterInstanceAL9QYD3E4F",
+	}
+
+	name := newChangeSetName(cr, render(tp1, "m5.xlarge"), tp1)
+	if !strings.HasPrefix(name, changeSetNamePrefix) {
+		t.Fatalf("expected prefix %#q got %#q", changeSetNamePrefix, name)
+	}
+
+	// Rendering the same template again results in different time hashed
+	// resource names, which must not change the change set name.
+	if n := newChangeSetName(cr, render(tp2, "m5.xlarge"), tp2); n != name {
+		t.Fatalf("expected %#q got %#q", name, n)
+	}
+
+	// Actual template changes must change the change set name.
+	if n := newChangeSetName(cr, render(tp2, "m5.2xlarge"), tp2); n == name {
+		t.Fatalf("expected change set name other than %#q", name)
+	}
+
+	// Version bundle version changes must change the change set name.
+	cr.Spec.VersionBundle.Version = "5.1.0"
+	if n := newChangeSetName(cr, render(tp1, "m5.xlarge"), tp1); n == name {
+		t.Fatalf("expected change set name other than %#q", name)
+	}
+}
+
+func Test_Resource_applyPlan(t *testing.T) {
+	changeSetName := "plan-0123456789abcdef"
+
+	completeChangeSet := &cloudformation.DescribeChangeSetOutput{
+		ChangeSetName: aws.String(changeSetName),
+		Changes: []*cloudformation.Change{
+			{
+				ResourceChange: &cloudformation.ResourceChange{
+					Action:            aws.String(cloudformation.ChangeActionModify),
+					LogicalResourceId: aws.String("MasterInstance"),
+					Replacement:       aws.String(cloudformation.ReplacementTrue),
+					ResourceType:      aws.String("AWS::EC2::Instance"),
+				},
+			},
+		},
+		Status: aws.String(cloudformation.ChangeSetStatusCreateComplete),
+	}
+	failedChangeSet := &cloudformation.DescribeChangeSetOutput{
+		ChangeSetName: aws.String(changeSetName),
+		Status:        aws.String(cloudformation.ChangeSetStatusFailed),
+		StatusReason:  aws.String("The submitted information didn't contain changes."),
+	}
+
+	testCases := []struct {
+		name             string
+		changeSet        *cloudformation.DescribeChangeSetOutput
+		statusPlan       v1alpha1.AWSConfigStatusAWSPlan
+		approved         string
+		expectedCalls    []string
+		notExpectedCalls []string
+		expectedCanceled bool
+		expectedPlan     v1alpha1.AWSConfigStatusAWSPlan
+	}{
+		{
+			name:             "case 0: missing change set is created",
+			changeSet:        nil,
+			expectedCalls:    []string{"ListChangeSets", "CreateChangeSet"},
+			notExpectedCalls: []string{"ExecuteChangeSet"},
+		},
+		{
+			name:             "case 1: planned change set is written to the CR status",
+			changeSet:        completeChangeSet,
+			approved:         changeSetName,
+			notExpectedCalls: []string{"CreateChangeSet", "ExecuteChangeSet"},
+			expectedCanceled: true,
+			expectedPlan:     newPlan(completeChangeSet),
+		},
+		{
+			name:             "case 2: change set is not executed without approval",
+			changeSet:        completeChangeSet,
+			statusPlan:       newPlan(completeChangeSet),
+			notExpectedCalls: []string{"CreateChangeSet", "ExecuteChangeSet"},
+			expectedPlan:     newPlan(completeChangeSet),
+		},
+		{
+			name:             "case 3: change set is not executed with approval of another change set",
+			changeSet:        completeChangeSet,
+			statusPlan:       newPlan(completeChangeSet),
+			approved:         "plan-fedcba9876543210",
+			notExpectedCalls: []string{"CreateChangeSet", "ExecuteChangeSet"},
+			expectedPlan:     newPlan(completeChangeSet),
+		},
+		{
+			name:             "case 4: approved change set is executed",
+			changeSet:        completeChangeSet,
+			statusPlan:       newPlan(completeChangeSet),
+			approved:         changeSetName,
+			expectedCalls:    []string{"ExecuteChangeSet"},
+			notExpectedCalls: []string{"CreateChangeSet"},
+			expectedPlan:     newPlan(completeChangeSet),
+		},
+		{
+			name:             "case 5: failed change set is not executed even with approval",
+			changeSet:        failedChangeSet,
+			statusPlan:       newPlan(failedChangeSet),
+			approved:         changeSetName,
+			notExpectedCalls: []string{"CreateChangeSet", "ExecuteChangeSet"},
+			expectedPlan:     newPlan(failedChangeSet),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						key.AnnotationPlanApproved: tc.approved,
+						key.AnnotationPlanMode:     "true",
+					},
+					Name:      "al9qy",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "al9qy",
+					},
+				},
+				Status: v1alpha1.AWSConfigStatus{
+					AWS: v1alpha1.AWSConfigStatusAWS{
+						Plan: tc.statusPlan,
+					},
+				},
+			}
+
+			g8sClient := fake.NewSimpleClientset(&cr)
+			cloudFormation := &cloudFormationMock{changeSet: tc.changeSet}
+
+			r := &Resource{
+				eventRecorder: recordertest.New(),
+				g8sClient:     g8sClient,
+				logger:        microloggertest.New(),
+			}
+
+			var ctx context.Context
+			{
+				c := controllercontext.Context{
+					Client: controllercontext.ContextClient{
+						TenantCluster: controllercontext.ContextClientTenantCluster{
+							AWS: awsclient.Clients{
+								CloudFormation: newCloudFormationClient(cloudFormation),
+								EC2:            &ec2ClientMock{},
+							},
+						},
+					},
+				}
+
+				ctx = controllercontext.NewContext(context.Background(), c)
+				ctx = reconciliationcanceledcontext.NewContext(ctx, make(chan struct{}))
+			}
+
+			err := r.applyPlan(ctx, cr, changeSetName, "Resources: {}")
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			for _, c := range tc.expectedCalls {
+				if !cloudFormation.called(c) {
+					t.Fatalf("expected %#q to be called, got calls %#v", c, cloudFormation.calls)
+				}
+			}
+			for _, c := range tc.notExpectedCalls {
+				if cloudFormation.called(c) {
+					t.Fatalf("expected %#q not to be called, got calls %#v", c, cloudFormation.calls)
+				}
+			}
+
+			if reconciliationcanceledcontext.IsCanceled(ctx) != tc.expectedCanceled {
+				t.Fatalf("expected canceled to be %t", tc.expectedCanceled)
+			}
+
+			updated, err := g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).Get(cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if len(updated.Status.AWS.Plan.Changes) != len(tc.expectedPlan.Changes) || updated.Status.AWS.Plan.ChangeSetName != tc.expectedPlan.ChangeSetName {
+				t.Fatalf("expected plan %#v got %#v", tc.expectedPlan, updated.Status.AWS.Plan)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package tccp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+const (
+	// recoveryBackoffBase is the time to wait after the first attempt of
+	// continuing a failed update rollback. The time to wait doubles with every
+	// further attempt.
+	recoveryBackoffBase = 5 * time.Minute
+	// recoveryBackoffMax is the maximum time to wait between two attempts of
+	// continuing a failed update rollback.
+	recoveryBackoffMax = 2 * time.Hour
+)
+
+// recoverStack continues the failed update rollback of the tenant cluster's
+// control plane stack. Stacks in UPDATE_ROLLBACK_FAILED cannot be updated
+// anymore and their outputs are not accessible, which would leave the tenant
+// cluster frozen forever. Resources failing to roll back are skipped in case
+// they are configured to be skippable. Attempts are tracked in the CR status
+// and retried with an exponential backoff.
+func (r *Resource) recoverStack(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	var customObject v1alpha1.AWSConfig
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding latest version of custom resource")
+
+		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		customObject = *newObj
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "found latest version of custom resource")
+	}
+
+	recovery := customObject.Status.AWS.StackRecovery
+
+	{
+		next := nextRecoveryAttempt(recovery)
+		if time.Now().Before(next) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("waiting until %s before continuing the failed update rollback again", next.Format(time.RFC3339)))
+			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
+			return nil
+		}
+	}
+
+	var skipResources []string
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding resources of the tenant cluster's control plane cloud formation stack which failed to roll back")
+
+		failedResources, err := r.searchFailedResources(ctx, cr)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		skipResources = intersect(failedResources, r.rollbackSkipResources)
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d resources which failed to roll back, %d of them can be skipped", len(failedResources), len(skipResources)))
+	}
+
+	var rollbackErr error
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("continuing the failed update rollback of the tenant cluster's control plane cloud formation stack skipping resources %#q", skipResources))
+
+		i := &cloudformation.ContinueUpdateRollbackInput{
+			StackName: aws.String(key.MainGuestStackName(cr)),
+		}
+		for _, s := range skipResources {
+			i.ResourcesToSkip = append(i.ResourcesToSkip, aws.String(s))
+		}
+
+		_, rollbackErr = cc.Client.TenantCluster.AWS.CloudFormation.ContinueUpdateRollback(i)
+		if rollbackErr != nil {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "failed continuing the failed update rollback of the tenant cluster's control plane cloud formation stack")
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonStackRecoveryFailed, fmt.Sprintf("failed continuing the failed update rollback of stack %#q: %s", key.MainGuestStackName(cr), rollbackErr.Error()))
+		} else {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "continued the failed update rollback of the tenant cluster's control plane cloud formation stack")
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonStackRecoveryRequested, fmt.Sprintf("continued the failed update rollback of stack %#q skipping resources %#q", key.MainGuestStackName(cr), skipResources))
+		}
+	}
+
+	// Failed attempts are tracked as well, so that the backoff also applies to
+	// requests being rejected by Cloud Formation.
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status stack recovery")
+
+		customObject.Status.AWS.StackRecovery = v1alpha1.AWSConfigStatusAWSStackRecovery{
+			Attempts:         recovery.Attempts + 1,
+			LastAttemptTime:  v1alpha1.DeepCopyTime{Time: time.Now()},
+			SkippedResources: skipResources,
+		}
+
+		_, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.GetNamespace()).UpdateStatus(&customObject)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status stack recovery")
+	}
+
+	if rollbackErr != nil {
+		return microerror.Mask(rollbackErr)
+	}
+
+	return nil
+}
+
+// ensureRecoveryStatusCleared resets the stack recovery in the CR status once
+// the tenant cluster's control plane stack left the UPDATE_ROLLBACK_FAILED
+// state, so that a future failure starts over without backoff.
+func (r *Resource) ensureRecoveryStatusCleared(ctx context.Context, cr v1alpha1.AWSConfig) error {
+	if cr.Status.AWS.StackRecovery.Attempts == 0 {
+		return nil
+	}
+
+	var customObject v1alpha1.AWSConfig
+	{
+		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		customObject = *newObj
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "clearing CR status stack recovery")
+
+		customObject.Status.AWS.StackRecovery = v1alpha1.AWSConfigStatusAWSStackRecovery{}
+
+		_, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.GetNamespace()).UpdateStatus(&customObject)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "cleared CR status stack recovery")
+	}
+
+	return nil
+}
+
+// searchFailedResources returns the logical IDs of the stack resources which
+// failed to roll back.
+func (r *Resource) searchFailedResources(ctx context.Context, cr v1alpha1.AWSConfig) ([]string, error) {
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var failedResources []string
+	var nextToken *string
+	for {
+		i := &cloudformation.ListStackResourcesInput{
+			NextToken: nextToken,
+			StackName: aws.String(key.MainGuestStackName(cr)),
+		}
+
+		o, err := cc.Client.TenantCluster.AWS.CloudFormation.ListStackResources(i)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		for _, s := range o.StackResourceSummaries {
+			if aws.StringValue(s.ResourceStatus) == cloudformation.ResourceStatusUpdateFailed {
+				failedResources = append(failedResources, aws.StringValue(s.LogicalResourceId))
+			}
+		}
+
+		if o.NextToken == nil {
+			break
+		}
+		nextToken = o.NextToken
+	}
+
+	return failedResources, nil
+}
+
+func intersect(a []string, b []string) []string {
+	var result []string
+
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				result = append(result, x)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// nextRecoveryAttempt returns the earliest time at which the failed update
+// rollback may be continued again. The backoff starts at recoveryBackoffBase
+// and doubles with every attempt until it reaches recoveryBackoffMax.
+func nextRecoveryAttempt(recovery v1alpha1.AWSConfigStatusAWSStackRecovery) time.Time {
+	if recovery.Attempts == 0 {
+		return time.Time{}
+	}
+
+	backoff := recoveryBackoffBase
+	for i := 1; i < recovery.Attempts && backoff < recoveryBackoffMax; i++ {
+		backoff *= 2
+	}
+	if backoff > recoveryBackoffMax {
+		backoff = recoveryBackoffMax
+	}
+
+	return recovery.LastAttemptTime.Add(backoff)
+}
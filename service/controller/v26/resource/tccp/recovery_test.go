@@ -0,0 +1,257 @@
+package tccp
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/fake"
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsclient "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+)
+
+func Test_Resource_recoverStack(t *testing.T) {
+	resources := []*cloudformation.StackResourceSummary{
+		{
+			LogicalResourceId: aws.String("MasterInstance"),
+			ResourceStatus:    aws.String(cloudformation.ResourceStatusUpdateFailed),
+		},
+		{
+			LogicalResourceId: aws.String("WorkerAutoScalingGroup"),
+			ResourceStatus:    aws.String(cloudformation.ResourceStatusUpdateFailed),
+		},
+		{
+			LogicalResourceId: aws.String("VPC"),
+			ResourceStatus:    aws.String(cloudformation.ResourceStatusUpdateComplete),
+		},
+	}
+
+	testCases := []struct {
+		name                     string
+		recovery                 v1alpha1.AWSConfigStatusAWSStackRecovery
+		rollbackError            error
+		expectedError            bool
+		expectedRollback         bool
+		expectedSkippedResources []string
+		expectedAttempts         int
+	}{
+		{
+			name:                     "case 0: failed update rollback is continued skipping the skippable resources",
+			expectedRollback:         true,
+			expectedSkippedResources: []string{"MasterInstance"},
+			expectedAttempts:         1,
+		},
+		{
+			name: "case 1: failed update rollback is not continued within the backoff",
+			recovery: v1alpha1.AWSConfigStatusAWSStackRecovery{
+				Attempts:        1,
+				LastAttemptTime: v1alpha1.DeepCopyTime{Time: time.Now()},
+			},
+			expectedRollback: false,
+			expectedAttempts: 1,
+		},
+		{
+			name: "case 2: failed update rollback is continued again after the backoff",
+			recovery: v1alpha1.AWSConfigStatusAWSStackRecovery{
+				Attempts:        1,
+				LastAttemptTime: v1alpha1.DeepCopyTime{Time: time.Now().Add(-recoveryBackoffBase - time.Minute)},
+			},
+			expectedRollback:         true,
+			expectedSkippedResources: []string{"MasterInstance"},
+			expectedAttempts:         2,
+		},
+		{
+			name:             "case 3: rejected attempt is tracked in the CR status",
+			rollbackError:    awserr.New("ValidationError", "Stack is not in a state to continue the update rollback", nil),
+			expectedError:    true,
+			expectedRollback: true,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "al9qy",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.AWSConfigSpec{
+					Cluster: v1alpha1.Cluster{
+						ID: "al9qy",
+					},
+				},
+				Status: v1alpha1.AWSConfigStatus{
+					AWS: v1alpha1.AWSConfigStatusAWS{
+						StackRecovery: tc.recovery,
+					},
+				},
+			}
+
+			g8sClient := fake.NewSimpleClientset(&cr)
+			cloudFormation := &cloudFormationMock{
+				resources:     resources,
+				rollbackError: tc.rollbackError,
+			}
+
+			r := &Resource{
+				eventRecorder:         recordertest.New(),
+				g8sClient:             g8sClient,
+				logger:                microloggertest.New(),
+				rollbackSkipResources: []string{"MasterInstance", "DockerVolume"},
+			}
+
+			c := controllercontext.Context{
+				Client: controllercontext.ContextClient{
+					TenantCluster: controllercontext.ContextClientTenantCluster{
+						AWS: awsclient.Clients{
+							CloudFormation: newCloudFormationClient(cloudFormation),
+						},
+					},
+				},
+			}
+			ctx := controllercontext.NewContext(context.Background(), c)
+
+			err := r.recoverStack(ctx, cr)
+			if tc.expectedError && err == nil {
+				t.Fatalf("expected error got %#v", nil)
+			}
+			if !tc.expectedError && err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			if cloudFormation.called("ContinueUpdateRollback") != tc.expectedRollback {
+				t.Fatalf("expected rollback to be continued %t, got calls %#v", tc.expectedRollback, cloudFormation.calls)
+			}
+			if !reflect.DeepEqual(cloudFormation.skippedResources, tc.expectedSkippedResources) {
+				t.Fatalf("expected skipped resources %#v got %#v", tc.expectedSkippedResources, cloudFormation.skippedResources)
+			}
+
+			updated, err := g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).Get(cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if updated.Status.AWS.StackRecovery.Attempts != tc.expectedAttempts {
+				t.Fatalf("expected %d attempts got %d", tc.expectedAttempts, updated.Status.AWS.StackRecovery.Attempts)
+			}
+		})
+	}
+}
+
+func Test_Resource_ensureRecoveryStatusCleared(t *testing.T) {
+	testCases := []struct {
+		name     string
+		recovery v1alpha1.AWSConfigStatusAWSStackRecovery
+	}{
+		{
+			name: "case 0: missing stack recovery is a no-op",
+		},
+		{
+			name: "case 1: stack recovery is cleared",
+			recovery: v1alpha1.AWSConfigStatusAWSStackRecovery{
+				Attempts:         3,
+				LastAttemptTime:  v1alpha1.DeepCopyTime{Time: time.Now()},
+				SkippedResources: []string{"MasterInstance"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := v1alpha1.AWSConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "al9qy",
+					Namespace: "default",
+				},
+				Status: v1alpha1.AWSConfigStatus{
+					AWS: v1alpha1.AWSConfigStatusAWS{
+						StackRecovery: tc.recovery,
+					},
+				},
+			}
+
+			g8sClient := fake.NewSimpleClientset(&cr)
+
+			r := &Resource{
+				g8sClient: g8sClient,
+				logger:    microloggertest.New(),
+			}
+
+			err := r.ensureRecoveryStatusCleared(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			updated, err := g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).Get(cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if updated.Status.AWS.StackRecovery.Attempts != 0 || len(updated.Status.AWS.StackRecovery.SkippedResources) != 0 {
+				t.Fatalf("expected empty stack recovery got %#v", updated.Status.AWS.StackRecovery)
+			}
+
+			var updates int
+			for _, a := range g8sClient.Actions() {
+				if a.GetVerb() == "update" {
+					updates++
+				}
+			}
+			if tc.recovery.Attempts == 0 && updates != 0 {
+				t.Fatalf("expected no CR status update got %d", updates)
+			}
+		})
+	}
+}
+
+func Test_nextRecoveryAttempt(t *testing.T) {
+	lastAttemptTime := time.Date(2019, 8, 1, 6, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		attempts     int
+		expectedWait time.Duration
+	}{
+		{
+			name:         "case 0: first attempt waits for the backoff base",
+			attempts:     1,
+			expectedWait: recoveryBackoffBase,
+		},
+		{
+			name:         "case 1: backoff doubles with every attempt",
+			attempts:     3,
+			expectedWait: 4 * recoveryBackoffBase,
+		},
+		{
+			name:         "case 2: backoff is capped",
+			attempts:     20,
+			expectedWait: recoveryBackoffMax,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recovery := v1alpha1.AWSConfigStatusAWSStackRecovery{
+				Attempts:        tc.attempts,
+				LastAttemptTime: v1alpha1.DeepCopyTime{Time: lastAttemptTime},
+			}
+
+			next := nextRecoveryAttempt(recovery)
+			if wait := next.Sub(lastAttemptTime); wait != tc.expectedWait {
+				t.Fatalf("expected %s got %s", tc.expectedWait, wait)
+			}
+		})
+	}
+
+	if next := nextRecoveryAttempt(v1alpha1.AWSConfigStatusAWSStackRecovery{}); !next.IsZero() {
+		t.Fatalf("expected zero time got %s", next)
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 
+	"github.com/giantswarm/aws-operator/pkg/recorder"
 	"github.com/giantswarm/aws-operator/service/controller/v26/adapter"
 	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
 	"github.com/giantswarm/aws-operator/service/controller/v26/detection"
 	"github.com/giantswarm/aws-operator/service/controller/v26/encrypter"
+	"github.com/giantswarm/aws-operator/service/controller/v26/image"
 	"github.com/giantswarm/aws-operator/service/controller/v26/key"
 )
 
@@ -45,6 +48,9 @@ type Config struct {
 	// EncrypterRoleManager manages role encryption. This can be supported by
 	// different implementations and thus is optional.
 	EncrypterRoleManager encrypter.RoleManager
+	EventRecorder        recorder.Interface
+	G8sClient            versioned.Interface
+	ImageResolver        image.Interface
 	Logger               micrologger.Logger
 
 	Detection                  *detection.Detection
@@ -54,21 +60,42 @@ type Config struct {
 	InstallationName           string
 	InstanceMonitoring         bool
 	PublicRouteTables          string
-	Route53Enabled             bool
+	// RollbackSkipResources are the logical IDs of the TCCP stack resources
+	// which may be skipped when continuing a failed update rollback. Only the
+	// resources which actually failed to roll back are skipped.
+	RollbackSkipResources []string
+	Route53Enabled        bool
+	// TransitGatewayID is the ID of the Transit Gateway the tenant cluster's VPC
+	// is attached to. When empty, the tenant cluster's VPC is peered with the
+	// control plane VPC.
+	TransitGatewayID string
+	// VolumeSnapshotDockerVolume defines whether the docker volume of the master
+	// is snapshotted in addition to the etcd volume before master updates.
+	VolumeSnapshotDockerVolume bool
+	// VolumeSnapshotRetention is the number of snapshots kept per master
+	// volume. No snapshots are created in case it is 0.
+	VolumeSnapshotRetention int
 }
 
 // Resource implements the cloudformation resource.
 type Resource struct {
 	apiWhiteList         adapter.APIWhitelist
 	encrypterRoleManager encrypter.RoleManager
+	eventRecorder        recorder.Interface
+	g8sClient            versioned.Interface
+	imageResolver        image.Interface
 	logger               micrologger.Logger
 
-	encrypterBackend   string
-	detection          *detection.Detection
-	installationName   string
-	instanceMonitoring bool
-	publicRouteTables  string
-	route53Enabled     bool
+	encrypterBackend           string
+	detection                  *detection.Detection
+	installationName           string
+	instanceMonitoring         bool
+	publicRouteTables          string
+	rollbackSkipResources      []string
+	route53Enabled             bool
+	transitGatewayID           string
+	volumeSnapshotDockerVolume bool
+	volumeSnapshotRetention    int
 }
 
 // New creates a new configured cloudformation resource.
@@ -76,6 +103,15 @@ func New(config Config) (*Resource, error) {
 	if config.Detection == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Detection must not be empty", config)
 	}
+	if config.EventRecorder == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.EventRecorder must not be empty", config)
+	}
+	if config.G8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
+	}
+	if config.ImageResolver == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.ImageResolver must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
@@ -83,18 +119,28 @@ func New(config Config) (*Resource, error) {
 	if config.EncrypterBackend == "" {
 		return nil, microerror.Maskf(invalidConfigError, "%T.EncrypterBackend must not be empty", config)
 	}
+	if config.VolumeSnapshotRetention < 0 {
+		return nil, microerror.Maskf(invalidConfigError, "%T.VolumeSnapshotRetention must not be negative", config)
+	}
 
 	r := &Resource{
 		apiWhiteList:         config.APIWhitelist,
 		detection:            config.Detection,
 		encrypterRoleManager: config.EncrypterRoleManager,
+		eventRecorder:        config.EventRecorder,
+		g8sClient:            config.G8sClient,
+		imageResolver:        config.ImageResolver,
 		logger:               config.Logger,
 
-		encrypterBackend:   config.EncrypterBackend,
-		installationName:   config.InstallationName,
-		instanceMonitoring: config.InstanceMonitoring,
-		publicRouteTables:  config.PublicRouteTables,
-		route53Enabled:     config.Route53Enabled,
+		encrypterBackend:           config.EncrypterBackend,
+		installationName:           config.InstallationName,
+		instanceMonitoring:         config.InstanceMonitoring,
+		publicRouteTables:          config.PublicRouteTables,
+		rollbackSkipResources:      config.RollbackSkipResources,
+		route53Enabled:             config.Route53Enabled,
+		transitGatewayID:           config.TransitGatewayID,
+		volumeSnapshotDockerVolume: config.VolumeSnapshotDockerVolume,
+		volumeSnapshotRetention:    config.VolumeSnapshotRetention,
 	}
 
 	return r, nil
@@ -104,11 +150,12 @@ func (r *Resource) Name() string {
 	return Name
 }
 
-// searchMasterInstanceID tries to find any "active" master instance. The method
-// ignores instances that are shutting down or are already terminated. This is
-// because we only need to find the master instance in order to terminate it
-// before updating the TCCP Cloud Formation stack. In case the master instance
-// is already terminated, we ignore it. The used filter name is the following.
+// searchMasterInstanceIDs tries to find all "active" master instances. The
+// method ignores instances that are shutting down or are already terminated.
+// This is because we only need to find the master instances in order to
+// terminate them before updating the TCCP Cloud Formation stack. In case a
+// master instance is already terminated, we ignore it. The used filter name is
+// the following.
 //
 //     instance-state-name
 //
@@ -116,21 +163,40 @@ func (r *Resource) Name() string {
 //
 //     pending, running, stopping, stopped
 //
-func (r *Resource) searchMasterInstanceID(ctx context.Context, cr v1alpha1.AWSConfig) (string, error) {
+func (r *Resource) searchMasterInstanceIDs(ctx context.Context, cr v1alpha1.AWSConfig) ([]string, error) {
+	instances, err := r.searchMasterInstances(ctx, cr)
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var instanceIDs []string
+	for _, instance := range instances {
+		instanceIDs = append(instanceIDs, *instance.InstanceId)
+	}
+
+	return instanceIDs, nil
+}
+
+// searchMasterInstances finds the "active" master instances the same way
+// searchMasterInstanceIDs does.
+func (r *Resource) searchMasterInstances(ctx context.Context, cr v1alpha1.AWSConfig) ([]*ec2.Instance, error) {
 	cc, err := controllercontext.FromContext(ctx)
 	if err != nil {
-		return "", microerror.Mask(err)
+		return nil, microerror.Mask(err)
 	}
 
-	var instanceID string
+	var instances []*ec2.Instance
 	{
+		var names []*string
+		for _, n := range key.MasterInstanceNames(cr) {
+			names = append(names, aws.String(n))
+		}
+
 		i := &ec2.DescribeInstancesInput{
 			Filters: []*ec2.Filter{
 				{
-					Name: aws.String("tag:Name"),
-					Values: []*string{
-						aws.String(key.MasterInstanceName(cr)),
-					},
+					Name:   aws.String("tag:Name"),
+					Values: names,
 				},
 				{
 					Name: aws.String("tag:giantswarm.io/cluster"),
@@ -152,49 +218,49 @@ func (r *Resource) searchMasterInstanceID(ctx context.Context, cr v1alpha1.AWSCo
 
 		o, err := cc.Client.TenantCluster.AWS.EC2.DescribeInstances(i)
 		if err != nil {
-			return "", microerror.Mask(err)
+			return nil, microerror.Mask(err)
 		}
 
-		if len(o.Reservations) == 0 {
-			return "", microerror.Maskf(notExistsError, "master instance")
+		for _, reservation := range o.Reservations {
+			instances = append(instances, reservation.Instances...)
 		}
-		if len(o.Reservations) != 1 {
-			return "", microerror.Maskf(executionFailedError, "expected one master instance, got %d", len(o.Reservations))
+
+		if len(instances) == 0 {
+			return nil, microerror.Maskf(notExistsError, "master instance")
 		}
-		if len(o.Reservations[0].Instances) != 1 {
-			return "", microerror.Maskf(executionFailedError, "expected one master instance, got %d", len(o.Reservations[0].Instances))
+		if len(instances) > key.MasterReplicas(cr) {
+			return nil, microerror.Maskf(executionFailedError, "expected at most %d master instances, got %d", key.MasterReplicas(cr), len(instances))
 		}
-
-		instanceID = *o.Reservations[0].Instances[0].InstanceId
 	}
 
-	return instanceID, nil
+	return instances, nil
 }
 
-func (r *Resource) terminateMasterInstance(ctx context.Context, cr v1alpha1.AWSConfig) error {
+func (r *Resource) terminateMasterInstances(ctx context.Context, cr v1alpha1.AWSConfig) error {
 	cc, err := controllercontext.FromContext(ctx)
 	if err != nil {
 		return microerror.Mask(err)
 	}
 
-	var instanceID string
+	var instanceIDs []string
 	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", "finding master instance ID")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding master instance IDs")
 
-		instanceID, err = r.searchMasterInstanceID(ctx, cr)
+		instanceIDs, err = r.searchMasterInstanceIDs(ctx, cr)
 		if IsNotExists(err) {
-			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find master instance ID")
-			r.logger.LogCtx(ctx, "level", "debug", "message", "master instance does not exist")
+			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find master instance IDs")
+			r.logger.LogCtx(ctx, "level", "debug", "message", "master instances do not exist")
 			return nil
 
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found master instance ID %#q", instanceID))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found master instance IDs %#q", instanceIDs))
 	}
 
-	{
+	var activeIDs []*string
+	for _, instanceID := range instanceIDs {
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("disabling termination protection for master instance %#q", instanceID))
 
 		i := &ec2.ModifyInstanceAttributeInput{
@@ -207,23 +273,28 @@ func (r *Resource) terminateMasterInstance(ctx context.Context, cr v1alpha1.AWSC
 		_, err = cc.Client.TenantCluster.AWS.EC2.ModifyInstanceAttribute(i)
 		if IsAlreadyTerminated(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("did not disable termination protection for master instance %#q", instanceID))
-			r.logger.LogCtx(ctx, "level", "debug", "message", "master instance is already terminated")
-			return nil
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("master instance %#q is already terminated", instanceID))
+			continue
 
 		} else if err != nil {
 			return microerror.Mask(err)
 		}
 
 		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("disabled termination protection for master instance %#q", instanceID))
+
+		activeIDs = append(activeIDs, aws.String(instanceID))
+	}
+
+	if len(activeIDs) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "all master instances are already terminated")
+		return nil
 	}
 
 	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("terminating master instance %#q", instanceID))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("terminating %d master instances", len(activeIDs)))
 
 		i := &ec2.TerminateInstancesInput{
-			InstanceIds: []*string{
-				aws.String(instanceID),
-			},
+			InstanceIds: activeIDs,
 		}
 
 		_, err := cc.Client.TenantCluster.AWS.EC2.TerminateInstances(i)
@@ -231,7 +302,7 @@ func (r *Resource) terminateMasterInstance(ctx context.Context, cr v1alpha1.AWSC
 			return microerror.Mask(err)
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("terminated master instance %#q", instanceID))
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("terminated %d master instances", len(activeIDs)))
 	}
 
 	return nil
@@ -0,0 +1,224 @@
+package tccp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/ebs"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// snapshotVolumes creates EBS snapshots of the etcd volume and optionally the
+// docker volume of the master before the master instance gets replaced. The
+// volumes are expected to be detached already, so that the snapshots are
+// consistent. The created snapshots are tracked in the CR status. Snapshots
+// are created without waiting for them to complete, so that the
+// reconciliation is not blocked. Instead snapshotVolumes returns false as long
+// as snapshots of the current master are pending and the update must not
+// continue. Snapshots in the CR status belong to the current master in case
+// they were created after its launch. Failed snapshots are created again.
+// Snapshots exceeding the configured retention are deleted once all snapshots
+// are completed.
+func (r *Resource) snapshotVolumes(ctx context.Context, cr v1alpha1.AWSConfig) (bool, error) {
+	if r.volumeSnapshotRetention == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "not creating snapshots of master volumes because snapshots are disabled")
+		return true, nil
+	}
+
+	cc, err := controllercontext.FromContext(ctx)
+	if err != nil {
+		return false, microerror.Mask(err)
+	}
+
+	var ebsService ebs.Interface
+	{
+		c := ebs.Config{
+			Client: cc.Client.TenantCluster.AWS.EC2,
+			Logger: r.logger,
+		}
+
+		ebsService, err = ebs.New(c)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	var launchTime time.Time
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the launch time of the master instances")
+
+		instances, err := r.searchMasterInstances(ctx, cr)
+		if IsNotExists(err) {
+			// The master instances are only terminated once their snapshots
+			// completed. So all snapshots in the CR status are considered.
+		} else if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		for _, instance := range instances {
+			if aws.TimeValue(instance.LaunchTime).After(launchTime) {
+				launchTime = aws.TimeValue(instance.LaunchTime)
+			}
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the launch time %s of the master instances", launchTime))
+	}
+
+	var all []ebs.Snapshot
+	{
+		all, err = ebsService.ListSnapshots(cr)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+	}
+
+	var snapshots []v1alpha1.AWSConfigStatusAWSVolumeSnapshot
+	var changed bool
+	{
+		for _, s := range cr.Status.AWS.VolumeSnapshots {
+			if !s.CreationTime.Time.After(launchTime) {
+				continue
+			}
+
+			if snapshotState(all, s.SnapshotID) == ec2.SnapshotStateError {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonVolumeSnapshotsFailed, fmt.Sprintf("snapshot %#q of EBS volume %#q failed", s.SnapshotID, s.VolumeID))
+				changed = true
+				continue
+			}
+
+			snapshots = append(snapshots, s)
+		}
+	}
+
+	var volumes []ebs.Volume
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding master volumes to snapshot")
+
+		filterFuncs := []func(t *ec2.Tag) bool{
+			ebs.NewEtcdVolumeFilter(cr),
+		}
+		if r.volumeSnapshotDockerVolume {
+			filterFuncs = append(filterFuncs, ebs.NewDockerVolumeFilter(cr))
+		}
+
+		volumes, err = ebsService.ListVolumes(cr, filterFuncs...)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d master volumes to snapshot", len(volumes)))
+	}
+
+	var created []string
+	{
+		tags := key.ClusterTags(cr, r.installationName)
+
+		for _, v := range volumes {
+			if hasVolumeSnapshot(snapshots, v.VolumeID) {
+				continue
+			}
+
+			snapshotID, err := ebsService.SnapshotVolume(ctx, v, tags)
+			if err != nil {
+				r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeWarning, key.EventReasonVolumeSnapshotsFailed, fmt.Sprintf("failed creating snapshot of EBS volume %#q: %s", v.VolumeID, err.Error()))
+				return false, microerror.Mask(err)
+			}
+
+			s := v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+				CreationTime: v1alpha1.DeepCopyTime{Time: time.Now()},
+				SnapshotID:   snapshotID,
+				VolumeID:     v.VolumeID,
+				VolumeName:   v.Name,
+			}
+
+			snapshots = append(snapshots, s)
+			created = append(created, snapshotID)
+			changed = true
+		}
+	}
+
+	if changed {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status volume snapshots")
+
+		customObject, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		customObject.Status.AWS.VolumeSnapshots = snapshots
+
+		_, err = r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.GetNamespace()).UpdateStatus(customObject)
+		if err != nil {
+			return false, microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "updated CR status volume snapshots")
+
+		if len(created) > 0 {
+			r.eventRecorder.Emit(ctx, &cr, corev1.EventTypeNormal, key.EventReasonVolumeSnapshotsCreated, fmt.Sprintf("created snapshots %#q of master volumes", created))
+		}
+	}
+
+	{
+		var pending []string
+		for _, s := range snapshots {
+			if snapshotState(all, s.SnapshotID) != ec2.SnapshotStateCompleted {
+				pending = append(pending, s.SnapshotID)
+			}
+		}
+
+		if len(pending) > 0 {
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("snapshots %#q of master volumes are pending", pending))
+			return false, nil
+		}
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding outdated snapshots of master volumes")
+
+		outdated := ebs.OutdatedSnapshots(all, r.volumeSnapshotRetention)
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d outdated snapshots of master volumes", len(outdated)))
+
+		for _, s := range outdated {
+			err := ebsService.DeleteSnapshot(ctx, s.SnapshotID)
+			if err != nil {
+				return false, microerror.Mask(err)
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func hasVolumeSnapshot(snapshots []v1alpha1.AWSConfigStatusAWSVolumeSnapshot, volumeID string) bool {
+	for _, s := range snapshots {
+		if s.VolumeID == volumeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// snapshotState returns the state of the snapshot with the given ID. Snapshots
+// not found are considered pending, since listing snapshots is eventually
+// consistent.
+func snapshotState(snapshots []ebs.Snapshot, snapshotID string) string {
+	for _, s := range snapshots {
+		if s.SnapshotID == snapshotID {
+			return s.State
+		}
+	}
+
+	return ec2.SnapshotStatePending
+}
@@ -0,0 +1,197 @@
+package tccp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/fake"
+	"github.com/giantswarm/micrologger/microloggertest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	awsclient "github.com/giantswarm/aws-operator/client/aws"
+	"github.com/giantswarm/aws-operator/pkg/recorder/recordertest"
+	"github.com/giantswarm/aws-operator/service/controller/v26/controllercontext"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+func Test_Resource_snapshotVolumes(t *testing.T) {
+	launchTime := time.Date(2019, 8, 1, 6, 0, 0, 0, time.UTC)
+
+	cr := v1alpha1.AWSConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "al9qy",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.AWSConfigSpec{
+			Cluster: v1alpha1.Cluster{
+				ID: "al9qy",
+			},
+		},
+	}
+
+	etcdVolume := &ec2.Volume{
+		Tags: []*ec2.Tag{
+			{
+				Key:   aws.String("Name"),
+				Value: aws.String(key.EtcdVolumeName(cr, 0)),
+			},
+		},
+		VolumeId: aws.String("vol-etcd"),
+	}
+
+	newStatusSnapshot := func(snapshotID string, creationTime time.Time) v1alpha1.AWSConfigStatusAWSVolumeSnapshot {
+		return v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+			CreationTime: v1alpha1.DeepCopyTime{Time: creationTime},
+			SnapshotID:   snapshotID,
+			VolumeID:     "vol-etcd",
+			VolumeName:   key.EtcdVolumeName(cr, 0),
+		}
+	}
+	newSnapshot := func(snapshotID string, state string) *ec2.Snapshot {
+		return &ec2.Snapshot{
+			SnapshotId: aws.String(snapshotID),
+			StartTime:  aws.Time(launchTime.Add(time.Hour)),
+			State:      aws.String(state),
+			VolumeId:   aws.String("vol-etcd"),
+		}
+	}
+
+	testCases := []struct {
+		name                      string
+		statusSnapshots           []v1alpha1.AWSConfigStatusAWSVolumeSnapshot
+		snapshots                 []*ec2.Snapshot
+		expectedCompleted         bool
+		expectedCreatedSnapshots  int
+		expectedStatusSnapshotIDs []string
+	}{
+		{
+			name:                      "case 0: missing snapshot is created without waiting for it",
+			statusSnapshots:           nil,
+			snapshots:                 nil,
+			expectedCompleted:         false,
+			expectedCreatedSnapshots:  1,
+			expectedStatusSnapshotIDs: []string{"snap-0"},
+		},
+		{
+			name: "case 1: pending snapshot is not created again",
+			statusSnapshots: []v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+				newStatusSnapshot("snap-pending", launchTime.Add(time.Hour)),
+			},
+			snapshots: []*ec2.Snapshot{
+				newSnapshot("snap-pending", ec2.SnapshotStatePending),
+			},
+			expectedCompleted:         false,
+			expectedCreatedSnapshots:  0,
+			expectedStatusSnapshotIDs: []string{"snap-pending"},
+		},
+		{
+			name: "case 2: completed snapshot lets the update continue",
+			statusSnapshots: []v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+				newStatusSnapshot("snap-completed", launchTime.Add(time.Hour)),
+			},
+			snapshots: []*ec2.Snapshot{
+				newSnapshot("snap-completed", ec2.SnapshotStateCompleted),
+			},
+			expectedCompleted:         true,
+			expectedCreatedSnapshots:  0,
+			expectedStatusSnapshotIDs: []string{"snap-completed"},
+		},
+		{
+			name: "case 3: snapshot of the previous master is not considered",
+			statusSnapshots: []v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+				newStatusSnapshot("snap-previous", launchTime.Add(-time.Hour)),
+			},
+			snapshots: []*ec2.Snapshot{
+				newSnapshot("snap-previous", ec2.SnapshotStateCompleted),
+			},
+			expectedCompleted:         false,
+			expectedCreatedSnapshots:  1,
+			expectedStatusSnapshotIDs: []string{"snap-0"},
+		},
+		{
+			name: "case 4: failed snapshot is created again",
+			statusSnapshots: []v1alpha1.AWSConfigStatusAWSVolumeSnapshot{
+				newStatusSnapshot("snap-failed", launchTime.Add(time.Hour)),
+			},
+			snapshots: []*ec2.Snapshot{
+				newSnapshot("snap-failed", ec2.SnapshotStateError),
+			},
+			expectedCompleted:         false,
+			expectedCreatedSnapshots:  1,
+			expectedStatusSnapshotIDs: []string{"snap-0"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := *cr.DeepCopy()
+			cr.Status.AWS.VolumeSnapshots = tc.statusSnapshots
+
+			g8sClient := fake.NewSimpleClientset(&cr)
+			ec2Client := &ec2ClientMock{
+				instances: []*ec2.Instance{
+					{
+						InstanceId: aws.String("i-master"),
+						LaunchTime: aws.Time(launchTime),
+					},
+				},
+				snapshots: tc.snapshots,
+				volumes: []*ec2.Volume{
+					etcdVolume,
+				},
+			}
+
+			r := &Resource{
+				eventRecorder:           recordertest.New(),
+				g8sClient:               g8sClient,
+				installationName:        "test",
+				logger:                  microloggertest.New(),
+				volumeSnapshotRetention: 3,
+			}
+
+			c := controllercontext.Context{
+				Client: controllercontext.ContextClient{
+					TenantCluster: controllercontext.ContextClientTenantCluster{
+						AWS: awsclient.Clients{
+							EC2: ec2Client,
+						},
+					},
+				},
+			}
+			ctx := controllercontext.NewContext(context.Background(), c)
+
+			completed, err := r.snapshotVolumes(ctx, cr)
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+			if completed != tc.expectedCompleted {
+				t.Fatalf("expected completed to be %t got %t", tc.expectedCompleted, completed)
+			}
+			if len(ec2Client.createdSnapshots) != tc.expectedCreatedSnapshots {
+				t.Fatalf("expected %d created snapshots got %d", tc.expectedCreatedSnapshots, len(ec2Client.createdSnapshots))
+			}
+
+			updated, err := g8sClient.ProviderV1alpha1().AWSConfigs(cr.Namespace).Get(cr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected %#v got %#v", nil, err)
+			}
+
+			var ids []string
+			for _, s := range updated.Status.AWS.VolumeSnapshots {
+				ids = append(ids, s.SnapshotID)
+			}
+			if len(ids) != len(tc.expectedStatusSnapshotIDs) {
+				t.Fatalf("expected status snapshots %#v got %#v", tc.expectedStatusSnapshotIDs, ids)
+			}
+			for i := range ids {
+				if ids[i] != tc.expectedStatusSnapshotIDs[i] {
+					t.Fatalf("expected status snapshots %#v got %#v", tc.expectedStatusSnapshotIDs, ids)
+				}
+			}
+		})
+	}
+}
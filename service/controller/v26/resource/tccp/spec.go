@@ -3,4 +3,7 @@ package tccp
 type templateParams struct {
 	DockerVolumeResourceName   string
 	MasterInstanceResourceName string
+	// ImageID is the EC2 AMI the master and worker nodes are launched from. The
+	// image resolver decides about the EC2 AMI in case it is empty.
+	ImageID string
 }
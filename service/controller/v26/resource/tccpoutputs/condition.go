@@ -0,0 +1,182 @@
+package tccpoutputs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cf "github.com/giantswarm/aws-operator/service/controller/v26/cloudformation"
+	"github.com/giantswarm/aws-operator/service/controller/v26/key"
+)
+
+// ensureStackFailedCondition persists the reason of a failed tenant cluster
+// control plane stack as StackFailed condition in the CR status. This way the
+// root cause of broken provisioning is visible without access to the AWS
+// console. Once the stack recovered the condition is set to False. While the
+// stack is transitioning the condition is left untouched.
+func (r *Resource) ensureStackFailedCondition(ctx context.Context, cr v1alpha1.AWSConfig, cloudFormation *cf.CloudFormation, stackStatus string) error {
+	var condition v1alpha1.StatusClusterResourceCondition
+	{
+		if cf.IsFailedStackStatus(stackStatus) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "finding the failed resource of the tenant cluster cloud formation stack")
+
+			failure, err := cloudFormation.DescribeFailure(key.MainGuestStackName(cr))
+			if cf.IsFailedResourceNotFound(err) {
+				r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the failed resource of the tenant cluster cloud formation stack")
+
+			} else if err != nil {
+				return microerror.Mask(err)
+
+			} else {
+				r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found the failed resource %#q of the tenant cluster cloud formation stack", failure.LogicalResourceID))
+			}
+
+			condition = newStackFailedCondition(stackStatus, failure)
+
+		} else if isStableStackStatus(stackStatus) {
+			condition = v1alpha1.StatusClusterResourceCondition{
+				Status: v1alpha1.StatusClusterStatusFalse,
+				Type:   key.StatusConditionTypeStackFailed,
+			}
+
+		} else {
+			return nil
+		}
+	}
+
+	// The CR given to the resource is checked first in order to not fetch the
+	// latest version of the CR on every reconciliation loop.
+	_, updated := withResourceCondition(cr.Status.Cluster.Resources, key.StatusResourceNameTCCP, condition, time.Now())
+	if !updated {
+		return nil
+	}
+
+	var customObject v1alpha1.AWSConfig
+	{
+		newObj, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(cr.GetNamespace()).Get(cr.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+		customObject = *newObj
+	}
+
+	resources, updated := withResourceCondition(customObject.Status.Cluster.Resources, key.StatusResourceNameTCCP, condition, time.Now())
+	if !updated {
+		return nil
+	}
+
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("updating CR status condition %#q to %#q", condition.Type, condition.Status))
+
+		customObject.Status.Cluster.Resources = resources
+
+		_, err := r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.GetNamespace()).UpdateStatus(&customObject)
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("updated CR status condition %#q to %#q", condition.Type, condition.Status))
+	}
+
+	return nil
+}
+
+func isStableStackStatus(stackStatus string) bool {
+	return stackStatus == cloudformation.StackStatusCreateComplete || stackStatus == cloudformation.StackStatusUpdateComplete
+}
+
+// newStackFailedCondition returns a StackFailed condition with the stack
+// status as reason and the failed resource as message, e.g.
+//
+//     AWS::EC2::Instance MasterInstance0 CREATE_FAILED: The requested configuration is currently not supported.
+//
+func newStackFailedCondition(stackStatus string, failure cf.StackFailure) v1alpha1.StatusClusterResourceCondition {
+	message := fmt.Sprintf("stack has status %s", stackStatus)
+	if failure.LogicalResourceID != "" {
+		message = fmt.Sprintf("%s %s %s: %s", failure.ResourceType, failure.LogicalResourceID, failure.ResourceStatus, failure.ResourceStatusReason)
+	}
+
+	c := v1alpha1.StatusClusterResourceCondition{
+		Message: message,
+		Reason:  stackStatus,
+		Status:  v1alpha1.StatusClusterStatusTrue,
+		Type:    key.StatusConditionTypeStackFailed,
+	}
+
+	return c
+}
+
+// withResourceCondition returns the given resources with the condition set for
+// the resource of the given name. The returned boolean is false when the
+// resources already contain the condition, in which case they are returned
+// unchanged. A condition not being present at all is only added when its status
+// is True, so that healthy clusters do not get their status updated.
+func withResourceCondition(resources []v1alpha1.StatusClusterResource, name string, condition v1alpha1.StatusClusterResourceCondition, t time.Time) ([]v1alpha1.StatusClusterResource, bool) {
+	var newResources []v1alpha1.StatusClusterResource
+	var found bool
+	var updated bool
+
+	for _, r := range resources {
+		if r.Name != name {
+			newResources = append(newResources, r)
+			continue
+		}
+
+		var newConditions []v1alpha1.StatusClusterResourceCondition
+		for _, c := range r.Conditions {
+			if c.Type != condition.Type {
+				newConditions = append(newConditions, c)
+				continue
+			}
+
+			found = true
+
+			if c.Status == condition.Status && c.Reason == condition.Reason && c.Message == condition.Message {
+				newConditions = append(newConditions, c)
+				continue
+			}
+
+			n := condition
+			n.LastTransitionTime = c.LastTransitionTime
+			if c.Status != condition.Status {
+				n.LastTransitionTime = v1alpha1.DeepCopyTime{Time: t}
+			}
+
+			newConditions = append(newConditions, n)
+			updated = true
+		}
+
+		r.Conditions = newConditions
+		newResources = append(newResources, r)
+	}
+
+	if found {
+		return newResources, updated
+	}
+
+	if condition.Status != v1alpha1.StatusClusterStatusTrue {
+		return resources, false
+	}
+
+	n := condition
+	n.LastTransitionTime = v1alpha1.DeepCopyTime{Time: t}
+
+	for i, r := range newResources {
+		if r.Name == name {
+			newResources[i].Conditions = append(newResources[i].Conditions, n)
+			return newResources, true
+		}
+	}
+
+	newResources = append(newResources, v1alpha1.StatusClusterResource{
+		Conditions: []v1alpha1.StatusClusterResourceCondition{n},
+		Name:       name,
+	})
+
+	return newResources, true
+}
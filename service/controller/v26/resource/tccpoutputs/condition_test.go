@@ -0,0 +1,115 @@
+package tccpoutputs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+)
+
+func Test_withResourceCondition(t *testing.T) {
+	before := time.Unix(10, 0)
+	now := time.Unix(20, 0)
+
+	failed := v1alpha1.StatusClusterResourceCondition{
+		Message: "AWS::EC2::Instance MasterInstance0 CREATE_FAILED: The requested configuration is currently not supported.",
+		Reason:  "ROLLBACK_COMPLETE",
+		Status:  v1alpha1.StatusClusterStatusTrue,
+		Type:    "StackFailed",
+	}
+	recovered := v1alpha1.StatusClusterResourceCondition{
+		Status: v1alpha1.StatusClusterStatusFalse,
+		Type:   "StackFailed",
+	}
+	other := v1alpha1.StatusClusterResource{
+		Name: "other",
+		Conditions: []v1alpha1.StatusClusterResourceCondition{
+			{
+				Status: v1alpha1.StatusClusterStatusTrue,
+				Type:   "Other",
+			},
+		},
+	}
+
+	withTime := func(c v1alpha1.StatusClusterResourceCondition, t time.Time) v1alpha1.StatusClusterResourceCondition {
+		c.LastTransitionTime = v1alpha1.DeepCopyTime{Time: t}
+		return c
+	}
+
+	testCases := []struct {
+		name              string
+		resources         []v1alpha1.StatusClusterResource
+		condition         v1alpha1.StatusClusterResourceCondition
+		expectedResources []v1alpha1.StatusClusterResource
+		expectedUpdated   bool
+	}{
+		{
+			name:              "case 0: healthy stack without condition is not updated",
+			resources:         []v1alpha1.StatusClusterResource{other},
+			condition:         recovered,
+			expectedResources: []v1alpha1.StatusClusterResource{other},
+			expectedUpdated:   false,
+		},
+		{
+			name:      "case 1: failed stack adds the condition",
+			resources: []v1alpha1.StatusClusterResource{other},
+			condition: failed,
+			expectedResources: []v1alpha1.StatusClusterResource{
+				other,
+				{
+					Name:       "tccp",
+					Conditions: []v1alpha1.StatusClusterResourceCondition{withTime(failed, now)},
+				},
+			},
+			expectedUpdated: true,
+		},
+		{
+			name: "case 2: unchanged condition is not updated",
+			resources: []v1alpha1.StatusClusterResource{
+				{
+					Name:       "tccp",
+					Conditions: []v1alpha1.StatusClusterResourceCondition{withTime(failed, before)},
+				},
+			},
+			condition: failed,
+			expectedResources: []v1alpha1.StatusClusterResource{
+				{
+					Name:       "tccp",
+					Conditions: []v1alpha1.StatusClusterResourceCondition{withTime(failed, before)},
+				},
+			},
+			expectedUpdated: false,
+		},
+		{
+			name: "case 3: recovered stack flips the condition",
+			resources: []v1alpha1.StatusClusterResource{
+				{
+					Name:       "tccp",
+					Conditions: []v1alpha1.StatusClusterResourceCondition{withTime(failed, before)},
+				},
+			},
+			condition: recovered,
+			expectedResources: []v1alpha1.StatusClusterResource{
+				{
+					Name:       "tccp",
+					Conditions: []v1alpha1.StatusClusterResourceCondition{withTime(recovered, now)},
+				},
+			},
+			expectedUpdated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resources, updated := withResourceCondition(tc.resources, "tccp", tc.condition, now)
+
+			if updated != tc.expectedUpdated {
+				t.Fatalf("expected %t got %t", tc.expectedUpdated, updated)
+			}
+			if !reflect.DeepEqual(resources, tc.expectedResources) {
+				t.Fatalf("expected %#v got %#v", tc.expectedResources, resources)
+			}
+		})
+	}
+}
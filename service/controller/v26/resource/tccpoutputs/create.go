@@ -3,9 +3,13 @@ package tccpoutputs
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
 
 	"github.com/giantswarm/aws-operator/service/controller/v26/cloudformation"
@@ -14,10 +18,11 @@ import (
 )
 
 const (
-	HostedZoneNameServersKey  = "HostedZoneNameServers"
-	VPCIDKey                  = "VPCID"
-	VPCPeeringConnectionIDKey = "VPCPeeringConnectionID"
-	WorkerASGNameKey          = "WorkerASGName"
+	HostedZoneNameServersKey      = "HostedZoneNameServers"
+	TransitGatewayAttachmentIDKey = "TransitGatewayAttachmentID"
+	VPCIDKey                      = "VPCID"
+	VPCPeeringConnectionIDKey     = "VPCPeeringConnectionID"
+	WorkerASGNameKey              = "WorkerASGName"
 )
 
 func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
@@ -47,6 +52,10 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the tenant cluster cloud formation stack outputs")
 
 		o, s, err := cloudFormation.DescribeOutputsAndStatus(key.MainGuestStackName(cr))
+		if err == nil || cloudformation.IsOutputsNotAccessible(err) {
+			cc.Status.TenantCluster.TCCP.StackStatus = s
+		}
+
 		if cloudformation.IsStackNotFound(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster cloud formation stack outputs")
 			r.logger.LogCtx(ctx, "level", "debug", "message", "the tenant cluster cloud formation stack does not exist")
@@ -56,6 +65,12 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		} else if cloudformation.IsOutputsNotAccessible(err) {
 			r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster cloud formation stack outputs")
 			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("the tenant cluster main cloud formation stack output values are not accessible due to stack status %#q", s))
+
+			err = r.ensureStackFailedCondition(ctx, cr, cloudFormation, s)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 			cc.Status.TenantCluster.TCCP.IsTransitioning = true
 			return nil
@@ -67,6 +82,11 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		outputs = o
 
 		r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster cloud formation stack outputs")
+
+		err = r.ensureStackFailedCondition(ctx, cr, cloudFormation, s)
+		if err != nil {
+			return microerror.Mask(err)
+		}
 	}
 
 	{
@@ -85,6 +105,64 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		cc.Status.TenantCluster.HostedZoneNameServers = v
 	}
 
+	{
+		v, err := cloudFormation.GetOutputValue(outputs, key.MasterAvailabilityZonesKey)
+		if cloudformation.IsOutputNotFound(err) {
+			// Stacks created before availability zones could be added to tenant
+			// clusters do not have the output. The master placement is then
+			// derived from the existing subnets when computing the template.
+			cc.Status.TenantCluster.MasterInstance.AvailabilityZones = nil
+		} else if err != nil {
+			return microerror.Mask(err)
+		} else {
+			cc.Status.TenantCluster.MasterInstance.AvailabilityZones = strings.Split(v, ",")
+		}
+	}
+
+	{
+		v, err := cloudFormation.GetOutputValue(outputs, key.MasterEtcdRestoreSnapshotKey)
+		if cloudformation.IsOutputNotFound(err) {
+			// The output only exists when the master got restored from an etcd
+			// snapshot.
+			v = ""
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		cc.Status.TenantCluster.MasterInstance.EtcdRestoreSnapshot = v
+	}
+
+	{
+		v, err := cloudFormation.GetOutputValue(outputs, key.MasterDockerVolumeKey)
+		if cloudformation.IsOutputNotFound(err) {
+			// Stacks created before the master volumes became configurable do not
+			// have the output and always used the default volume configuration.
+			v = key.VolumeOutputValue(key.MasterDockerVolume(v1alpha1.AWSConfig{}))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		cc.Status.TenantCluster.MasterInstance.DockerVolume = v
+	}
+
+	{
+		v, err := cloudFormation.GetOutputValue(outputs, key.MasterEtcdVolumeKey)
+		if cloudformation.IsOutputNotFound(err) {
+			v = key.VolumeOutputValue(key.MasterEtcdVolume(v1alpha1.AWSConfig{}))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		cc.Status.TenantCluster.MasterInstance.EtcdVolume = v
+	}
+
+	{
+		v, err := cloudFormation.GetOutputValue(outputs, key.MasterLogVolumeKey)
+		if cloudformation.IsOutputNotFound(err) {
+			v = key.VolumeOutputValue(key.MasterLogVolume(v1alpha1.AWSConfig{}))
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+		cc.Status.TenantCluster.MasterInstance.LogVolume = v
+	}
+
 	{
 		v, err := cloudFormation.GetOutputValue(outputs, key.MasterImageIDKey)
 		if err != nil {
@@ -118,11 +196,11 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	}
 
 	{
-		v, err := cloudFormation.GetOutputValue(outputs, WorkerASGNameKey)
+		asgs, err := newNodePoolASGs(cloudFormation, outputs)
 		if err != nil {
 			return microerror.Mask(err)
 		}
-		cc.Status.TenantCluster.TCCP.ASG.Name = v
+		cc.Status.TenantCluster.TCCP.ASGs = asgs
 	}
 
 	{
@@ -154,7 +232,25 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		}
 	}
 
-	{
+	peered := r.transitGatewayID == ""
+
+	if r.transitGatewayID != "" {
+		v, err := cloudFormation.GetOutputValue(outputs, TransitGatewayAttachmentIDKey)
+		if cloudformation.IsOutputNotFound(err) {
+			// Tenant clusters created before the Transit Gateway was configured are
+			// still connected to the control plane using VPC peering. They keep
+			// their peering connection until the next update of the TCCP stack
+			// attaches them to the Transit Gateway. The detection service triggers
+			// this update as soon as the attachment ID is missing here.
+			peered = true
+		} else if err != nil {
+			return microerror.Mask(err)
+		} else {
+			cc.Status.TenantCluster.TCCP.VPC.TransitGatewayAttachmentID = v
+		}
+	}
+
+	if peered {
 		v, err := cloudFormation.GetOutputValue(outputs, VPCPeeringConnectionIDKey)
 		if cloudformation.IsOutputNotFound(err) {
 			// TODO this exception is necessary for clusters upgrading from v23 to
@@ -184,32 +280,141 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 	}
 
 	{
-		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerDockerVolumeSizeKey)
+		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerImageIDKey)
 		if err != nil {
 			return microerror.Mask(err)
 		}
-		cc.Status.TenantCluster.WorkerInstance.DockerVolumeSizeGB = v
+		cc.Status.TenantCluster.WorkerInstance.Image = v
 	}
 
 	{
-		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerImageIDKey)
-		if err != nil {
+		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerVolumeEncryptionKey)
+		if cloudformation.IsOutputNotFound(err) {
+			// Stacks created before the worker volumes got encrypted do not have
+			// the output.
+			v = ""
+		} else if err != nil {
 			return microerror.Mask(err)
 		}
-		cc.Status.TenantCluster.WorkerInstance.Image = v
+		cc.Status.TenantCluster.WorkerInstance.VolumeEncryption = v
 	}
 
 	{
-		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerInstanceTypeKey)
-		if err != nil {
+		v, err := cloudFormation.GetOutputValue(outputs, key.WorkerVolumeTypeKey)
+		if cloudformation.IsOutputNotFound(err) {
+			v = key.VolumeTypeGP2
+		} else if err != nil {
 			return microerror.Mask(err)
 		}
-		cc.Status.TenantCluster.WorkerInstance.Type = v
+		cc.Status.TenantCluster.WorkerInstance.VolumeType = v
 	}
 
 	return nil
 }
 
+// newNodePoolASGs collects the worker ASGs of all node pools the tenant
+// cluster's main stack provides outputs for. The outputs of a node pool are
+// suffixed with the node pool's name as defined by key.NodePoolResourceName.
+// The ASGs are sorted by node pool name so that the unnamed node pool comes
+// first.
+func newNodePoolASGs(cloudFormation *cloudformation.CloudFormation, outputs []cloudformation.Output) ([]controllercontext.ContextStatusTenantClusterTCCPASG, error) {
+	var asgs []controllercontext.ContextStatusTenantClusterTCCPASG
+
+	for _, o := range outputs {
+		if !strings.HasPrefix(o.OutputKey, WorkerASGNameKey) {
+			continue
+		}
+
+		asg := controllercontext.ContextStatusTenantClusterTCCPASG{
+			Name:     o.OutputValue,
+			NodePool: strings.ToLower(strings.TrimPrefix(o.OutputKey, WorkerASGNameKey)),
+		}
+
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerDockerVolumeSizeKey, asg.NodePool))
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			asg.DockerVolumeSizeGB = v
+		}
+
+		// Stacks created before the kubelet volume size became configurable do
+		// not provide the kubelet volume size outputs. Their kubelet volumes
+		// always have the size of the docker volumes.
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerKubeletVolumeSizeKey, asg.NodePool))
+			if cloudformation.IsOutputNotFound(err) {
+				asg.KubeletVolumeSizeGB = asg.DockerVolumeSizeGB
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			} else {
+				asg.KubeletVolumeSizeGB = v
+			}
+		}
+
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerInstanceTypeKey, asg.NodePool))
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+			asg.InstanceType = v
+		}
+
+		// Stacks created before the worker auto scaling group used a launch
+		// template do not provide the instance distribution outputs. Their
+		// workers are on-demand instances of the single worker instance type,
+		// which is what we put into the controller context in such cases.
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerInstanceTypesKey, asg.NodePool))
+			if cloudformation.IsOutputNotFound(err) {
+				asg.InstanceTypes = []string{asg.InstanceType}
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			} else {
+				asg.InstanceTypes = strings.Split(v, ",")
+			}
+		}
+
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerOnDemandBaseCapacityKey, asg.NodePool))
+			if cloudformation.IsOutputNotFound(err) {
+				asg.OnDemandBaseCapacity = 0
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			} else {
+				i, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, microerror.Mask(err)
+				}
+				asg.OnDemandBaseCapacity = i
+			}
+		}
+
+		{
+			v, err := cloudFormation.GetOutputValue(outputs, key.NodePoolResourceName(key.WorkerSpotPercentageKey, asg.NodePool))
+			if cloudformation.IsOutputNotFound(err) {
+				asg.SpotPercentage = 0
+			} else if err != nil {
+				return nil, microerror.Mask(err)
+			} else {
+				i, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, microerror.Mask(err)
+				}
+				asg.SpotPercentage = i
+			}
+		}
+
+		asgs = append(asgs, asg)
+	}
+
+	sort.Slice(asgs, func(i, j int) bool {
+		return asgs[i].NodePool < asgs[j].NodePool
+	})
+
+	return asgs, nil
+}
+
 func searchPeeringConnectionID(client EC2, clusterID string) (string, error) {
 	var peeringID string
 	{
@@ -1,6 +1,7 @@
 package tccpoutputs
 
 import (
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
 	"github.com/giantswarm/microerror"
 	"github.com/giantswarm/micrologger"
 )
@@ -10,33 +11,43 @@ const (
 )
 
 type Config struct {
-	Logger micrologger.Logger
+	G8sClient versioned.Interface
+	Logger    micrologger.Logger
 
-	Route53Enabled bool
+	Route53Enabled   bool
+	TransitGatewayID string
 }
 
 // Resource implements an operatorkit resource and provides a mechanism to fetch
 // information from Cloud Formation stack outputs of the Tenant Cluster Control
 // Plane stack.
 //
-// The TCCP manages the VPC Peering Connection. The peering connection ID is
-// added to the controller context and used in the CPF stack.
+// The TCCP manages the VPC Peering Connection or, in case a Transit Gateway is
+// configured, the Transit Gateway Attachment. Their IDs are added to the
+// controller context and used in the CPF stack.
 //
 type Resource struct {
-	logger micrologger.Logger
+	g8sClient versioned.Interface
+	logger    micrologger.Logger
 
-	route53Enabled bool
+	route53Enabled   bool
+	transitGatewayID string
 }
 
 func New(config Config) (*Resource, error) {
+	if config.G8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
+	}
 	if config.Logger == nil {
 		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
 	}
 
 	r := &Resource{
-		logger: config.Logger,
+		g8sClient: config.G8sClient,
+		logger:    config.Logger,
 
-		route53Enabled: config.Route53Enabled,
+		route53Enabled:   config.Route53Enabled,
+		transitGatewayID: config.TransitGatewayID,
 	}
 
 	return r, nil
@@ -2,6 +2,8 @@ package workerasgname
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 
 	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
 	"github.com/giantswarm/microerror"
@@ -36,24 +38,43 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 		r.logger.LogCtx(ctx, "level", "debug", "message", "found latest version of custom resource")
 	}
 
-	{
-		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the tenant cluster worker ASG name in the CR")
+	// The worker ASG names are persisted in the CR status so that the drainer
+	// can do its job even while the tenant cluster's main stack outputs are not
+	// accessible, e.g. during stack updates.
+	if len(cc.Status.TenantCluster.TCCP.ASGs) == 0 {
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding the tenant cluster worker ASG names in the CR")
 
-		if customObject.Status.AWS.AutoScalingGroup.Name != "" {
-			cc.Status.TenantCluster.TCCP.ASG.Name = customObject.Status.AWS.AutoScalingGroup.Name
+		asgs := asgsFromStatus(customObject)
+		if len(asgs) != 0 {
+			cc.Status.TenantCluster.TCCP.ASGs = asgs
 
-			r.logger.LogCtx(ctx, "level", "debug", "message", "found the tenant cluster worker ASG name in the CR")
+			r.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("found %d tenant cluster worker ASG names in the CR", len(asgs)))
 			r.logger.LogCtx(ctx, "level", "debug", "message", "canceling resource")
 			return nil
 		}
 
-		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster worker ASG name in the CR")
+		r.logger.LogCtx(ctx, "level", "debug", "message", "did not find the tenant cluster worker ASG names in the CR")
+		return nil
 	}
 
-	if cc.Status.TenantCluster.TCCP.ASG.Name != "" {
+	{
+		r.logger.LogCtx(ctx, "level", "debug", "message", "finding out if the CR status has to be updated")
+
+		statusASGs := asgsToStatus(cc.Status.TenantCluster.TCCP.ASGs)
+		if reflect.DeepEqual(customObject.Status.AWS.AutoScalingGroups, statusASGs) {
+			r.logger.LogCtx(ctx, "level", "debug", "message", "CR status does not have to be updated")
+			return nil
+		}
+
+		r.logger.LogCtx(ctx, "level", "debug", "message", "CR status has to be updated")
 		r.logger.LogCtx(ctx, "level", "debug", "message", "updating CR status")
 
-		customObject.Status.AWS.AutoScalingGroup.Name = cc.Status.TenantCluster.TCCP.ASG.Name
+		customObject.Status.AWS.AutoScalingGroups = statusASGs
+		for _, a := range statusASGs {
+			if a.NodePool == "" {
+				customObject.Status.AWS.AutoScalingGroup.Name = a.Name
+			}
+		}
 
 		_, err = r.g8sClient.ProviderV1alpha1().AWSConfigs(customObject.Namespace).UpdateStatus(&customObject)
 		if err != nil {
@@ -67,3 +88,38 @@ func (r *Resource) EnsureCreated(ctx context.Context, obj interface{}) error {
 
 	return nil
 }
+
+// asgsFromStatus returns the worker ASGs persisted in the CR status. CRs of
+// tenant clusters created before node pools existed only hold the name of the
+// unnamed node pool's ASG.
+func asgsFromStatus(customObject v1alpha1.AWSConfig) []controllercontext.ContextStatusTenantClusterTCCPASG {
+	var asgs []controllercontext.ContextStatusTenantClusterTCCPASG
+
+	for _, a := range customObject.Status.AWS.AutoScalingGroups {
+		asgs = append(asgs, controllercontext.ContextStatusTenantClusterTCCPASG{
+			Name:     a.Name,
+			NodePool: a.NodePool,
+		})
+	}
+
+	if len(asgs) == 0 && customObject.Status.AWS.AutoScalingGroup.Name != "" {
+		asgs = append(asgs, controllercontext.ContextStatusTenantClusterTCCPASG{
+			Name: customObject.Status.AWS.AutoScalingGroup.Name,
+		})
+	}
+
+	return asgs
+}
+
+func asgsToStatus(asgs []controllercontext.ContextStatusTenantClusterTCCPASG) []v1alpha1.AWSConfigStatusAWSAutoScalingGroup {
+	var statusASGs []v1alpha1.AWSConfigStatusAWSAutoScalingGroup
+
+	for _, a := range asgs {
+		statusASGs = append(statusASGs, v1alpha1.AWSConfigStatusAWSAutoScalingGroup{
+			Name:     a.Name,
+			NodePool: a.NodePool,
+		})
+	}
+
+	return statusASGs
+}
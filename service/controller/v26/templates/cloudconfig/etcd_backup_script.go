@@ -0,0 +1,38 @@
+package cloudconfig
+
+const EtcdBackupScript = `#!/bin/bash -e
+snapshot=etcd-backup-$(date -u +%Y%m%dT%H%M%SZ).db
+backup_dir=/var/lib/etcd-backup
+
+mkdir -p $backup_dir
+trap "rm -f $backup_dir/$snapshot" EXIT
+
+echo creating etcd snapshot $snapshot
+docker run --rm \
+  -v /etc/kubernetes/ssl/etcd/:/etc/etcd \
+  -v $backup_dir:/backup \
+  --net=host \
+  -e ETCDCTL_API=3 \
+  {{ .EtcdImage }} \
+  etcdctl \
+  --endpoints https://127.0.0.1:2379 \
+  --cacert /etc/etcd/server-ca.pem \
+  --cert /etc/etcd/server-crt.pem \
+  --key /etc/etcd/server-key.pem \
+  snapshot save /backup/$snapshot
+
+echo uploading etcd snapshot $snapshot
+rkt run \
+  --volume=backup,kind=host,source=$backup_dir,readOnly=true \
+  --mount=volume=backup,target=/backup \
+  --volume=dns,kind=host,source=/etc/resolv.conf,readOnly=true --mount volume=dns,target=/etc/resolv.conf \
+  --net=host \
+  --trust-keys-from-https \
+  quay.io/coreos/awscli:025a357f05242fdad6a81e8a6b520098aa65a600 --exec=/usr/bin/aws -- \
+    --region {{ .AWS.Region }} s3 cp \
+    /backup/$snapshot \
+    s3://{{ .EtcdBackup.Bucket }}/{{ .EtcdBackup.Prefix }}$snapshot \
+    --sse aws:kms {{ if eq .EncrypterType "kms" }}--sse-kms-key-id {{ .EncryptionKey }}{{ end }}
+
+echo done.
+`
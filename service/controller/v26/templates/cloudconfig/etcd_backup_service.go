@@ -0,0 +1,26 @@
+package cloudconfig
+
+const EtcdBackupService = `
+[Unit]
+Description=etcd backup to S3
+After=docker.service etcd3.service
+Requires=docker.service etcd3.service
+
+[Service]
+Type=oneshot
+ExecStart=/opt/bin/etcd-backup
+
+[Install]
+WantedBy=multi-user.target
+`
+
+const EtcdBackupTimer = `
+[Unit]
+Description=Execute etcd-backup every 6 hours
+
+[Timer]
+OnCalendar=*-*-* 00/6:00:00 UTC
+
+[Install]
+WantedBy=multi-user.target
+`
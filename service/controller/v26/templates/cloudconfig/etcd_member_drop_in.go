@@ -0,0 +1,38 @@
+package cloudconfig
+
+// EtcdMemberDropIn overrides the etcd3 unit of k8scloudconfig on masters of
+// multi master tenant clusters. k8scloudconfig configures a single member etcd
+// cluster, so the member name, the advertised peer URL and the initial cluster
+// are replaced with the ones of the etcd member running on the master. The
+// other flags match the etcd3 unit of k8scloudconfig.
+const EtcdMemberDropIn = `
+[Service]
+EnvironmentFile={{ .EtcdMember.EnvironmentFile }}
+ExecStart=
+ExecStart=/usr/bin/docker run \
+    -v /etc/ssl/certs/ca-certificates.crt:/etc/ssl/certs/ca-certificates.crt \
+    -v /etc/kubernetes/ssl/etcd/:/etc/etcd \
+    -v /var/lib/etcd/:/var/lib/etcd  \
+    --net=host  \
+    --name $NAME \
+    $IMAGE \
+    etcd \
+    --name {{ .EtcdMember.Name }} \
+    --trusted-ca-file /etc/etcd/server-ca.pem \
+    --cert-file /etc/etcd/server-crt.pem \
+    --key-file /etc/etcd/server-key.pem\
+    --client-cert-auth=true \
+    --peer-trusted-ca-file /etc/etcd/server-ca.pem \
+    --peer-cert-file /etc/etcd/server-crt.pem \
+    --peer-key-file /etc/etcd/server-key.pem \
+    --peer-client-cert-auth=true \
+    --advertise-client-urls=https://{{ .Cluster.Etcd.Domain }}:{{ .Cluster.Etcd.Port }} \
+    --initial-advertise-peer-urls={{ .EtcdMember.PeerURL }} \
+    --listen-client-urls=https://0.0.0.0:2379 \
+    --listen-peer-urls=https://${DEFAULT_IPV4}:2380 \
+    --initial-cluster-token k8s-etcd-cluster \
+    --initial-cluster {{ .EtcdMember.InitialCluster }} \
+    --initial-cluster-state new \
+    --data-dir=/var/lib/etcd \
+    --enable-v2
+`
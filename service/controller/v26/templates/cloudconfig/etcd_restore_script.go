@@ -0,0 +1,59 @@
+package cloudconfig
+
+// EtcdRestoreScript restores the etcd snapshot into the etcd member running on
+// the master. Every master of a multi master tenant cluster restores the same
+// snapshot with its own member name and peer URL and the full member list, so
+// that the restored members form a new cluster holding the snapshot's data.
+const EtcdRestoreScript = `#!/bin/bash -e
+{{- if .EtcdBackup.RestoreSnapshot }}
+snapshot="{{ .EtcdBackup.RestoreSnapshot }}"
+restore_dir=/var/lib/etcd-restore
+marker=/var/lib/etcd/.restored-$snapshot
+{{- if .EtcdMember.EnvironmentFile }}
+
+. {{ .EtcdMember.EnvironmentFile }}
+{{- end }}
+
+if [ -f "$marker" ]; then
+  echo etcd snapshot $snapshot already restored
+  exit 0
+fi
+
+mkdir -p $restore_dir
+trap "rm -rf $restore_dir" EXIT
+
+echo downloading etcd snapshot $snapshot
+rkt run \
+  --volume=restore,kind=host,source=$restore_dir,readOnly=false \
+  --mount=volume=restore,target=/restore \
+  --volume=dns,kind=host,source=/etc/resolv.conf,readOnly=true --mount volume=dns,target=/etc/resolv.conf \
+  --net=host \
+  --trust-keys-from-https \
+  quay.io/coreos/awscli:025a357f05242fdad6a81e8a6b520098aa65a600 --exec=/usr/bin/aws -- \
+    --region {{ .AWS.Region }} s3 cp \
+    "s3://{{ .EtcdBackup.Bucket }}/{{ .EtcdBackup.Prefix }}$snapshot" \
+    /restore/snapshot.db
+
+echo restoring etcd snapshot $snapshot
+docker run --rm \
+  -v $restore_dir:/restore \
+  -e ETCDCTL_API=3 \
+  {{ .EtcdImage }} \
+  etcdctl snapshot restore /restore/snapshot.db \
+  --name {{ .EtcdMember.Name }} \
+  --initial-cluster {{ .EtcdMember.InitialCluster }} \
+  --initial-cluster-token k8s-etcd-cluster \
+  --initial-advertise-peer-urls {{ .EtcdMember.PeerURL }} \
+  --data-dir /restore/data
+
+if [ -d /var/lib/etcd/member ]; then
+  mv /var/lib/etcd/member /var/lib/etcd/member.bak-$(date -u +%Y%m%dT%H%M%SZ)
+fi
+mv $restore_dir/data/member /var/lib/etcd/member
+touch "$marker"
+
+echo done.
+{{- else }}
+echo no etcd snapshot to restore
+{{- end }}
+`
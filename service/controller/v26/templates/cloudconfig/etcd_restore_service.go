@@ -0,0 +1,17 @@
+package cloudconfig
+
+const EtcdRestoreService = `
+[Unit]
+Description=etcd restore from S3
+After=docker.service var-lib-etcd.mount
+Requires=docker.service var-lib-etcd.mount
+Before=etcd3.service
+
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/opt/bin/etcd-restore
+
+[Install]
+WantedBy=multi-user.target
+`
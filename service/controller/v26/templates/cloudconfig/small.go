@@ -51,7 +51,18 @@ const Small = `{
         }
       }
     {{- end }}
+    ]{{ if .EtcdMemberEnvironment }},
+    "files": [
+      {
+        "filesystem": "root",
+        "path": "{{ .EtcdMemberEnvironmentFile }}",
+        "mode": 420,
+        "contents": {
+          "source": "data:text/plain;charset=utf-8;base64,{{ .EtcdMemberEnvironment }}"
+        }
+      }
     ]
+    {{- end }}
   }
 }
 `
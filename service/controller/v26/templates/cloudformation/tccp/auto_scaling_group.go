@@ -2,8 +2,8 @@ package tccp
 
 const AutoScalingGroup = `
 {{define "autoscaling_group"}}
-{{- $v := .Guest.AutoScalingGroup }}
-  {{ $v.ASGType }}AutoScalingGroup:
+{{- range $v := .Guest.AutoScalingGroup.NodePools }}
+  {{ $v.ResourceName }}:
     Type: "AWS::AutoScaling::AutoScalingGroup"
     Properties:
       VPCZoneIdentifier:
@@ -17,9 +17,28 @@ const AutoScalingGroup = `
       DesiredCapacity: {{ $v.ASGDesiredCapacity }}
       MinSize: {{ $v.ASGMinSize }}
       MaxSize: {{ $v.ASGMaxSize }}
-      LaunchConfigurationName: !Ref {{ $v.ASGType }}LaunchConfiguration
+      MixedInstancesPolicy:
+        InstancesDistribution:
+          OnDemandBaseCapacity: {{ $v.OnDemandBaseCapacity }}
+          OnDemandPercentageAboveBaseCapacity: {{ $v.OnDemandPercentageAboveBaseCapacity }}
+          SpotAllocationStrategy: lowest-price
+        LaunchTemplate:
+          LaunchTemplateSpecification:
+            LaunchTemplateId: !Ref {{ $v.LaunchTemplateResourceName }}
+            Version: !GetAtt {{ $v.LaunchTemplateResourceName }}.LatestVersionNumber
+          Overrides:
+          {{- range $t := $v.InstanceTypes }}
+          - InstanceType: {{ $t }}
+          {{- end }}
+      {{- if $v.IngressTargetGroups }}
+      TargetGroupARNs:
+      {{- range $g := $v.IngressTargetGroups }}
+        - !Ref {{ $g }}
+      {{- end }}
+      {{- else }}
       LoadBalancerNames:
         - !Ref IngressLoadBalancer
+      {{- end }}
       HealthCheckGracePeriod: {{ $v.HealthCheckGracePeriod }}
       MetricsCollection:
         - Granularity: "1Minute"
@@ -41,5 +60,6 @@ const AutoScalingGroup = `
         MaxBatchSize: {{ $v.MaxBatchSize }}
         # after creating a new instance, pause operations on the ASG for this amount of time
         PauseTime: {{ $v.RollingUpdatePauseTime }}
+{{- end }}
 {{end}}
 `
@@ -0,0 +1,19 @@
+package tccp
+
+const ExistingVPC = `
+{{define "existing_vpc"}}
+{{- $v := .Guest.ExistingVPC }}
+{{- if $v.ID }}
+  VPC:
+    Type: AWS::EC2::VPC::Id
+    Description: Existing VPC the tenant cluster is deployed into.
+    Default: {{ $v.ID }}
+  {{- range $v.Subnets }}
+  {{ .Name }}:
+    Type: AWS::EC2::Subnet::Id
+    Description: Existing subnet the tenant cluster is deployed into.
+    Default: {{ .ID }}
+  {{- end }}
+{{- end }}
+{{end}}
+`
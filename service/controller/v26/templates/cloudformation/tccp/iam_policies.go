@@ -28,7 +28,9 @@ const IAMPolicies = `
             Resource: "*"
 {{ if $v.KMSKeyARN }}
           - Effect: "Allow"
-            Action: "kms:Decrypt"
+            Action:
+              - "kms:Decrypt"
+              - "kms:GenerateDataKey"
             Resource: "{{ $v.KMSKeyARN }}"
 {{ end }}
           - Effect: "Allow"
@@ -45,6 +47,10 @@ const IAMPolicies = `
             Action: "s3:GetObject"
             Resource: "arn:{{ $v.RegionARN }}:s3:::{{ $v.S3Bucket }}/*"
 
+          - Effect: "Allow"
+            Action: "s3:PutObject"
+            Resource: "arn:{{ $v.RegionARN }}:s3:::{{ $v.S3Bucket }}/{{ $v.EtcdBackupPrefix }}*"
+
           - Effect: "Allow"
             Action: "elasticloadbalancing:*"
             Resource: "*"
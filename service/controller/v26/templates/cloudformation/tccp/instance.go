@@ -3,79 +3,99 @@ package tccp
 const Instance = `
 {{ define "instance" }}
 {{- $v := .Guest.Instance }}
-  {{ $v.Master.Instance.ResourceName }}:
+{{- range $m := $v.Masters }}
+  {{ $m.Instance.ResourceName }}:
     Type: "AWS::EC2::Instance"
     Description: Master instance
     DependsOn:
-    - {{ $v.Master.DockerVolume.ResourceName }}
-    - EtcdVolume
+    - {{ $m.DockerVolume.ResourceName }}
+    - {{ $m.EtcdVolume.ResourceName }}
     Properties:
-      AvailabilityZone: {{ $v.Master.AZ }}
+      AvailabilityZone: {{ $m.AZ }}
       DisableApiTermination: true
       IamInstanceProfile: !Ref MasterInstanceProfile
       ImageId: {{ $v.Image.ID }}
-      InstanceType: {{ $v.Master.Instance.Type }}
-      Monitoring: {{ $v.Master.Instance.Monitoring }}
+      InstanceType: {{ $m.Instance.Type }}
+      Monitoring: {{ $m.Instance.Monitoring }}
       SecurityGroupIds:
       - !Ref MasterSecurityGroup
-      SubnetId: !Ref {{ $v.Master.PrivateSubnet }}
-      UserData: {{ $v.Master.CloudConfig }}
+      SubnetId: !Ref {{ $m.PrivateSubnet }}
+      UserData: {{ $m.CloudConfig }}
       Tags:
       - Key: Name
-        Value: {{ $v.Cluster.ID }}-master
-  {{ $v.Master.DockerVolume.ResourceName }}:
+        Value: {{ $m.Instance.Name }}
+  {{ $m.DockerVolume.ResourceName }}:
     Type: AWS::EC2::Volume
     Properties:
-{{ if eq $v.Master.EncrypterBackend "kms" }}
+{{ if eq $m.EncrypterBackend "kms" }}
       Encrypted: true
 {{ end }}
-      Size: 50
-      VolumeType: gp2
-      AvailabilityZone: {{ $v.Master.AZ }}
+      Size: {{ $m.DockerVolume.SizeGB }}
+      VolumeType: {{ $m.DockerVolume.Type }}
+{{- if $m.DockerVolume.IOPS }}
+      Iops: {{ $m.DockerVolume.IOPS }}
+{{- end }}
+{{- if $m.DockerVolume.Throughput }}
+      Throughput: {{ $m.DockerVolume.Throughput }}
+{{- end }}
+      AvailabilityZone: {{ $m.AZ }}
       Tags:
       - Key: Name
-        Value: {{ $v.Master.DockerVolume.Name }}
-  EtcdVolume:
+        Value: {{ $m.DockerVolume.Name }}
+  {{ $m.EtcdVolume.ResourceName }}:
     Type: AWS::EC2::Volume
     Properties:
-{{ if eq $v.Master.EncrypterBackend "kms" }}
+{{ if eq $m.EncrypterBackend "kms" }}
       Encrypted: true
 {{ end }}
-      Size: 100
-      VolumeType: gp2
-      AvailabilityZone: {{ $v.Master.AZ }}
+      Size: {{ $m.EtcdVolume.SizeGB }}
+      VolumeType: {{ $m.EtcdVolume.Type }}
+{{- if $m.EtcdVolume.IOPS }}
+      Iops: {{ $m.EtcdVolume.IOPS }}
+{{- end }}
+{{- if $m.EtcdVolume.Throughput }}
+      Throughput: {{ $m.EtcdVolume.Throughput }}
+{{- end }}
+      AvailabilityZone: {{ $m.AZ }}
       Tags:
       - Key: Name
-        Value: {{ $v.Master.EtcdVolume.Name }}
-  LogVolume:
+        Value: {{ $m.EtcdVolume.Name }}
+  {{ $m.LogVolume.ResourceName }}:
     Type: AWS::EC2::Volume
     Properties:
-{{ if eq $v.Master.EncrypterBackend "kms" }}
+{{ if eq $m.EncrypterBackend "kms" }}
       Encrypted: true
 {{ end }}
-      Size: 100
-      VolumeType: gp2
-      AvailabilityZone: {{ $v.Master.AZ }}
+      Size: {{ $m.LogVolume.SizeGB }}
+      VolumeType: {{ $m.LogVolume.Type }}
+{{- if $m.LogVolume.IOPS }}
+      Iops: {{ $m.LogVolume.IOPS }}
+{{- end }}
+{{- if $m.LogVolume.Throughput }}
+      Throughput: {{ $m.LogVolume.Throughput }}
+{{- end }}
+      AvailabilityZone: {{ $m.AZ }}
       Tags:
       - Key: Name
-        Value: {{ $v.Master.LogVolume.Name }}
-  {{ $v.Master.Instance.ResourceName }}DockerMountPoint:
+        Value: {{ $m.LogVolume.Name }}
+  {{ $m.Instance.ResourceName }}DockerMountPoint:
     Type: AWS::EC2::VolumeAttachment
     Properties:
-      InstanceId: !Ref {{ $v.Master.Instance.ResourceName }}
-      VolumeId: !Ref {{ $v.Master.DockerVolume.ResourceName }}
+      InstanceId: !Ref {{ $m.Instance.ResourceName }}
+      VolumeId: !Ref {{ $m.DockerVolume.ResourceName }}
       Device: /dev/xvdc
-  {{ $v.Master.Instance.ResourceName }}EtcdMountPoint:
+  {{ $m.Instance.ResourceName }}EtcdMountPoint:
     Type: AWS::EC2::VolumeAttachment
     Properties:
-      InstanceId: !Ref {{ $v.Master.Instance.ResourceName }}
-      VolumeId: !Ref EtcdVolume
+      InstanceId: !Ref {{ $m.Instance.ResourceName }}
+      VolumeId: !Ref {{ $m.EtcdVolume.ResourceName }}
       Device: /dev/xvdh
-  {{ $v.Master.Instance.ResourceName }}LogMountPoint:
+  {{ $m.Instance.ResourceName }}LogMountPoint:
     Type: AWS::EC2::VolumeAttachment
     Properties:
-      InstanceId: !Ref {{ $v.Master.Instance.ResourceName }}
-      VolumeId: !Ref LogVolume
+      InstanceId: !Ref {{ $m.Instance.ResourceName }}
+      VolumeId: !Ref {{ $m.LogVolume.ResourceName }}
       Device: /dev/xvdf
+{{- end }}
 {{ end }}
 `
@@ -3,6 +3,7 @@ package tccp
 const InternetGateway = `
 {{define "internet_gateway"}}
 {{- $v := .Guest.InternetGateway }}
+{{- if not .Guest.ExistingVPC.ID }}
   InternetGateway:
     Type: AWS::EC2::InternetGateway
     Properties:
@@ -31,5 +32,6 @@ const InternetGateway = `
       DestinationCidrBlock: 0.0.0.0/0
       GatewayId:
         Ref: InternetGateway
+{{- end }}
 {{end}}
 `
@@ -0,0 +1,39 @@
+package tccp
+
+const LaunchTemplate = `
+{{define "launch_template"}}
+{{- range $v := .Guest.LaunchTemplate.NodePools }}
+  {{ $v.ResourceName }}:
+    Type: "AWS::EC2::LaunchTemplate"
+    Properties:
+      LaunchTemplateName: {{ $v.ClusterID }}-{{ $v.ASGType }}
+      LaunchTemplateData:
+        BlockDeviceMappings:
+        {{ range $v.WorkerBlockDeviceMappings }}
+        - DeviceName: "{{ .DeviceName }}"
+          Ebs:
+            DeleteOnTermination: {{ .DeleteOnTermination }}
+            Encrypted: {{ .Encrypted }}
+            {{- if .KMSKeyARN }}
+            KmsKeyId: {{ .KMSKeyARN }}
+            {{- end }}
+            {{- if .VolumeSize }}
+            VolumeSize: {{ .VolumeSize }}
+            {{- end }}
+            VolumeType: {{ .VolumeType }}
+        {{ end }}
+        IamInstanceProfile:
+          Name: !Ref WorkerInstanceProfile
+        ImageId: {{ $v.WorkerImageID }}
+        InstanceType: {{ $v.WorkerInstanceType }}
+        Monitoring:
+          Enabled: {{ $v.WorkerInstanceMonitoring }}
+        NetworkInterfaces:
+        - AssociatePublicIpAddress: {{ $v.WorkerAssociatePublicIPAddress }}
+          DeviceIndex: 0
+          Groups:
+          - !Ref WorkerSecurityGroup
+        UserData: {{ $v.WorkerSmallCloudConfig }}
+{{- end }}
+{{end}}
+`
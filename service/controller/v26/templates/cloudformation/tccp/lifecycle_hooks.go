@@ -2,15 +2,16 @@ package tccp
 
 const LifecycleHooks = `
 {{ define "lifecycle_hooks" }}
-{{- $v := .Guest.LifecycleHooks }}
-  {{ $v.Worker.LifecycleHook.Name }}LifecycleHook:
+{{- range $v := .Guest.LifecycleHooks.Workers }}
+  {{ $v.LifecycleHook.ResourceName }}:
     Type: "AWS::AutoScaling::LifecycleHook"
     Properties:
       AutoScalingGroupName:
-        Ref: {{ $v.Worker.ASG.Ref }}
+        Ref: {{ $v.ASG.Ref }}
       DefaultResult: CONTINUE
       HeartbeatTimeout: 3600
-      LifecycleHookName: {{ $v.Worker.LifecycleHook.Name }}
+      LifecycleHookName: {{ $v.LifecycleHook.Name }}
       LifecycleTransition: "autoscaling:EC2_INSTANCE_TERMINATING"
+{{- end }}
 {{ end }}
 `
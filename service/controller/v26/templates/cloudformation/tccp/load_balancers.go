@@ -3,10 +3,140 @@ package tccp
 const LoadBalancers = `
 {{define "load_balancers"}}
 {{- $v := .Guest.LoadBalancers }}
+{{- if $v.IsNetworkLoadBalancer }}
+  ApiNetworkLoadBalancer:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+    {{- if not $.Guest.ExistingVPC.ID }}
+    DependsOn:
+      - VPCGatewayAttachment
+    {{- end }}
+    Properties:
+      LoadBalancerAttributes:
+      - Key: load_balancing.cross_zone.enabled
+        Value: "true"
+      Name: {{ $v.APIElbName }}
+      Scheme: {{ $v.APIElbScheme }}
+      Subnets:
+      {{- range $s := $v.PublicSubnets }}
+        - !Ref {{ $s }}
+      {{- end }}
+      Type: network
+  {{- range $v.APIElbPortsToOpen }}
+  {{ .TargetGroupResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::TargetGroup
+    Properties:
+      HealthCheckIntervalSeconds: {{ $v.NLBHealthCheckInterval }}
+      HealthCheckProtocol: TCP
+      HealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      Port: {{ .PortInstance }}
+      Protocol: TCP
+      TargetType: instance
+      Targets:
+      {{- range $i := $v.MasterInstanceResourceNames }}
+      - Id: !Ref {{ $i }}
+      {{- end }}
+      UnhealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      VpcId: !Ref VPC
+  {{ .ListenerResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::Listener
+    Properties:
+      DefaultActions:
+      - TargetGroupArn: !Ref {{ .TargetGroupResourceName }}
+        Type: forward
+      LoadBalancerArn: !Ref ApiNetworkLoadBalancer
+      Port: {{ .PortELB }}
+      Protocol: TCP
+  {{- end }}
+
+  EtcdNetworkLoadBalancer:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+    Properties:
+      LoadBalancerAttributes:
+      - Key: load_balancing.cross_zone.enabled
+        Value: "true"
+      Name: {{ $v.EtcdElbName }}
+      Scheme: {{ $v.EtcdElbScheme }}
+      Subnets:
+      {{- range $s := $v.PrivateSubnets }}
+        - !Ref {{ $s }}
+      {{- end }}
+      Type: network
+  {{- range $v.EtcdElbPortsToOpen }}
+  {{ .TargetGroupResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::TargetGroup
+    Properties:
+      HealthCheckIntervalSeconds: {{ $v.NLBHealthCheckInterval }}
+      HealthCheckProtocol: TCP
+      HealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      Port: {{ .PortInstance }}
+      Protocol: TCP
+      TargetType: instance
+      Targets:
+      {{- range $i := $v.MasterInstanceResourceNames }}
+      - Id: !Ref {{ $i }}
+      {{- end }}
+      UnhealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      VpcId: !Ref VPC
+  {{ .ListenerResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::Listener
+    Properties:
+      DefaultActions:
+      - TargetGroupArn: !Ref {{ .TargetGroupResourceName }}
+        Type: forward
+      LoadBalancerArn: !Ref EtcdNetworkLoadBalancer
+      Port: {{ .PortELB }}
+      Protocol: TCP
+  {{- end }}
+
+  IngressNetworkLoadBalancer:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+    {{- if not $.Guest.ExistingVPC.ID }}
+    DependsOn:
+      - VPCGatewayAttachment
+    {{- end }}
+    Properties:
+      LoadBalancerAttributes:
+      - Key: load_balancing.cross_zone.enabled
+        Value: "true"
+      Name: {{ $v.IngressElbName }}
+      Scheme: {{ $v.IngressElbScheme }}
+      Subnets:
+      {{- range $s := $v.PublicSubnets }}
+        - !Ref {{ $s }}
+      {{- end }}
+      Type: network
+  {{- range $v.IngressElbPortsToOpen }}
+  {{ .TargetGroupResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::TargetGroup
+    Properties:
+      HealthCheckIntervalSeconds: {{ $v.NLBHealthCheckInterval }}
+      HealthCheckProtocol: TCP
+      HealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      Port: {{ .PortInstance }}
+      Protocol: TCP
+      TargetGroupAttributes:
+      - Key: proxy_protocol_v2.enabled
+        Value: "true"
+      TargetType: instance
+      UnhealthyThresholdCount: {{ $v.NLBHealthCheckThreshold }}
+      VpcId: !Ref VPC
+  {{ .ListenerResourceName }}:
+    Type: AWS::ElasticLoadBalancingV2::Listener
+    Properties:
+      DefaultActions:
+      - TargetGroupArn: !Ref {{ .TargetGroupResourceName }}
+        Type: forward
+      LoadBalancerArn: !Ref IngressNetworkLoadBalancer
+      Port: {{ .PortELB }}
+      Protocol: TCP
+  {{- end }}
+{{- else }}
   ApiLoadBalancer:
     Type: AWS::ElasticLoadBalancing::LoadBalancer
+    {{- if not $.Guest.ExistingVPC.ID }}
     DependsOn:
       - VPCGatewayAttachment
+    {{- end }}
     Properties:
       ConnectionSettings:
         IdleTimeout: 1200
@@ -17,7 +147,9 @@ const LoadBalancers = `
         Timeout: {{ $v.ELBHealthCheckTimeout }}
         UnhealthyThreshold: {{ $v.ELBHealthCheckUnhealthyThreshold }}
       Instances:
-      - !Ref {{ $v.MasterInstanceResourceName }}
+      {{- range $i := $v.MasterInstanceResourceNames }}
+      - !Ref {{ $i }}
+      {{- end }}
       Listeners:
       {{ range $v.APIElbPortsToOpen}}
       - InstancePort: {{ .PortInstance }}
@@ -46,7 +178,9 @@ const LoadBalancers = `
         Timeout: {{ $v.ELBHealthCheckTimeout }}
         UnhealthyThreshold: {{ $v.ELBHealthCheckUnhealthyThreshold }}
       Instances:
-      - !Ref {{ $v.MasterInstanceResourceName }}
+      {{- range $i := $v.MasterInstanceResourceNames }}
+      - !Ref {{ $i }}
+      {{- end }}
       Listeners:
       {{ range $v.EtcdElbPortsToOpen}}
       - InstancePort: {{ .PortInstance }}
@@ -65,8 +199,10 @@ const LoadBalancers = `
 
   IngressLoadBalancer:
     Type: AWS::ElasticLoadBalancing::LoadBalancer
+    {{- if not $.Guest.ExistingVPC.ID }}
     DependsOn:
       - VPCGatewayAttachment
+    {{- end }}
     Properties:
       ConnectionSettings:
         IdleTimeout: 60
@@ -101,5 +237,6 @@ const LoadBalancers = `
       {{- range $s := $v.PublicSubnets }}
         - !Ref {{ $s }}
       {{end}}
+{{- end }}
 {{end}}
 `
@@ -10,6 +10,7 @@ Parameters:
   VersionBundleVersionParameter:
     Type: String
     Description: Sets the VersionBundleVersion used to generate the template.
+  {{template "existing_vpc" .}}
 Resources:
   {{template "vpc" .}}
   {{template "iam_policies" .}}
@@ -20,7 +21,7 @@ Resources:
   {{template "nat_gateway" .}}
   {{template "instance" .}}
   {{template "load_balancers" .}}
-  {{template "launch_configuration" .}}
+  {{template "launch_template" .}}
   {{template "lifecycle_hooks" .}}
   {{template "autoscaling_group" .}}
   {{template "record_sets" .}}
@@ -4,32 +4,63 @@ const Outputs = `
 {{define "outputs"}}
   DockerVolumeResourceName:
     Value: {{ .Guest.Outputs.Master.DockerVolume.ResourceName }}
+  MasterAvailabilityZones:
+    Value: {{ .Guest.Outputs.Master.AvailabilityZones }}
+  MasterDockerVolume:
+    Value: {{ .Guest.Outputs.Master.DockerVolume.Volume }}
   {{ if .Guest.Outputs.Route53Enabled }}
   HostedZoneNameServers:
     Value: !Join [ ',', !GetAtt 'HostedZone.NameServers' ]
   {{ end }}
+  {{- if .Guest.Outputs.Master.EtcdRestoreSnapshot }}
+  MasterEtcdRestoreSnapshot:
+    Value: {{ .Guest.Outputs.Master.EtcdRestoreSnapshot }}
+  {{- end }}
+  MasterEtcdVolume:
+    Value: {{ .Guest.Outputs.Master.EtcdVolume.Volume }}
   MasterImageID:
     Value: {{ .Guest.Outputs.Master.ImageID }}
   MasterInstanceResourceName:
     Value: {{ .Guest.Outputs.Master.Instance.ResourceName }}
   MasterInstanceType:
     Value: {{ .Guest.Outputs.Master.Instance.Type }}
+  MasterLogVolume:
+    Value: {{ .Guest.Outputs.Master.LogVolume.Volume }}
   MasterCloudConfigVersion:
     Value: {{ .Guest.Outputs.Master.CloudConfig.Version }}
   VPCID:
     Value: !Ref VPC
+  {{- if .Guest.Outputs.TransitGatewayAttached }}
+  TransitGatewayAttachmentID:
+    Value: !Ref TransitGatewayAttachment
+  {{- else }}
   VPCPeeringConnectionID:
     Value: !Ref VPCPeeringConnection
-  WorkerASGName:
-    Value: !Ref {{ .Guest.Outputs.Worker.ASG.Ref }}
-  WorkerDockerVolumeSizeGB:
-    Value: {{ .Guest.Outputs.Worker.DockerVolumeSizeGB }}
+  {{- end }}
+  {{- range $p := .Guest.Outputs.Worker.NodePools }}
+  WorkerASGName{{ $p.OutputKeySuffix }}:
+    Value: !Ref {{ $p.ASG.Ref }}
+  WorkerDockerVolumeSizeGB{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.DockerVolumeSizeGB }}
+  WorkerInstanceType{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.InstanceType }}
+  WorkerInstanceTypes{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.InstanceTypes }}
+  WorkerKubeletVolumeSizeGB{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.KubeletVolumeSizeGB }}
+  WorkerOnDemandBaseCapacity{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.OnDemandBaseCapacity }}
+  WorkerSpotPercentage{{ $p.OutputKeySuffix }}:
+    Value: {{ $p.SpotPercentage }}
+  {{- end }}
   WorkerImageID:
     Value: {{ .Guest.Outputs.Worker.ImageID }}
-  WorkerInstanceType:
-    Value: {{ .Guest.Outputs.Worker.InstanceType }}
   WorkerCloudConfigVersion:
     Value: {{ .Guest.Outputs.Worker.CloudConfig.Version }}
+  WorkerVolumeEncryption:
+    Value: {{ .Guest.Outputs.Worker.VolumeEncryption }}
+  WorkerVolumeType:
+    Value: {{ .Guest.Outputs.Worker.VolumeType }}
   VersionBundleVersion:
     Value:
       Ref: VersionBundleVersionParameter
@@ -12,8 +12,13 @@ const RecordSets = `
     Type: AWS::Route53::RecordSet
     Properties:
       AliasTarget:
+        {{- if $v.IsNetworkLoadBalancer }}
+        DNSName: !GetAtt ApiNetworkLoadBalancer.DNSName
+        HostedZoneId: !GetAtt ApiNetworkLoadBalancer.CanonicalHostedZoneID
+        {{- else }}
         DNSName: !GetAtt ApiLoadBalancer.DNSName
         HostedZoneId: !GetAtt ApiLoadBalancer.CanonicalHostedZoneNameID
+        {{- end }}
         EvaluateTargetHealth: false
       Name: 'api.{{ $v.ClusterID }}.k8s.{{ $v.BaseDomain }}.'
       HostedZoneId: !Ref 'HostedZone'
@@ -22,18 +27,39 @@ const RecordSets = `
     Type: AWS::Route53::RecordSet
     Properties:
       AliasTarget:
+        {{- if $v.IsNetworkLoadBalancer }}
+        DNSName: !GetAtt EtcdNetworkLoadBalancer.DNSName
+        HostedZoneId: !GetAtt EtcdNetworkLoadBalancer.CanonicalHostedZoneID
+        {{- else }}
         DNSName: !GetAtt EtcdLoadBalancer.DNSName
         HostedZoneId: !GetAtt EtcdLoadBalancer.CanonicalHostedZoneNameID
+        {{- end }}
         EvaluateTargetHealth: false
       Name: '{{ $v.EtcdDomain }}.'
       HostedZoneId: !Ref 'HostedZone'
       Type: A
+  {{- range $m := $v.EtcdMembers }}
+  {{ $m.ResourceName }}:
+    Type: AWS::Route53::RecordSet
+    Properties:
+      Name: '{{ $m.Domain }}.'
+      HostedZoneId: !Ref 'HostedZone'
+      TTL: '60'
+      Type: A
+      ResourceRecords:
+        - !GetAtt {{ $m.InstanceResourceName }}.PrivateIp
+  {{- end }}
   IngressRecordSet:
     Type: AWS::Route53::RecordSet
     Properties:
       AliasTarget:
+        {{- if $v.IsNetworkLoadBalancer }}
+        DNSName: !GetAtt IngressNetworkLoadBalancer.DNSName
+        HostedZoneId: !GetAtt IngressNetworkLoadBalancer.CanonicalHostedZoneID
+        {{- else }}
         DNSName: !GetAtt IngressLoadBalancer.DNSName
         HostedZoneId: !GetAtt IngressLoadBalancer.CanonicalHostedZoneNameID
+        {{- end }}
         EvaluateTargetHealth: false
       Name: 'ingress.{{ $v.ClusterID }}.k8s.{{ $v.BaseDomain }}.'
       HostedZoneId: !Ref 'HostedZone'
@@ -3,6 +3,27 @@ package tccp
 const RouteTables = `
 {{ define "route_tables" }}
 {{- $v := .Guest.RouteTables }}
+{{- if .Guest.ExistingVPC.ID }}
+  {{- range $v.ExistingPrivateRouteTables }}
+  {{- if $v.TransitGatewayID }}
+  {{ .TransitGatewayRouteName }}:
+    Type: AWS::EC2::Route
+    DependsOn: TransitGatewayAttachment
+    Properties:
+      RouteTableId: {{ .ID }}
+      DestinationCidrBlock: {{ $v.HostClusterCIDR }}
+      TransitGatewayId: {{ $v.TransitGatewayID }}
+  {{- else }}
+  {{ .VPCPeeringRouteName }}:
+    Type: AWS::EC2::Route
+    Properties:
+      RouteTableId: {{ .ID }}
+      DestinationCidrBlock: {{ $v.HostClusterCIDR }}
+      VpcPeeringConnectionId:
+        Ref: "VPCPeeringConnection"
+  {{- end }}
+  {{ end }}
+{{- else }}
   {{ $v.PublicRouteTableName.ResourceName }}:
     Type: AWS::EC2::RouteTable
     Properties:
@@ -20,6 +41,15 @@ const RouteTables = `
       - Key: Name
         Value: {{ .TagName }}
 
+  {{- if $v.TransitGatewayID }}
+  {{ .TransitGatewayRouteName }}:
+    Type: AWS::EC2::Route
+    DependsOn: TransitGatewayAttachment
+    Properties:
+      RouteTableId: !Ref {{ .ResourceName }}
+      DestinationCidrBlock: {{ $v.HostClusterCIDR }}
+      TransitGatewayId: {{ $v.TransitGatewayID }}
+  {{- else }}
   {{ .VPCPeeringRouteName }}:
     Type: AWS::EC2::Route
     Properties:
@@ -27,6 +57,8 @@ const RouteTables = `
       DestinationCidrBlock: {{ $v.HostClusterCIDR }}
       VpcPeeringConnectionId:
         Ref: "VPCPeeringConnection"
+  {{- end }}
   {{ end }}
+{{- end }}
 {{ end }}
 `
@@ -144,6 +144,7 @@ const SecurityGroups = `
       ToPort: -1
       SourceSecurityGroupId: !Ref MasterSecurityGroup
 
+  {{- if not $.Guest.ExistingVPC.ID }}
   VPCDefaultSecurityGroupEgress:
     Type: AWS::EC2::SecurityGroupEgress
     Properties:
@@ -151,5 +152,6 @@ const SecurityGroups = `
       Description: "Allow outbound traffic from loopback address."
       IpProtocol: -1
       CidrIp: 127.0.0.1/32
+  {{- end }}
 {{ end }}
 `
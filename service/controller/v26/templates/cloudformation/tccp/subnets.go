@@ -6,6 +6,9 @@ const Subnets = `
   {{- range $v.PublicSubnets }}
   {{ .Name }}:
     Type: AWS::EC2::Subnet
+    {{- if .DependsOn }}
+    DependsOn: {{ .DependsOn }}
+    {{- end }}
     Properties:
       AvailabilityZone: {{ .AvailabilityZone }}
       CidrBlock: {{ .CIDR }}
@@ -28,6 +31,9 @@ const Subnets = `
   {{- range $v.PrivateSubnets }}
   {{ .Name }}:
     Type: AWS::EC2::Subnet
+    {{- if .DependsOn }}
+    DependsOn: {{ .DependsOn }}
+    {{- end }}
     Properties:
       AvailabilityZone: {{ .AvailabilityZone }}
       CidrBlock: {{ .CIDR }}
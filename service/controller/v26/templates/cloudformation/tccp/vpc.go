@@ -3,6 +3,7 @@ package tccp
 const VPC = `
 {{define "vpc"}}
 {{- $v := .Guest.VPC }}
+{{- if not .Guest.ExistingVPC.ID }}
   VPC:
     Type: AWS::EC2::VPC
     Properties:
@@ -14,6 +15,28 @@ const VPC = `
         Value: {{ $v.ClusterID }}
       - Key: Installation
         Value: {{ $v.InstallationName }}
+  {{- range $v.SecondaryCidrBlocks }}
+  {{ .Name }}:
+    Type: AWS::EC2::VPCCidrBlock
+    Properties:
+      CidrBlock: {{ .CidrBlock }}
+      VpcId: !Ref VPC
+  {{- end }}
+{{- end }}
+{{- if $v.TransitGatewayID }}
+  TransitGatewayAttachment:
+    Type: AWS::EC2::TransitGatewayAttachment
+    Properties:
+      SubnetIds:
+        {{- range $v.PrivateSubnetNames }}
+        - !Ref {{ . }}
+        {{- end }}
+      TransitGatewayId: {{ $v.TransitGatewayID }}
+      VpcId: !Ref VPC
+      Tags:
+        - Key: Name
+          Value: {{ $v.ClusterID }}
+{{- else }}
   VPCPeeringConnection:
     Type: 'AWS::EC2::VPCPeeringConnection'
     Properties:
@@ -24,6 +47,8 @@ const VPC = `
       Tags:
         - Key: Name
           Value: {{ $v.ClusterID }}
+{{- end }}
+{{- if not .Guest.ExistingVPC.ID }}
   VPCS3Endpoint:
     Type: 'AWS::EC2::VPCEndpoint'
     Properties:
@@ -46,5 +71,6 @@ const VPC = `
             Effect: "Allow"
             Action: "s3:*"
             Resource: "arn:{{ $v.RegionARN }}:s3:::*/*"
+{{- end }}
 {{end}}
 `
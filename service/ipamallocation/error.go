@@ -1,7 +1,16 @@
-package service
+package ipamallocation
 
 import "github.com/giantswarm/microerror"
 
+var alreadyAllocatedError = &microerror.Error{
+	Kind: "alreadyAllocatedError",
+}
+
+// IsAlreadyAllocated asserts alreadyAllocatedError.
+func IsAlreadyAllocated(err error) bool {
+	return microerror.Cause(err) == alreadyAllocatedError
+}
+
 var invalidConfigError = &microerror.Error{
 	Kind: "invalidConfigError",
 }
@@ -19,12 +28,3 @@ var notFoundError = &microerror.Error{
 func IsNotFound(err error) bool {
 	return microerror.Cause(err) == notFoundError
 }
-
-var wrongTypeError = &microerror.Error{
-	Kind: "wrongTypeError",
-}
-
-// IsWrongTypeError asserts wrongTypeError.
-func IsWrongTypeError(err error) bool {
-	return microerror.Cause(err) == wrongTypeError
-}
@@ -0,0 +1,226 @@
+// Package ipamallocation manages the IPAMAllocation custom resources which
+// lock network segments allocated for tenant clusters. The custom resources
+// are shared by the ipam resources of all controller versions.
+package ipamallocation
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
+	"github.com/giantswarm/microerror"
+	"github.com/giantswarm/micrologger"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// labelCluster is the label the IPAMAllocation custom resources carry the
+	// ID of their tenant cluster in.
+	labelCluster = "giantswarm.io/cluster"
+)
+
+type Config struct {
+	G8sClient versioned.Interface
+	Logger    micrologger.Logger
+}
+
+type IPAMAllocation struct {
+	g8sClient versioned.Interface
+	logger    micrologger.Logger
+}
+
+func New(config Config) (*IPAMAllocation, error) {
+	if config.G8sClient == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.G8sClient must not be empty", config)
+	}
+	if config.Logger == nil {
+		return nil, microerror.Maskf(invalidConfigError, "%T.Logger must not be empty", config)
+	}
+
+	a := &IPAMAllocation{
+		g8sClient: config.G8sClient,
+		logger:    config.Logger,
+	}
+
+	return a, nil
+}
+
+func (a *IPAMAllocation) Allocations(ctx context.Context) ([]net.IPNet, error) {
+	list, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []net.IPNet
+	for _, i := range list.Items {
+		_, n, err := net.ParseCIDR(i.Spec.CIDR)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		subnets = append(subnets, *n)
+	}
+
+	return subnets, nil
+}
+
+func (a *IPAMAllocation) List(ctx context.Context, clusterID string) ([]net.IPNet, error) {
+	list, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().List(listOptions(clusterID))
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []net.IPNet
+	for _, i := range list.Items {
+		_, n, err := net.ParseCIDR(i.Spec.CIDR)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		subnets = append(subnets, *n)
+	}
+
+	return subnets, nil
+}
+
+func (a *IPAMAllocation) Lookup(ctx context.Context, clusterID string) (net.IPNet, error) {
+	list, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().List(listOptions(clusterID))
+	if err != nil {
+		return net.IPNet{}, microerror.Mask(err)
+	}
+
+	if len(list.Items) == 0 {
+		return net.IPNet{}, microerror.Maskf(notFoundError, "IPAM allocation for tenant cluster %#q", clusterID)
+	}
+
+	_, n, err := net.ParseCIDR(list.Items[0].Spec.CIDR)
+	if err != nil {
+		return net.IPNet{}, microerror.Mask(err)
+	}
+
+	return *n, nil
+}
+
+func (a *IPAMAllocation) Release(ctx context.Context, clusterID string) error {
+	list, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().List(listOptions(clusterID))
+	if err != nil {
+		return microerror.Mask(err)
+	}
+
+	for _, i := range list.Items {
+		a.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("releasing IPAM allocation of subnet %#q", i.Spec.CIDR))
+
+		err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().Delete(i.GetName(), &metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			// fall through
+		} else if err != nil {
+			return microerror.Mask(err)
+		}
+
+		a.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("released IPAM allocation of subnet %#q", i.Spec.CIDR))
+	}
+
+	return nil
+}
+
+// Reserve creates the IPAMAllocation custom resource of the given network
+// segment. Its name is derived from the network segment, so that only one
+// IPAMAllocation custom resource can exist for any network segment. Creating
+// the custom resource is atomic and thus acts as a lock across concurrent
+// reconciliations and controller versions. The lock only covers network
+// segments of the same size though. Network segments of different sizes may
+// overlap, e.g. when tenant clusters use different subnet sizes. So once
+// created, the IPAMAllocation custom resource is checked against all other
+// IPAMAllocation custom resources and released again in case it overlaps with
+// any of them. Tenant clusters reserving overlapping network segments
+// concurrently might then both fail and have to try other network segments.
+func (a *IPAMAllocation) Reserve(ctx context.Context, clusterID string, subnet net.IPNet) error {
+	i := &v1alpha1.IPAMAllocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: Name(subnet),
+			Labels: map[string]string{
+				labelCluster: clusterID,
+			},
+		},
+		Spec: v1alpha1.IPAMAllocationSpec{
+			CIDR:      subnet.String(),
+			ClusterID: clusterID,
+		},
+	}
+
+	_, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().Create(i)
+	if apierrors.IsAlreadyExists(err) {
+		current, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().Get(Name(subnet), metav1.GetOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		// The tenant cluster might have reserved the network segment in a
+		// previous reconciliation which failed before it could finish the
+		// allocation.
+		if current.Spec.ClusterID == clusterID {
+			return nil
+		}
+
+		return microerror.Maskf(alreadyAllocatedError, "subnet %#q is allocated for tenant cluster %#q", subnet.String(), current.Spec.ClusterID)
+	} else if err != nil {
+		return microerror.Mask(err)
+	}
+
+	{
+		list, err := a.g8sClient.ProviderV1alpha1().IPAMAllocations().List(metav1.ListOptions{})
+		if err != nil {
+			return microerror.Mask(err)
+		}
+
+		for _, other := range list.Items {
+			if other.GetName() == Name(subnet) {
+				continue
+			}
+
+			_, n, err := net.ParseCIDR(other.Spec.CIDR)
+			if err != nil {
+				return microerror.Mask(err)
+			}
+
+			if !overlaps(subnet, *n) {
+				continue
+			}
+
+			a.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("releasing IPAM allocation of subnet %#q overlapping with subnet %#q", subnet.String(), n.String()))
+
+			err = a.g8sClient.ProviderV1alpha1().IPAMAllocations().Delete(Name(subnet), &metav1.DeleteOptions{})
+			if apierrors.IsNotFound(err) {
+				// fall through
+			} else if err != nil {
+				return microerror.Mask(err)
+			}
+
+			a.logger.LogCtx(ctx, "level", "debug", "message", fmt.Sprintf("released IPAM allocation of subnet %#q overlapping with subnet %#q", subnet.String(), n.String()))
+
+			return microerror.Maskf(alreadyAllocatedError, "subnet %#q overlaps with subnet %#q allocated for tenant cluster %#q", subnet.String(), n.String(), other.Spec.ClusterID)
+		}
+	}
+
+	return nil
+}
+
+// Name returns the name of the IPAMAllocation custom resource of the given
+// network segment, e.g. 10.1.0.0-24 for 10.1.0.0/24.
+func Name(subnet net.IPNet) string {
+	return strings.Replace(subnet.String(), "/", "-", 1)
+}
+
+func overlaps(a, b net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func listOptions(clusterID string) metav1.ListOptions {
+	return metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", labelCluster, clusterID),
+	}
+}
@@ -0,0 +1,126 @@
+package ipamallocation
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/fake"
+	"github.com/giantswarm/micrologger/microloggertest"
+)
+
+func mustParseCIDR(val string) net.IPNet {
+	_, n, err := net.ParseCIDR(val)
+	if err != nil {
+		panic(err)
+	}
+
+	return *n
+}
+
+func Test_IPAMAllocation_Reserve(t *testing.T) {
+	ctx := context.Background()
+
+	var a *IPAMAllocation
+	{
+		c := Config{
+			G8sClient: fake.NewSimpleClientset(),
+			Logger:    microloggertest.New(),
+		}
+
+		var err error
+		a, err = New(c)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	subnet := mustParseCIDR("10.1.0.0/24")
+
+	err := a.Reserve(ctx, "al9qy", subnet)
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+
+	// Reserving the same subnet again for the same tenant cluster succeeds.
+	err = a.Reserve(ctx, "al9qy", subnet)
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+
+	// Reserving the same subnet for another tenant cluster fails.
+	err = a.Reserve(ctx, "w7utg", subnet)
+	if !IsAlreadyAllocated(err) {
+		t.Fatalf("expected alreadyAllocatedError, got %#v", err)
+	}
+
+	// Reserving a bigger subnet overlapping with the reserved subnet for another
+	// tenant cluster fails and does not leave its reservation behind.
+	err = a.Reserve(ctx, "w7utg", mustParseCIDR("10.1.0.0/16"))
+	if !IsAlreadyAllocated(err) {
+		t.Fatalf("expected alreadyAllocatedError, got %#v", err)
+	}
+	_, err = a.Lookup(ctx, "w7utg")
+	if !IsNotFound(err) {
+		t.Fatalf("expected notFoundError, got %#v", err)
+	}
+
+	// Reserving a smaller subnet overlapping with the reserved subnet for
+	// another tenant cluster fails as well.
+	err = a.Reserve(ctx, "w7utg", mustParseCIDR("10.1.0.128/25"))
+	if !IsAlreadyAllocated(err) {
+		t.Fatalf("expected alreadyAllocatedError, got %#v", err)
+	}
+
+	err = a.Reserve(ctx, "w7utg", mustParseCIDR("10.1.1.0/24"))
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+
+	allocations, err := a.Allocations(ctx)
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+	if len(allocations) != 2 {
+		t.Fatalf("expected %d allocations, got %d", 2, len(allocations))
+	}
+
+	n, err := a.Lookup(ctx, "al9qy")
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+	if n.String() != subnet.String() {
+		t.Fatalf("expected %#q, got %#q", subnet.String(), n.String())
+	}
+
+	err = a.Release(ctx, "al9qy")
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+
+	_, err = a.Lookup(ctx, "al9qy")
+	if !IsNotFound(err) {
+		t.Fatalf("expected notFoundError, got %#v", err)
+	}
+
+	// The released subnet can be reserved by another tenant cluster.
+	err = a.Reserve(ctx, "w7utg", subnet)
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+
+	subnets, err := a.List(ctx, "w7utg")
+	if err != nil {
+		t.Fatalf("expected nil, got %#v", err)
+	}
+	if len(subnets) != 2 {
+		t.Fatalf("expected %d subnets, got %d", 2, len(subnets))
+	}
+}
+
+func Test_Name(t *testing.T) {
+	name := Name(mustParseCIDR("10.1.0.0/24"))
+	if name != "10.1.0.0-24" {
+		t.Fatalf("expected %#q, got %#q", "10.1.0.0-24", name)
+	}
+}
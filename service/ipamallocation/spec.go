@@ -0,0 +1,27 @@
+package ipamallocation
+
+import (
+	"context"
+	"net"
+)
+
+type Interface interface {
+	// Allocations returns the network segments allocated for all tenant
+	// clusters.
+	Allocations(ctx context.Context) ([]net.IPNet, error)
+	// List returns all network segments allocated for the given tenant cluster.
+	// Tenant clusters may have secondary network segments allocated when adding
+	// availability zones.
+	List(ctx context.Context, clusterID string) ([]net.IPNet, error)
+	// Lookup returns the network segment allocated for the given tenant
+	// cluster. It returns an error matched by IsNotFound in case there is none.
+	Lookup(ctx context.Context, clusterID string) (net.IPNet, error)
+	// Release frees all network segments allocated for the given tenant
+	// cluster.
+	Release(ctx context.Context, clusterID string) error
+	// Reserve allocates the given network segment for the given tenant cluster.
+	// It returns an error matched by IsAlreadyAllocated in case the network
+	// segment or any network segment overlapping with it is allocated for
+	// another tenant cluster already.
+	Reserve(ctx context.Context, clusterID string, subnet net.IPNet) error
+}
@@ -0,0 +1,87 @@
+// Package ipamsubnet finds the network segments already in use, which is
+// shared by the ipam resources and the ipam collector.
+package ipamsubnet
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned"
+	"github.com/giantswarm/microerror"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// tagCluster is the tag the subnets of tenant cluster VPCs carry the ID of
+	// their tenant cluster in.
+	tagCluster = "giantswarm.io/cluster"
+)
+
+// AWSConfigSubnets returns the primary and secondary network segments written
+// to the status of all AWSConfig CRs.
+func AWSConfigSubnets(g8sClient versioned.Interface) ([]ClusterSubnet, error) {
+	awsConfigs, err := g8sClient.ProviderV1alpha1().AWSConfigs(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []ClusterSubnet
+	for _, awsConfig := range awsConfigs.Items {
+		cidrs := awsConfig.Status.Cluster.Network.SecondaryCIDRs
+		if awsConfig.Status.Cluster.Network.CIDR != "" {
+			cidrs = append([]string{awsConfig.Status.Cluster.Network.CIDR}, cidrs...)
+		}
+
+		for _, cidr := range cidrs {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, microerror.Mask(err)
+			}
+
+			s := ClusterSubnet{
+				ClusterID: awsConfig.Spec.Cluster.ID,
+				Subnet:    *n,
+				VPCID:     awsConfig.Spec.AWS.VPC.ID,
+			}
+
+			subnets = append(subnets, s)
+		}
+	}
+
+	return subnets, nil
+}
+
+// VPCSubnets returns the subnets of all VPCs of the AWS account the given EC2
+// client is configured for.
+func VPCSubnets(ec2Client EC2) ([]VPCSubnet, error) {
+	o, err := ec2Client.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+	if err != nil {
+		return nil, microerror.Mask(err)
+	}
+
+	var subnets []VPCSubnet
+	for _, subnet := range o.Subnets {
+		_, n, err := net.ParseCIDR(*subnet.CidrBlock)
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+
+		var cluster string
+		for _, tag := range subnet.Tags {
+			if *tag.Key == tagCluster {
+				cluster = *tag.Value
+			}
+		}
+
+		s := VPCSubnet{
+			ClusterID: cluster,
+			ID:        *subnet.SubnetId,
+			Subnet:    *n,
+			VPCID:     *subnet.VpcId,
+		}
+
+		subnets = append(subnets, s)
+	}
+
+	return subnets, nil
+}
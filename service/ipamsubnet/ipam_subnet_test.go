@@ -0,0 +1,117 @@
+package ipamsubnet
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/giantswarm/apiextensions/pkg/apis/provider/v1alpha1"
+	"github.com/giantswarm/apiextensions/pkg/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type ec2ClientMock struct {
+	subnets []*ec2.Subnet
+}
+
+func (e *ec2ClientMock) DescribeSubnets(*ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error) {
+	return &ec2.DescribeSubnetsOutput{Subnets: e.subnets}, nil
+}
+
+func Test_AWSConfigSubnets(t *testing.T) {
+	cr := &v1alpha1.AWSConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "al9qy",
+			Namespace: "default",
+		},
+		Spec: v1alpha1.AWSConfigSpec{
+			AWS: v1alpha1.AWSConfigSpecAWS{
+				VPC: v1alpha1.AWSConfigSpecAWSVPC{
+					ID: "vpc-existing",
+				},
+			},
+			Cluster: v1alpha1.Cluster{
+				ID: "al9qy",
+			},
+		},
+		Status: v1alpha1.AWSConfigStatus{
+			Cluster: v1alpha1.StatusCluster{
+				Network: v1alpha1.StatusClusterNetwork{
+					CIDR:           "10.1.0.0/24",
+					SecondaryCIDRs: []string{"10.1.1.0/24"},
+				},
+			},
+		},
+	}
+	pending := &v1alpha1.AWSConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "p1x6m",
+			Namespace: "default",
+		},
+	}
+
+	subnets, err := AWSConfigSubnets(fake.NewSimpleClientset(cr, pending))
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	expected := []string{"10.1.0.0/24", "10.1.1.0/24"}
+	if len(subnets) != len(expected) {
+		t.Fatalf("expected %d subnets got %d", len(expected), len(subnets))
+	}
+	for i, s := range subnets {
+		if s.Subnet.String() != expected[i] {
+			t.Fatalf("expected %#q got %#q", expected[i], s.Subnet.String())
+		}
+		if s.ClusterID != "al9qy" {
+			t.Fatalf("expected %#q got %#q", "al9qy", s.ClusterID)
+		}
+		if s.VPCID != "vpc-existing" {
+			t.Fatalf("expected %#q got %#q", "vpc-existing", s.VPCID)
+		}
+	}
+}
+
+func Test_VPCSubnets(t *testing.T) {
+	ec2Client := &ec2ClientMock{
+		subnets: []*ec2.Subnet{
+			{
+				CidrBlock: aws.String("10.1.0.0/25"),
+				SubnetId:  aws.String("subnet-tenant"),
+				Tags: []*ec2.Tag{
+					{
+						Key:   aws.String(tagCluster),
+						Value: aws.String("al9qy"),
+					},
+				},
+				VpcId: aws.String("vpc-tenant"),
+			},
+			{
+				CidrBlock: aws.String("172.31.0.0/20"),
+				SubnetId:  aws.String("subnet-default"),
+				VpcId:     aws.String("vpc-default"),
+			},
+		},
+	}
+
+	subnets, err := VPCSubnets(ec2Client)
+	if err != nil {
+		t.Fatalf("expected %#v got %#v", nil, err)
+	}
+
+	expected := []VPCSubnet{
+		{ClusterID: "al9qy", ID: "subnet-tenant", VPCID: "vpc-tenant"},
+		{ClusterID: "", ID: "subnet-default", VPCID: "vpc-default"},
+	}
+	if len(subnets) != len(expected) {
+		t.Fatalf("expected %d subnets got %d", len(expected), len(subnets))
+	}
+	for i, s := range subnets {
+		if s.ClusterID != expected[i].ClusterID || s.ID != expected[i].ID || s.VPCID != expected[i].VPCID {
+			t.Fatalf("expected %#v got %#v", expected[i], s)
+		}
+		if s.Subnet.String() != *ec2Client.subnets[i].CidrBlock {
+			t.Fatalf("expected %#q got %#q", *ec2Client.subnets[i].CidrBlock, s.Subnet.String())
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package ipamsubnet
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+type EC2 interface {
+	DescribeSubnets(input *ec2.DescribeSubnetsInput) (*ec2.DescribeSubnetsOutput, error)
+}
+
+// ClusterSubnet is a network segment of a tenant cluster as written to the
+// status of its AWSConfig CR.
+type ClusterSubnet struct {
+	ClusterID string
+	Subnet    net.IPNet
+	VPCID     string
+}
+
+// VPCSubnet is a subnet of any VPC of an AWS account. ClusterID is empty for
+// subnets not tagged with the ID of a tenant cluster.
+type VPCSubnet struct {
+	ClusterID string
+	ID        string
+	Subnet    net.IPNet
+	VPCID     string
+}
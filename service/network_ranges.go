@@ -0,0 +1,39 @@
+package service
+
+import (
+	"net"
+	"strings"
+
+	"github.com/giantswarm/microerror"
+)
+
+// parseNetworkRanges parses the named IPAM network ranges of the installation
+// given as comma separated list of name=CIDR pairs, e.g.
+// "production=10.1.0.0/16,staging=10.2.0.0/16".
+func parseNetworkRanges(ranges string) (map[string]net.IPNet, error) {
+	if strings.TrimSpace(ranges) == "" {
+		return nil, nil
+	}
+
+	networkRanges := map[string]net.IPNet{}
+	for _, r := range strings.Split(ranges, ",") {
+		pair := strings.SplitN(strings.TrimSpace(r), "=", 2)
+		if len(pair) != 2 || pair[0] == "" {
+			return nil, microerror.Maskf(invalidConfigError, "network range %#q must be given as name=CIDR", r)
+		}
+
+		name := strings.TrimSpace(pair[0])
+		if _, ok := networkRanges[name]; ok {
+			return nil, microerror.Maskf(invalidConfigError, "network range %#q must not be given more than once", name)
+		}
+
+		_, n, err := net.ParseCIDR(strings.TrimSpace(pair[1]))
+		if err != nil {
+			return nil, microerror.Maskf(invalidConfigError, "network range %#q must have a valid CIDR: %s", name, err)
+		}
+
+		networkRanges[name] = *n
+	}
+
+	return networkRanges, nil
+}
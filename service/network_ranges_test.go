@@ -0,0 +1,80 @@
+package service
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func Test_parseNetworkRanges(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ranges         string
+		expectedRanges map[string]net.IPNet
+		errorMatcher   func(error) bool
+	}{
+		{
+			name:           "case 0: no network ranges",
+			ranges:         "",
+			expectedRanges: nil,
+			errorMatcher:   nil,
+		},
+		{
+			name:   "case 1: multiple network ranges",
+			ranges: "production=10.1.0.0/16, staging = 10.2.0.0/16",
+			expectedRanges: map[string]net.IPNet{
+				"production": mustParseCIDR("10.1.0.0/16"),
+				"staging":    mustParseCIDR("10.2.0.0/16"),
+			},
+			errorMatcher: nil,
+		},
+		{
+			name:           "case 2: missing name",
+			ranges:         "10.1.0.0/16",
+			expectedRanges: nil,
+			errorMatcher:   IsInvalidConfig,
+		},
+		{
+			name:           "case 3: invalid CIDR",
+			ranges:         "production=10.1.0.0",
+			expectedRanges: nil,
+			errorMatcher:   IsInvalidConfig,
+		},
+		{
+			name:           "case 4: duplicate name",
+			ranges:         "production=10.1.0.0/16,production=10.2.0.0/16",
+			expectedRanges: nil,
+			errorMatcher:   IsInvalidConfig,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ranges, err := parseNetworkRanges(tc.ranges)
+
+			switch {
+			case err == nil && tc.errorMatcher == nil:
+				// correct; carry on
+			case err != nil && tc.errorMatcher == nil:
+				t.Fatalf("error == %#v, want nil", err)
+			case err == nil && tc.errorMatcher != nil:
+				t.Fatalf("error == nil, want non-nil")
+			case !tc.errorMatcher(err):
+				t.Fatalf("error == %#v, want matching", err)
+			}
+
+			if !reflect.DeepEqual(ranges, tc.expectedRanges) {
+				t.Fatalf("ranges == %#v, want %#v", ranges, tc.expectedRanges)
+			}
+		})
+	}
+}
+
+func mustParseCIDR(cidr string) net.IPNet {
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return *n
+}
@@ -114,13 +114,23 @@ func New(config Config) (*Service, error) {
 		}
 	}
 
-	var clusterController *controller.Cluster
+	var ipamNetworkRange net.IPNet
+	var ipamNetworkRanges map[string]net.IPNet
 	{
-		_, ipamNetworkRange, err := net.ParseCIDR(config.Viper.GetString(config.Flag.Service.Installation.Guest.IPAM.Network.CIDR))
+		_, n, err := net.ParseCIDR(config.Viper.GetString(config.Flag.Service.Installation.Guest.IPAM.Network.CIDR))
+		if err != nil {
+			return nil, microerror.Mask(err)
+		}
+		ipamNetworkRange = *n
+
+		ipamNetworkRanges, err = parseNetworkRanges(config.Viper.GetString(config.Flag.Service.Installation.Guest.IPAM.Network.Ranges))
 		if err != nil {
 			return nil, microerror.Mask(err)
 		}
+	}
 
+	var clusterController *controller.Cluster
+	{
 		c := controller.ClusterConfig{
 			G8sClient:    g8sClient,
 			K8sClient:    k8sClient,
@@ -135,6 +145,7 @@ func New(config Config) (*Service, error) {
 			AdvancedMonitoringEC2: config.Viper.GetBool(config.Flag.Service.AWS.AdvancedMonitoringEC2),
 			DeleteLoggingBucket:   config.Viper.GetBool(config.Flag.Service.AWS.LoggingBucket.Delete),
 			EncrypterBackend:      config.Viper.GetString(config.Flag.Service.AWS.Encrypter),
+			EtcdBackupExpiration:  config.Viper.GetInt(config.Flag.Service.AWS.EtcdBackup.Expiration),
 			GuestAWSConfig: controller.ClusterConfigAWSConfig{
 				AccessKeyID:       config.Viper.GetString(config.Flag.Service.AWS.AccessKey.ID),
 				AccessKeySecret:   config.Viper.GetString(config.Flag.Service.AWS.AccessKey.Secret),
@@ -152,10 +163,16 @@ func New(config Config) (*Service, error) {
 				SessionToken:      config.Viper.GetString(config.Flag.Service.AWS.HostAccessKey.Session),
 				Region:            config.Viper.GetString(config.Flag.Service.AWS.Region),
 			},
-			IgnitionPath:     config.Viper.GetString(config.Flag.Service.Guest.Ignition.Path),
-			IncludeTags:      config.Viper.GetBool(config.Flag.Service.AWS.IncludeTags),
-			InstallationName: config.Viper.GetString(config.Flag.Service.Installation.Name),
-			IPAMNetworkRange: *ipamNetworkRange,
+			IgnitionPath: config.Viper.GetString(config.Flag.Service.Guest.Ignition.Path),
+			Image: controller.ClusterConfigImage{
+				Name:     config.Viper.GetString(config.Flag.Service.AWS.Image.Name),
+				Owner:    config.Viper.GetString(config.Flag.Service.AWS.Image.Owner),
+				Resolver: config.Viper.GetString(config.Flag.Service.AWS.Image.Resolver),
+			},
+			IncludeTags:       config.Viper.GetBool(config.Flag.Service.AWS.IncludeTags),
+			InstallationName:  config.Viper.GetString(config.Flag.Service.Installation.Name),
+			IPAMNetworkRange:  ipamNetworkRange,
+			IPAMNetworkRanges: ipamNetworkRanges,
 			OIDC: controller.ClusterConfigOIDC{
 				ClientID:      config.Viper.GetString(config.Flag.Service.Installation.Guest.Kubernetes.API.Auth.Provider.OIDC.ClientID),
 				IssuerURL:     config.Viper.GetString(config.Flag.Service.Installation.Guest.Kubernetes.API.Auth.Provider.OIDC.IssuerURL),
@@ -167,10 +184,16 @@ func New(config Config) (*Service, error) {
 			ProjectName:            config.ProjectName,
 			PubKeyFile:             config.Viper.GetString(config.Flag.Service.AWS.PubKeyFile),
 			RegistryDomain:         config.Viper.GetString(config.Flag.Service.RegistryDomain),
+			RollbackSkipResources:  config.Viper.GetStringSlice(config.Flag.Service.AWS.RollbackSkipResources),
 			Route53Enabled:         config.Viper.GetBool(config.Flag.Service.AWS.Route53.Enabled),
 			RouteTables:            config.Viper.GetString(config.Flag.Service.AWS.RouteTables),
 			SSOPublicKey:           config.Viper.GetString(config.Flag.Service.Guest.SSH.SSOPublicKey),
+			TransitGatewayID:       config.Viper.GetString(config.Flag.Service.AWS.TransitGateway.ID),
 			VaultAddress:           config.Viper.GetString(config.Flag.Service.AWS.VaultAddress),
+			VolumeSnapshot: controller.ClusterConfigVolumeSnapshot{
+				DockerVolume: config.Viper.GetBool(config.Flag.Service.AWS.VolumeSnapshot.DockerVolume),
+				Retention:    config.Viper.GetInt(config.Flag.Service.AWS.VolumeSnapshot.Retention),
+			},
 		}
 
 		clusterController, err = controller.NewCluster(c)
@@ -217,7 +240,10 @@ func New(config Config) (*Service, error) {
 			Logger:    config.Logger,
 
 			AWSConfig:             awsConfig,
+			GuestSubnetMaskBits:   config.Viper.GetInt(config.Flag.Service.Installation.Guest.IPAM.Network.SubnetMaskBits),
 			InstallationName:      config.Viper.GetString(config.Flag.Service.Installation.Name),
+			IPAMNetworkRange:      ipamNetworkRange,
+			IPAMNetworkRanges:     ipamNetworkRanges,
 			TrustedAdvisorEnabled: config.Viper.GetBool(config.Flag.Service.AWS.TrustedAdvisor.Enabled),
 		}
 
@@ -3,12 +3,7 @@ package service
 import (
 	"github.com/giantswarm/versionbundle"
 
-	"github.com/giantswarm/aws-operator/service/controller/v22"
-	"github.com/giantswarm/aws-operator/service/controller/v22patch1"
-	"github.com/giantswarm/aws-operator/service/controller/v23"
-	"github.com/giantswarm/aws-operator/service/controller/v24"
-	"github.com/giantswarm/aws-operator/service/controller/v25"
-	"github.com/giantswarm/aws-operator/service/controller/v26"
+	"github.com/giantswarm/aws-operator/service/controller"
 )
 
 // NewVersionBundles returns the array of version bundles defined for the
@@ -16,12 +11,9 @@ import (
 func NewVersionBundles() []versionbundle.Bundle {
 	var versionBundles []versionbundle.Bundle
 
-	versionBundles = append(versionBundles, v22.VersionBundle())
-	versionBundles = append(versionBundles, v22patch1.VersionBundle())
-	versionBundles = append(versionBundles, v23.VersionBundle())
-	versionBundles = append(versionBundles, v24.VersionBundle())
-	versionBundles = append(versionBundles, v25.VersionBundle())
-	versionBundles = append(versionBundles, v26.VersionBundle())
+	for _, b := range controller.Bundles() {
+		versionBundles = append(versionBundles, b.VersionBundle)
+	}
 
 	return versionBundles
 }